@@ -0,0 +1,144 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTileAnalytics handles GET /v1/analytics/tiles
+// Aggregates the per-tile counters of every round of a sport between startDate and endDate (inclusive),
+// so round designers can see which clues give the answer away
+// endDate defaults to the latest released round and startDate to MaxTileAnalyticsDays before it, or the first round
+// The counters come back with the per-sport query, so no round is read on its own
+func (s *Server) GetTileAnalytics(c *gin.Context) {
+	sport := c.Query(QueryParamSport)
+	if sport == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "sport parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if !IsValidSport(sport) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid sport '" + sport + "'",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	endDate := c.DefaultQuery(QueryParamEndDate, latestReleasedDate(c, time.Now()))
+	end, err := time.Parse(DateFormatYYYYMMDD, endDate)
+	if err != nil {
+		respondInvalidAnalyticsDate(c, endDate)
+		return
+	}
+	startDate := c.Query(QueryParamStartDate)
+	if startDate == "" {
+		startDate = max(end.AddDate(0, 0, 1-MaxTileAnalyticsDays).Format(DateFormatYYYYMMDD), FIRST_ROUND_DATE_STRING)
+	}
+	start, err := time.Parse(DateFormatYYYYMMDD, startDate)
+	if err != nil {
+		respondInvalidAnalyticsDate(c, startDate)
+		return
+	}
+	if start.After(end) || start.AddDate(0, 0, MaxTileAnalyticsDays).Before(end.AddDate(0, 0, 1)) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "startDate must not be after endDate, and the range can cover at most " + strconv.Itoa(MaxTileAnalyticsDays) + " days",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	rounds, err := s.db.GetRoundStatsBySport(c.Request.Context(), sport, startDate, endDate)
+	if err != nil {
+		respondAnalyticsDatabaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aggregateTileAnalytics(sport, startDate, endDate, rounds))
+}
+
+// respondInvalidAnalyticsDate writes the 400 response for a malformed startDate or endDate
+func respondInvalidAnalyticsDate(c *gin.Context, date string) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		JSONFieldError:     StatusBadRequest,
+		JSONFieldMessage:   "Invalid date '" + date + "'. Expected YYYY-MM-DD",
+		JSONFieldCode:      ErrorInvalidParameter,
+		JSONFieldTimestamp: time.Now(),
+	})
+}
+
+// respondAnalyticsDatabaseError writes the 500 response for a failed read while aggregating analytics
+func respondAnalyticsDatabaseError(c *gin.Context, err error) {
+	c.JSON(http.StatusInternalServerError, gin.H{
+		JSONFieldError:     StatusInternalServerError,
+		JSONFieldMessage:   "Failed to retrieve rounds: " + err.Error(),
+		JSONFieldCode:      ErrorDatabaseError,
+		JSONFieldTimestamp: time.Now(),
+	})
+}
+
+// aggregateTileAnalytics sums the tile counters of rounds and derives each tile's rates
+func aggregateTileAnalytics(sport, startDate, endDate string, rounds []*Round) *TileAnalytics {
+	analytics := &TileAnalytics{
+		Sport:     sport,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Rounds:    len(rounds),
+		Tiles:     make([]*TileAnalyticsItem, 0, len(tileRegistry)),
+	}
+
+	var flips, first, lastCorrect, lastIncorrect, results, scores map[string]int
+	for _, round := range rounds {
+		stats := &round.Stats.Stats
+		analytics.TotalPlays += stats.TotalPlays
+		analytics.CorrectCount += stats.CorrectCount
+		addTileCounts(&flips, stats.MostTileFlippedTracker)
+		addTileCounts(&first, stats.FirstTileFlippedTracker)
+		addTileCounts(&lastCorrect, stats.LastTileFlippedCorrectTracker)
+		addTileCounts(&lastIncorrect, stats.LastTileFlippedIncorrectTracker)
+		addTileCounts(&results, stats.TileFlippedResultsTracker)
+		addTileCounts(&scores, stats.TileFlippedScoreTracker)
+	}
+	analytics.MostCommonLastTileBeforeCorrect = findMostCommonTile(lastCorrect)
+
+	for _, tile := range AllTiles() {
+		item := &TileAnalyticsItem{
+			Tile:                 tile,
+			Flips:                flips[tile],
+			ResultsFlipped:       results[tile],
+			FirstFlipped:         first[tile],
+			LastFlippedCorrect:   lastCorrect[tile],
+			LastFlippedIncorrect: lastIncorrect[tile],
+		}
+		if last := item.LastFlippedCorrect + item.LastFlippedIncorrect; last > 0 {
+			item.LastFlippedSolveRate = float64(item.LastFlippedCorrect) * 100 / float64(last)
+		}
+		if item.ResultsFlipped > 0 {
+			item.AverageScore = float64(scores[tile]) / float64(item.ResultsFlipped)
+		}
+		analytics.Tiles = append(analytics.Tiles, item)
+	}
+
+	return analytics
+}
+
+// addTileCounts adds every count in src to the same tile in dst, creating dst if needed
+func addTileCounts(dst *map[string]int, src map[string]int) {
+	if *dst == nil {
+		*dst = map[string]int{}
+	}
+	for tile, count := range src {
+		(*dst)[tile] += count
+	}
+}
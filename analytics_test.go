@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestAggregateTileAnalytics(t *testing.T) {
+	first := &Round{Stats: RoundStats{Stats: Stats{}}}
+	for _, result := range []*Result{
+		{Score: 80, IsCorrect: true, FlippedTiles: []string{TileBio, TilePhoto}},
+		{Score: 0, IsCorrect: false, FlippedTiles: []string{TilePhoto}},
+	} {
+		updateStatsWithResult(&first.Stats.Stats, result)
+	}
+	second := &Round{Stats: RoundStats{Stats: Stats{}}}
+	updateStatsWithResult(&second.Stats.Stats, &Result{Score: 60, IsCorrect: true, FlippedTiles: []string{TileBio, TileInitials, TilePhoto}})
+
+	analytics := aggregateTileAnalytics(SportBasketball, "2026-02-08", "2026-03-01", []*Round{first, second})
+
+	if analytics.Rounds != 2 || analytics.TotalPlays != 3 || analytics.CorrectCount != 2 {
+		t.Errorf("totals = %d rounds, %d plays, %d correct, want 2, 3, 2", analytics.Rounds, analytics.TotalPlays, analytics.CorrectCount)
+	}
+	if analytics.MostCommonLastTileBeforeCorrect != TilePhoto {
+		t.Errorf("MostCommonLastTileBeforeCorrect = %q, want %q", analytics.MostCommonLastTileBeforeCorrect, TilePhoto)
+	}
+	if len(analytics.Tiles) != len(AllTiles()) {
+		t.Fatalf("got %d tiles, want %d", len(analytics.Tiles), len(AllTiles()))
+	}
+
+	tiles := map[string]*TileAnalyticsItem{}
+	for i, item := range analytics.Tiles {
+		if item.Tile != AllTiles()[i] {
+			t.Errorf("tiles[%d] = %s, want %s", i, item.Tile, AllTiles()[i])
+		}
+		tiles[item.Tile] = item
+	}
+
+	tests := []struct {
+		tile     string
+		expected TileAnalyticsItem
+	}{
+		{
+			tile: TilePhoto,
+			expected: TileAnalyticsItem{Tile: TilePhoto, Flips: 3, ResultsFlipped: 3, FirstFlipped: 1,
+				LastFlippedCorrect: 2, LastFlippedIncorrect: 1, LastFlippedSolveRate: 200.0 / 3, AverageScore: 140.0 / 3},
+		},
+		{
+			tile:     TileBio,
+			expected: TileAnalyticsItem{Tile: TileBio, Flips: 2, ResultsFlipped: 2, FirstFlipped: 2, AverageScore: 70},
+		},
+		{
+			tile:     TileNicknames,
+			expected: TileAnalyticsItem{Tile: TileNicknames},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tile, func(t *testing.T) {
+			if *tiles[tt.tile] != tt.expected {
+				t.Errorf("tile = %+v, want %+v", *tiles[tt.tile], tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetTileAnalytics(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+
+	for _, playDate := range []string{"2026-02-10", "2026-02-11", "2026-03-01"} {
+		if err := server.db.CreateRound(ctx, &Round{Sport: SportBasketball, PlayDate: playDate}); err != nil {
+			t.Fatalf("CreateRound() error = %v", err)
+		}
+		result := &Result{Score: 70, IsCorrect: true, FlippedTiles: []string{TileJerseyNumbers}}
+		if err := server.db.ApplyRoundResult(ctx, SportBasketball, playDate, result); err != nil {
+			t.Fatalf("ApplyRoundResult() error = %v", err)
+		}
+	}
+
+	w := performRequest(server.GetTileAnalytics, http.MethodGet, "/v1/analytics/tiles?sport=basketball&startDate=2026-02-01&endDate=2026-02-28", nil, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var analytics TileAnalytics
+	if err := json.NewDecoder(w.Body).Decode(&analytics); err != nil {
+		t.Fatalf("failed to decode analytics: %v", err)
+	}
+	if analytics.Rounds != 2 || analytics.MostCommonLastTileBeforeCorrect != TileJerseyNumbers {
+		t.Errorf("analytics = %+v, want 2 rounds ending on %s", analytics, TileJerseyNumbers)
+	}
+
+	// startDate defaults to the longest range ending on endDate, but not before the first round
+	defaultStartTests := []struct {
+		endDate       string
+		expectedStart string
+		expectedCount int
+	}{
+		{endDate: "2026-03-01", expectedStart: FIRST_ROUND_DATE_STRING, expectedCount: 3},
+		{endDate: "2027-03-01", expectedStart: "2026-03-01", expectedCount: 1},
+	}
+	for _, tt := range defaultStartTests {
+		w := performRequest(server.GetTileAnalytics, http.MethodGet, "/v1/analytics/tiles?sport=basketball&endDate="+tt.endDate, nil, "")
+		if err := json.NewDecoder(w.Body).Decode(&analytics); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("status = %d (%v), want %d", w.Code, err, http.StatusOK)
+		}
+		if analytics.StartDate != tt.expectedStart || analytics.Rounds != tt.expectedCount {
+			t.Errorf("endDate %s: startDate %s with %d rounds, want %s with %d", tt.endDate, analytics.StartDate, analytics.Rounds, tt.expectedStart, tt.expectedCount)
+		}
+	}
+
+	for _, query := range []string{
+		"",
+		"sport=hockey",
+		"sport=basketball&startDate=02/01/2026",
+		"sport=basketball&startDate=2026-03-02&endDate=2026-03-01",
+		"sport=basketball&startDate=2026-01-01&endDate=2027-01-02",
+	} {
+		if w := performRequest(server.GetTileAnalytics, http.MethodGet, "/v1/analytics/tiles?"+query, nil, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("analytics?%s status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
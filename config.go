@@ -20,19 +20,49 @@ func init() {
 
 // Config holds application configuration
 type Config struct {
-	DynamoDBEndpoint   string
-	RoundsTableName    string
-	UserStatsTableName string
-	AWSRegion          string
+	StorageBackend         string
+	DatabaseURL            string
+	DynamoDBEndpoint       string
+	RoundsTableName        string
+	UserStatsTableName     string
+	GameSessionsTableName  string
+	SubmissionsTableName   string
+	PlayHistoryTableName   string
+	LeaderboardTableName   string
+	LeaguesTableName       string
+	LeagueMembersTableName string
+	RoundEditsTableName    string
+	AWSRegion              string
+
+	// Which streaks archive plays count toward (see ArchiveStreakRuleNone)
+	ArchiveStreakRule string
+
+	// Schema migrations
+	SchemaMigrationsTableName string
+	AutoMigrate               bool
 }
 
 // LoadConfig loads configuration from environment variables
 func LoadConfig() *Config {
 	return &Config{
-		DynamoDBEndpoint:   getEnv("DYNAMODB_ENDPOINT", ""),
-		RoundsTableName:    getEnv("ROUNDS_TABLE_NAME", "AthleteUnknownRoundsDev"),
-		UserStatsTableName: getEnv("USER_STATS_TABLE_NAME", "AthleteUnknownUserStatsDev"),
-		AWSRegion:          getEnv("AWS_REGION", "us-west-2"),
+		StorageBackend:         getEnv("STORAGE_BACKEND", StorageBackendDynamoDB),
+		DatabaseURL:            getEnv("DATABASE_URL", "athlete-unknown.db"),
+		DynamoDBEndpoint:       getEnv("DYNAMODB_ENDPOINT", ""),
+		RoundsTableName:        getEnv("ROUNDS_TABLE_NAME", "AthleteUnknownRoundsDev"),
+		UserStatsTableName:     getEnv("USER_STATS_TABLE_NAME", "AthleteUnknownUserStatsDev"),
+		GameSessionsTableName:  getEnv("GAME_SESSIONS_TABLE_NAME", "AthleteUnknownGameSessionsDev"),
+		SubmissionsTableName:   getEnv("SUBMISSIONS_TABLE_NAME", "AthleteUnknownSubmissionsDev"),
+		PlayHistoryTableName:   getEnv("PLAY_HISTORY_TABLE_NAME", "AthleteUnknownPlayHistoryDev"),
+		LeaderboardTableName:   getEnv("LEADERBOARD_TABLE_NAME", "AthleteUnknownLeaderboardDev"),
+		LeaguesTableName:       getEnv("LEAGUES_TABLE_NAME", "AthleteUnknownLeaguesDev"),
+		LeagueMembersTableName: getEnv("LEAGUE_MEMBERS_TABLE_NAME", "AthleteUnknownLeagueMembersDev"),
+		RoundEditsTableName:    getEnv("ROUND_EDITS_TABLE_NAME", "AthleteUnknownRoundEditsDev"),
+		AWSRegion:              getEnv("AWS_REGION", "us-west-2"),
+
+		ArchiveStreakRule: getEnv("ARCHIVE_STREAK_RULE", ArchiveStreakRuleNone),
+
+		SchemaMigrationsTableName: getEnv("SCHEMA_MIGRATIONS_TABLE_NAME", "AthleteUnknownSchemaMigrationsDev"),
+		AutoMigrate:               getEnv("AUTO_MIGRATE", "false") == "true",
 	}
 }
 
@@ -67,38 +67,102 @@ func TestLoadConfig(t *testing.T) {
 			name: "default configuration",
 			setupEnv: func() {
 				// Clear all config-related environment variables
+				os.Unsetenv("STORAGE_BACKEND")
+				os.Unsetenv("DATABASE_URL")
 				os.Unsetenv("DYNAMODB_ENDPOINT")
 				os.Unsetenv("ROUNDS_TABLE_NAME")
 				os.Unsetenv("USER_STATS_TABLE_NAME")
+				os.Unsetenv("GAME_SESSIONS_TABLE_NAME")
+				os.Unsetenv("SUBMISSIONS_TABLE_NAME")
+				os.Unsetenv("PLAY_HISTORY_TABLE_NAME")
+				os.Unsetenv("LEADERBOARD_TABLE_NAME")
+				os.Unsetenv("LEAGUES_TABLE_NAME")
+				os.Unsetenv("LEAGUE_MEMBERS_TABLE_NAME")
+				os.Unsetenv("ROUND_EDITS_TABLE_NAME")
+				os.Unsetenv("SCHEMA_MIGRATIONS_TABLE_NAME")
+				os.Unsetenv("AUTO_MIGRATE")
 				os.Unsetenv("AWS_REGION")
+				os.Unsetenv("ARCHIVE_STREAK_RULE")
 			},
 			cleanupEnv: func() {},
 			expectedConfig: &Config{
-				DynamoDBEndpoint:   "",
-				RoundsTableName:    "AthleteUnknownRoundsDev",
-				UserStatsTableName: "AthleteUnknownUserStatsDev",
-				AWSRegion:          "us-west-2",
+				StorageBackend:         "dynamodb",
+				DatabaseURL:            "athlete-unknown.db",
+				DynamoDBEndpoint:       "",
+				RoundsTableName:        "AthleteUnknownRoundsDev",
+				UserStatsTableName:     "AthleteUnknownUserStatsDev",
+				GameSessionsTableName:  "AthleteUnknownGameSessionsDev",
+				SubmissionsTableName:   "AthleteUnknownSubmissionsDev",
+				PlayHistoryTableName:   "AthleteUnknownPlayHistoryDev",
+				LeaderboardTableName:   "AthleteUnknownLeaderboardDev",
+				LeaguesTableName:       "AthleteUnknownLeaguesDev",
+				LeagueMembersTableName: "AthleteUnknownLeagueMembersDev",
+				RoundEditsTableName:    "AthleteUnknownRoundEditsDev",
+				AWSRegion:              "us-west-2",
+
+				ArchiveStreakRule: "none",
+
+				SchemaMigrationsTableName: "AthleteUnknownSchemaMigrationsDev",
+				AutoMigrate:               false,
 			},
 		},
 		{
 			name: "custom configuration from environment",
 			setupEnv: func() {
+				os.Setenv("STORAGE_BACKEND", "postgres")
+				os.Setenv("DATABASE_URL", "postgres://localhost:5432/athlete_unknown")
 				os.Setenv("DYNAMODB_ENDPOINT", "http://custom:9000")
 				os.Setenv("ROUNDS_TABLE_NAME", "CustomRoundsTable")
 				os.Setenv("USER_STATS_TABLE_NAME", "CustomUserStatsTable")
+				os.Setenv("GAME_SESSIONS_TABLE_NAME", "CustomGameSessionsTable")
+				os.Setenv("SUBMISSIONS_TABLE_NAME", "CustomSubmissionsTable")
+				os.Setenv("PLAY_HISTORY_TABLE_NAME", "CustomPlayHistoryTable")
+				os.Setenv("LEADERBOARD_TABLE_NAME", "CustomLeaderboardTable")
+				os.Setenv("LEAGUES_TABLE_NAME", "CustomLeaguesTable")
+				os.Setenv("LEAGUE_MEMBERS_TABLE_NAME", "CustomLeagueMembersTable")
+				os.Setenv("ROUND_EDITS_TABLE_NAME", "CustomRoundEditsTable")
+				os.Setenv("SCHEMA_MIGRATIONS_TABLE_NAME", "CustomSchemaMigrationsTable")
+				os.Setenv("AUTO_MIGRATE", "true")
 				os.Setenv("AWS_REGION", "us-east-1")
+				os.Setenv("ARCHIVE_STREAK_RULE", "win")
 			},
 			cleanupEnv: func() {
+				os.Unsetenv("STORAGE_BACKEND")
+				os.Unsetenv("DATABASE_URL")
 				os.Unsetenv("DYNAMODB_ENDPOINT")
 				os.Unsetenv("ROUNDS_TABLE_NAME")
 				os.Unsetenv("USER_STATS_TABLE_NAME")
+				os.Unsetenv("GAME_SESSIONS_TABLE_NAME")
+				os.Unsetenv("SUBMISSIONS_TABLE_NAME")
+				os.Unsetenv("PLAY_HISTORY_TABLE_NAME")
+				os.Unsetenv("LEADERBOARD_TABLE_NAME")
+				os.Unsetenv("LEAGUES_TABLE_NAME")
+				os.Unsetenv("LEAGUE_MEMBERS_TABLE_NAME")
+				os.Unsetenv("ROUND_EDITS_TABLE_NAME")
+				os.Unsetenv("SCHEMA_MIGRATIONS_TABLE_NAME")
+				os.Unsetenv("AUTO_MIGRATE")
 				os.Unsetenv("AWS_REGION")
+				os.Unsetenv("ARCHIVE_STREAK_RULE")
 			},
 			expectedConfig: &Config{
-				DynamoDBEndpoint:   "http://custom:9000",
-				RoundsTableName:    "CustomRoundsTable",
-				UserStatsTableName: "CustomUserStatsTable",
-				AWSRegion:          "us-east-1",
+				StorageBackend:         "postgres",
+				DatabaseURL:            "postgres://localhost:5432/athlete_unknown",
+				DynamoDBEndpoint:       "http://custom:9000",
+				RoundsTableName:        "CustomRoundsTable",
+				UserStatsTableName:     "CustomUserStatsTable",
+				GameSessionsTableName:  "CustomGameSessionsTable",
+				SubmissionsTableName:   "CustomSubmissionsTable",
+				PlayHistoryTableName:   "CustomPlayHistoryTable",
+				LeaderboardTableName:   "CustomLeaderboardTable",
+				LeaguesTableName:       "CustomLeaguesTable",
+				LeagueMembersTableName: "CustomLeagueMembersTable",
+				RoundEditsTableName:    "CustomRoundEditsTable",
+				AWSRegion:              "us-east-1",
+
+				ArchiveStreakRule: "win",
+
+				SchemaMigrationsTableName: "CustomSchemaMigrationsTable",
+				AutoMigrate:               true,
 			},
 		},
 		{
@@ -132,6 +196,12 @@ func TestLoadConfig(t *testing.T) {
 			cfg := LoadConfig()
 
 			// Verify config fields
+			if tt.expectedConfig.StorageBackend != "" && cfg.StorageBackend != tt.expectedConfig.StorageBackend {
+				t.Errorf("StorageBackend = %v, want %v", cfg.StorageBackend, tt.expectedConfig.StorageBackend)
+			}
+			if tt.expectedConfig.DatabaseURL != "" && cfg.DatabaseURL != tt.expectedConfig.DatabaseURL {
+				t.Errorf("DatabaseURL = %v, want %v", cfg.DatabaseURL, tt.expectedConfig.DatabaseURL)
+			}
 			if cfg.DynamoDBEndpoint != tt.expectedConfig.DynamoDBEndpoint {
 				t.Errorf("DynamoDBEndpoint = %v, want %v", cfg.DynamoDBEndpoint, tt.expectedConfig.DynamoDBEndpoint)
 			}
@@ -141,9 +211,39 @@ func TestLoadConfig(t *testing.T) {
 			if cfg.UserStatsTableName != tt.expectedConfig.UserStatsTableName {
 				t.Errorf("UserStatsTableName = %v, want %v", cfg.UserStatsTableName, tt.expectedConfig.UserStatsTableName)
 			}
+			if tt.expectedConfig.GameSessionsTableName != "" && cfg.GameSessionsTableName != tt.expectedConfig.GameSessionsTableName {
+				t.Errorf("GameSessionsTableName = %v, want %v", cfg.GameSessionsTableName, tt.expectedConfig.GameSessionsTableName)
+			}
+			if tt.expectedConfig.SubmissionsTableName != "" && cfg.SubmissionsTableName != tt.expectedConfig.SubmissionsTableName {
+				t.Errorf("SubmissionsTableName = %v, want %v", cfg.SubmissionsTableName, tt.expectedConfig.SubmissionsTableName)
+			}
+			if tt.expectedConfig.PlayHistoryTableName != "" && cfg.PlayHistoryTableName != tt.expectedConfig.PlayHistoryTableName {
+				t.Errorf("PlayHistoryTableName = %v, want %v", cfg.PlayHistoryTableName, tt.expectedConfig.PlayHistoryTableName)
+			}
+			if tt.expectedConfig.LeaderboardTableName != "" && cfg.LeaderboardTableName != tt.expectedConfig.LeaderboardTableName {
+				t.Errorf("LeaderboardTableName = %v, want %v", cfg.LeaderboardTableName, tt.expectedConfig.LeaderboardTableName)
+			}
+			if tt.expectedConfig.LeaguesTableName != "" && cfg.LeaguesTableName != tt.expectedConfig.LeaguesTableName {
+				t.Errorf("LeaguesTableName = %v, want %v", cfg.LeaguesTableName, tt.expectedConfig.LeaguesTableName)
+			}
+			if tt.expectedConfig.LeagueMembersTableName != "" && cfg.LeagueMembersTableName != tt.expectedConfig.LeagueMembersTableName {
+				t.Errorf("LeagueMembersTableName = %v, want %v", cfg.LeagueMembersTableName, tt.expectedConfig.LeagueMembersTableName)
+			}
+			if tt.expectedConfig.RoundEditsTableName != "" && cfg.RoundEditsTableName != tt.expectedConfig.RoundEditsTableName {
+				t.Errorf("RoundEditsTableName = %v, want %v", cfg.RoundEditsTableName, tt.expectedConfig.RoundEditsTableName)
+			}
+			if tt.expectedConfig.SchemaMigrationsTableName != "" && cfg.SchemaMigrationsTableName != tt.expectedConfig.SchemaMigrationsTableName {
+				t.Errorf("SchemaMigrationsTableName = %v, want %v", cfg.SchemaMigrationsTableName, tt.expectedConfig.SchemaMigrationsTableName)
+			}
+			if cfg.AutoMigrate != tt.expectedConfig.AutoMigrate {
+				t.Errorf("AutoMigrate = %v, want %v", cfg.AutoMigrate, tt.expectedConfig.AutoMigrate)
+			}
 			if cfg.AWSRegion != tt.expectedConfig.AWSRegion {
 				t.Errorf("AWSRegion = %v, want %v", cfg.AWSRegion, tt.expectedConfig.AWSRegion)
 			}
+			if tt.expectedConfig.ArchiveStreakRule != "" && cfg.ArchiveStreakRule != tt.expectedConfig.ArchiveStreakRule {
+				t.Errorf("ArchiveStreakRule = %v, want %v", cfg.ArchiveStreakRule, tt.expectedConfig.ArchiveStreakRule)
+			}
 		})
 	}
 }
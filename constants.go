@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 // Sport constants
 const (
 	SportBaseball   = "baseball"
@@ -10,6 +12,8 @@ const (
 // Permission constants
 const (
 	PermissionReadUserStats      = "read:athlete-unknown:user-stats"
+	PermissionReadLeagues        = "read:athlete-unknown:leagues"
+	PermissionWriteLeagues       = "write:athlete-unknown:leagues"
 	PermissionReadUpcomingRounds = "read:athlete-unknown:upcoming-rounds"
 	PermissionReadRounds         = "read:athlete-unknown:rounds"
 	PermissionReadRoundStats     = "read:athlete-unknown:round-stats"
@@ -24,6 +28,7 @@ const (
 	ConstantPermissions = "permissions"
 	ConstantRoles       = "roles"
 	ConstantIsAdmin     = "isAdmin"
+	ConstantAdminName   = "adminName" // set by the API key middleware for a named admin key (ADMIN_API_KEYS)
 )
 
 // Role constants
@@ -54,23 +59,6 @@ const (
 	TileNicknames            = "nicknames"
 )
 
-// AllTiles returns a slice of all tile names
-func AllTiles() []string {
-	return []string{
-		TileBio,
-		TilePlayerInformation,
-		TileDraftInformation,
-		TileTeamsPlayedOn,
-		TileJerseyNumbers,
-		TileCareerStats,
-		TilePersonalAchievements,
-		TilePhoto,
-		TileYearsActive,
-		TileInitials,
-		TileNicknames,
-	}
-}
-
 // AllSports returns a slice of all supported sports
 func AllSports() []string {
 	return []string{
@@ -96,6 +84,7 @@ const (
 	StatusInternalServerError = "Internal Server Error"
 	StatusNotFound            = "Not Found"
 	StatusConflict            = "Conflict"
+	StatusForbidden           = "Forbidden"
 )
 
 // Error codes
@@ -118,6 +107,164 @@ const (
 	ErrorNoPlayersFound           = "NO_PLAYERS_FOUND"
 	ErrorMultiplePlayersFound     = "MULTIPLE_PLAYERS_FOUND"
 	ErrorInvalidSearchResultURL   = "INVALID_SEARCH_RESULT_URL"
+	ErrorSessionNotFound          = "SESSION_NOT_FOUND"
+	ErrorSessionMismatch          = "SESSION_MISMATCH"
+	ErrorInvalidTile              = "INVALID_TILE"
+	ErrorLeagueNotFound           = "LEAGUE_NOT_FOUND"
+	ErrorNotLeagueMember          = "NOT_LEAGUE_MEMBER"
+	ErrorAlreadyLeagueMember      = "ALREADY_LEAGUE_MEMBER"
+	ErrorLeagueFull               = "LEAGUE_FULL"
+	ErrorScheduleQueueEmpty       = "SCHEDULE_QUEUE_EMPTY"
+	ErrorNamedAdminKeyRequired    = "NAMED_ADMIN_KEY_REQUIRED"
+	ErrorRoundEditConflict        = "ROUND_EDIT_CONFLICT"
+)
+
+// Storage backends (STORAGE_BACKEND)
+const (
+	StorageBackendDynamoDB = "dynamodb"
+	StorageBackendMemory   = "memory"
+	StorageBackendSQLite   = "sqlite"
+	StorageBackendPostgres = "postgres"
+)
+
+// Round release constants
+const (
+	// Rounds are released at midnight in the caller's timezone, but never before midnight in the earliest
+	// timezone (UTC+14), the first place each day begins
+	EarliestTimezoneOffset = 14 * time.Hour
+)
+
+// Round edit constants
+const (
+	RoundEditFieldTheme        = "theme"
+	RoundEditFieldPlayerPrefix = "player." // followed by the tile key, e.g. "player.bio"
+
+	// RoundEditKeyFormat is a fixed-width UTC timestamp, so edit keys sort in the order the edits were made
+	RoundEditKeyFormat = "2006-01-02T15:04:05.000000000Z"
+
+	MaxRoundPatchAttempts = 3 // re-read and re-apply a patch this many times when the round is edited concurrently
+)
+
+// Scrape preview warning codes (POST /v1/round?dryRun=true)
+const (
+	ScrapeWarningClueTooLong    = "CLUE_TOO_LONG"   // longer than ClueMaxLength
+	ScrapeWarningEmptyTile      = "EMPTY_TILE"      // nothing was scraped for the tile
+	ScrapeWarningNoAchievements = "NO_ACHIEVEMENTS" // personalAchievements fell back to "N/A"
+	ScrapeWarningMissingPhoto   = "MISSING_PHOTO"
+)
+
+// Submission statuses: how far SubmitResults got in counting a submission
+const (
+	SubmissionStatusClaimed      = "claimed"      // nothing counted yet, or a request is still counting it
+	SubmissionStatusRoundCounted = "roundCounted" // the round stats are counted, the user stats and leaderboards aren't yet
+	SubmissionStatusUserCounted  = "userCounted"  // the round and user stats are counted, the leaderboards aren't yet
+	SubmissionStatusComplete     = "complete"
+)
+
+// Round manifest constants (import-rounds command)
+const (
+	RoundManifestFormatCSV  = "csv"
+	RoundManifestFormatJSON = "json"
+
+	RoundManifestStatusCreated  = "created"
+	RoundManifestStatusFailed   = "failed"
+	RoundManifestStatusUnfilled = "unfilled" // fill-schedule ran out of candidates for the date
+
+	// Each player takes one or two Sports Reference requests, so this keeps an import to about 20 requests a minute
+	DefaultRoundManifestScrapeDelay = 6 * time.Second
+)
+
+// Schedule constants
+const (
+	DefaultScheduleGapDays = 14  // days ahead checked for missing rounds unless told otherwise
+	MaxScheduleGapDays     = 366 // longest range checked at once
+)
+
+// Analytics constants
+const (
+	MaxTileAnalyticsDays = 366 // longest date range tile analytics aggregate at once
+)
+
+// Game session constants
+const (
+	GameSessionTTL = 7 * 24 * time.Hour // sessions are removed by DynamoDB TTL after a week
+)
+
+// User stats constants
+const (
+	MaxUserStatsWriteAttempts = 3 // re-read and re-apply a result this many times on a version conflict
+
+	// Streak freezes cover a single missed day of the overall daily streak
+	StreakFreezeMilestoneDays = 7  // a freeze is earned every time the daily streak reaches a multiple of this
+	MaxStreakFreezes          = 2  // freezes earned beyond this are lost
+	MaxStreakFreezeLogEntries = 20 // only the most recent freezes spent are kept in the log
+)
+
+// Archive play constants
+// A result for a playDate before today (in the user's timezone) is an archive play. It is kept in play history
+// but not in round or user stats, and ARCHIVE_STREAK_RULE decides which streaks it counts toward
+const (
+	ArchiveStreakRuleNone = "none" // archive plays don't affect any streak
+	ArchiveStreakRuleWin  = "win"  // archive plays extend or break win streaks but not daily streaks
+	ArchiveStreakRuleAll  = "all"  // archive plays count toward every streak, like today's round
+)
+
+// Round difficulty constants
+const (
+	RoundDifficultyMinPlays = 10 // rounds with fewer plays aren't rated
+
+	RoundDifficultyOrderHardest = "hardest"
+	RoundDifficultyOrderEasiest = "easiest"
+)
+
+// Stats distribution constants
+const (
+	ScoreDistributionBucketSize     = 10 // correct scores are bucketed by their lower bound, e.g. 80 covers 80-89
+	MaxIncorrectGuessesDistribution = 5  // results with this many or more incorrect guesses share the last bucket
+)
+
+// Play history constants
+const (
+	DefaultPlayHistoryPageSize = 20
+	MaxPlayHistoryPageSize     = 100
+)
+
+// Leaderboard constants
+// Results are recorded on a daily board per round ("<sport>#<playDate>"), an all-time board ("<sport>#all")
+// and the board of every rolling window that covers the round ("<sport>#7d#<endDate>", "<sport>#30d#<endDate>")
+const (
+	LeaderboardWindowRound   = "round"
+	LeaderboardWindowWeek    = "7d"
+	LeaderboardWindowMonth   = "30d"
+	LeaderboardWindowAllTime = "all"
+
+	LeaderboardBoardAllTime = "all"
+	LeaderboardScoreIndex   = "BoardScoreIndex"
+
+	DefaultLeaderboardPageSize = 25
+	MaxLeaderboardPageSize     = 100
+
+	// DynamoDB counts the entries above a score a page at a time, so ranks stop being counted here
+	// and entries further down are ranked MaxLeaderboardRankCount + 1
+	MaxLeaderboardRankCount = 10000
+
+	// Rolling window boards expire this many days after their window ends, since they're only read for recent windows
+	LeaderboardWindowRetentionDays = 90
+
+	MaxLeaderboardWriteAttempts = 3 // re-read and re-write a player's entries this many times when they change concurrently
+
+	// BatchGetItem reads at most this many items per request
+	DynamoDBBatchGetLimit = 100
+)
+
+// League constants
+const (
+	LeagueInviteCodeIndex  = "InviteCodeIndex"
+	LeagueUserLeaguesIndex = "UserLeaguesIndex"
+	LeagueInviteCodeLength = 8
+	MaxLeagueNameLength    = 50
+	MaxLeagueMembers       = 50
+	DefaultLeagueWindow    = LeaderboardWindowWeek // league leaderboards cover the last week unless a window is given
 )
 
 // Date format constants
@@ -135,15 +282,33 @@ const (
 	QueryParamName               = "name"
 	QueryParamSportsReferenceURL = "sportsReferenceURL"
 	QueryParamTheme              = "theme"
+	QueryParamSessionId          = "sessionId"
+	QueryParamTile               = "tile"
+	QueryParamLimit              = "limit"
+	QueryParamCursor             = "cursor"
+	QueryParamWindow             = "window"
+	QueryParamLeagueId           = "leagueId"
+	QueryParamOrder              = "order"
+	QueryParamDryRun             = "dryRun"
+	QueryParamDays               = "days"
+)
+
+// HTTP header names
+const (
+	HeaderUserTimezone       = "X-User-Timezone"
+	HeaderGuestToken         = "X-Guest-Token"
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
 )
 
 // JSON response field names
 const (
-	JSONFieldError     = "error"
-	JSONFieldMessage   = "message"
-	JSONFieldCode      = "code"
-	JSONFieldTimestamp = "timestamp"
-	JSONFieldDetails   = "details"
+	JSONFieldError      = "error"
+	JSONFieldMessage    = "message"
+	JSONFieldCode       = "code"
+	JSONFieldTimestamp  = "timestamp"
+	JSONFieldDetails    = "details"
+	JSONFieldCandidates = "candidates"
 )
 
 // IsValidSport checks if a sport is valid
@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -15,9 +19,18 @@ import (
 
 // DB wraps the DynamoDB client
 type DB struct {
-	client             *dynamodb.Client
-	roundsTableName    string
-	userStatsTableName string
+	client                 *dynamodb.Client
+	roundsTableName        string
+	userStatsTableName     string
+	gameSessionsTableName  string
+	submissionsTableName   string
+	playHistoryTableName   string
+	leaderboardTableName   string
+	leaguesTableName       string
+	leagueMembersTableName string
+	roundEditsTableName    string
+
+	schemaMigrationsTableName string
 }
 
 // NewDB creates a new DynamoDB client
@@ -42,9 +55,18 @@ func NewDB(cfg *Config) (*DB, error) {
 		})
 
 		return &DB{
-			client:             client,
-			roundsTableName:    cfg.RoundsTableName,
-			userStatsTableName: cfg.UserStatsTableName,
+			client:                 client,
+			roundsTableName:        cfg.RoundsTableName,
+			userStatsTableName:     cfg.UserStatsTableName,
+			gameSessionsTableName:  cfg.GameSessionsTableName,
+			submissionsTableName:   cfg.SubmissionsTableName,
+			playHistoryTableName:   cfg.PlayHistoryTableName,
+			leaderboardTableName:   cfg.LeaderboardTableName,
+			leaguesTableName:       cfg.LeaguesTableName,
+			leagueMembersTableName: cfg.LeagueMembersTableName,
+			roundEditsTableName:    cfg.RoundEditsTableName,
+
+			schemaMigrationsTableName: cfg.SchemaMigrationsTableName,
 		}, nil
 	}
 
@@ -59,9 +81,18 @@ func NewDB(cfg *Config) (*DB, error) {
 	client := dynamodb.NewFromConfig(awsCfg)
 
 	return &DB{
-		client:             client,
-		roundsTableName:    cfg.RoundsTableName,
-		userStatsTableName: cfg.UserStatsTableName,
+		client:                 client,
+		roundsTableName:        cfg.RoundsTableName,
+		userStatsTableName:     cfg.UserStatsTableName,
+		gameSessionsTableName:  cfg.GameSessionsTableName,
+		submissionsTableName:   cfg.SubmissionsTableName,
+		playHistoryTableName:   cfg.PlayHistoryTableName,
+		leaderboardTableName:   cfg.LeaderboardTableName,
+		leaguesTableName:       cfg.LeaguesTableName,
+		leagueMembersTableName: cfg.LeagueMembersTableName,
+		roundEditsTableName:    cfg.RoundEditsTableName,
+
+		schemaMigrationsTableName: cfg.SchemaMigrationsTableName,
 	}, nil
 }
 
@@ -88,6 +119,9 @@ func (db *DB) GetRound(ctx context.Context, sport, playDate string) (*Round, err
 		return nil, fmt.Errorf("failed to unmarshal round: %w", err)
 	}
 
+	// Stats are stored as raw counters, derive the averages, percentages and difficulty
+	computeRoundStats(&round)
+
 	return &round, nil
 }
 
@@ -118,7 +152,7 @@ func (db *DB) CreateRound(ctx context.Context, round *Round) error {
 		ConditionExpression: aws.String("attribute_not_exists(playDate) AND attribute_not_exists(sport)"),
 	})
 	if err != nil {
-		if _, ok := err.(*types.ConditionalCheckFailedException); ok {
+		if isConditionalCheckFailed(err) {
 			return fmt.Errorf("round already exists")
 		}
 		return fmt.Errorf("failed to create round: %w", err)
@@ -148,6 +182,391 @@ func (db *DB) UpdateRound(ctx context.Context, round *Round) error {
 	return nil
 }
 
+// PatchRound sets the theme and tile fields present in a patch, leaving the rest of the round and its stats alone
+// The round update and its audit record (see newRoundEdit) are written in one transaction. Only the patched
+// attributes are written, on the condition that the changed ones still hold the values read, so concurrent
+// result submissions aren't overwritten and a concurrent edit is re-read and retried
+// Returns the round as it was before the patch, or an error "round not found" if the round doesn't exist
+func (db *DB) PatchRound(ctx context.Context, sport, playDate string, patch *RoundPatch, editor string) (*Round, error) {
+	for attempt := 1; ; attempt++ {
+		previous, err := db.GetRound(ctx, sport, playDate)
+		if err != nil {
+			return nil, err
+		}
+		if previous == nil {
+			return nil, fmt.Errorf("round not found")
+		}
+
+		edit := newRoundEdit(previous, patch, editor)
+		if edit == nil {
+			// Every field already has its patched value, so there's nothing to write or record
+			return previous, nil
+		}
+
+		err = db.writeRoundPatch(ctx, previous, patch, edit)
+		if isTransactionConditionFailed(err) && attempt < MaxRoundPatchAttempts {
+			continue
+		}
+		if isTransactionConditionFailed(err) {
+			return nil, fmt.Errorf("round edit conflict")
+		}
+		if err != nil {
+			return nil, err
+		}
+		return previous, nil
+	}
+}
+
+// writeRoundPatch applies a patch to a round and saves the edit's audit record in one transaction
+// The update is conditional on the round existing and every changed field still holding its value in previous
+func (db *DB) writeRoundPatch(ctx context.Context, previous *Round, patch *RoundPatch, edit *RoundEdit) error {
+	now, err := attributevalue.Marshal(edit.Edited)
+	if err != nil {
+		return fmt.Errorf("failed to marshal timestamp: %w", err)
+	}
+
+	sets := []string{"lastUpdated = :now"}
+	conditions := []string{"attribute_exists(#sport)"}
+	names := map[string]string{"#sport": "sport"}
+	values := map[string]types.AttributeValue{":now": now}
+	if patch.Theme != nil {
+		sets = append(sets, "#theme = :theme")
+		names["#theme"] = "theme"
+		values[":theme"] = &types.AttributeValueMemberS{Value: *patch.Theme}
+		if *patch.Theme != previous.Theme {
+			conditions = append(conditions, "#theme = :oldTheme")
+			values[":oldTheme"] = &types.AttributeValueMemberS{Value: previous.Theme}
+		}
+	}
+	// Tile keys match the Player attribute names. Iterate in registry order so the expression is deterministic
+	for i, tile := range tileRegistry {
+		value, ok := patch.Player[tile.Key]
+		if !ok {
+			continue
+		}
+		name := fmt.Sprintf("#tile%d", i)
+		placeholder := fmt.Sprintf(":tile%d", i)
+		names["#player"] = "player"
+		names[name] = tile.Key
+		values[placeholder] = &types.AttributeValueMemberS{Value: value}
+		sets = append(sets, "#player."+name+" = "+placeholder)
+
+		if old := *tile.Field(&previous.Player); value != old {
+			values[":old"+placeholder[1:]] = &types.AttributeValueMemberS{Value: old}
+			conditions = append(conditions, "#player."+name+" = :old"+placeholder[1:])
+		}
+	}
+
+	item, err := attributevalue.MarshalMap(edit)
+	if err != nil {
+		return fmt.Errorf("failed to marshal round edit: %w", err)
+	}
+
+	_, err = db.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName:                 aws.String(db.roundsTableName),
+					Key:                       roundKey(previous.Sport, previous.PlayDate),
+					UpdateExpression:          aws.String("SET " + strings.Join(sets, ", ")),
+					ConditionExpression:       aws.String(strings.Join(conditions, " AND ")),
+					ExpressionAttributeNames:  names,
+					ExpressionAttributeValues: values,
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:           aws.String(db.roundEditsTableName),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_not_exists(editKey)"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update round: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyRoundResult adds a submitted result to a round's stats
+// Counters are incremented in place with UpdateItem ADD, so concurrent submissions never overwrite each other
+// Returns an error "round not found" if the round doesn't exist
+func (db *DB) ApplyRoundResult(ctx context.Context, sport, playDate string, result *Result) error {
+	if result.Archive {
+		return db.addRoundArchivePlay(ctx, sport, playDate)
+	}
+
+	err := db.addRoundResultCounters(ctx, sport, playDate, result)
+	if isConditionalCheckFailed(err) {
+		// The round is missing or was saved before raw counters were tracked
+		err = db.backfillRoundCounters(ctx, sport, playDate)
+		if err != nil {
+			return err
+		}
+		err = db.addRoundResultCounters(ctx, sport, playDate, result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update round stats: %w", err)
+	}
+
+	// ADD can't take a maximum, so raise the highest score with a conditional SET instead
+	if result.Score > 0 {
+		_, err = db.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:                aws.String(db.roundsTableName),
+			Key:                      roundKey(sport, playDate),
+			UpdateExpression:         aws.String("SET #stats.highestScore = :score"),
+			ConditionExpression:      aws.String("#stats.highestScore < :score"),
+			ExpressionAttributeNames: map[string]string{"#stats": "stats"},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":score": &types.AttributeValueMemberN{Value: strconv.Itoa(result.Score)},
+			},
+		})
+		if isConditionalCheckFailed(err) {
+			err = nil // Existing highest score is already higher
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update highest score: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// addRoundArchivePlay counts an archive play on a round without touching its live stats (see applyRoundResult)
+// Returns an error "round not found" if the round doesn't exist
+func (db *DB) addRoundArchivePlay(ctx context.Context, sport, playDate string) error {
+	now, err := attributevalue.Marshal(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to marshal timestamp: %w", err)
+	}
+
+	_, err = db.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                aws.String(db.roundsTableName),
+		Key:                      roundKey(sport, playDate),
+		UpdateExpression:         aws.String("SET lastUpdated = :now ADD #stats.archivePlays :one"),
+		ConditionExpression:      aws.String("attribute_exists(#stats)"),
+		ExpressionAttributeNames: map[string]string{"#stats": "stats"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": now,
+			":one": &types.AttributeValueMemberN{Value: "1"},
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return fmt.Errorf("round not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update round stats: %w", err)
+	}
+
+	return nil
+}
+
+// roundStatsMaps are the stats attributes keyed by tile or bucket that results are ADDed to
+// DynamoDB can only ADD to a key of a map that already exists, so they're backfilled before the first result
+var roundStatsMaps = []string{
+	"firstTileFlippedTracker",
+	"lastTileFlippedTracker",
+	"mostTileFlippedTracker",
+	"lastTileFlippedCorrectTracker",
+	"lastTileFlippedIncorrectTracker",
+	"tileFlippedResultsTracker",
+	"tileFlippedScoreTracker",
+	"scoreDistribution",
+	"tilesFlippedDistribution",
+	"incorrectGuessesDistribution",
+}
+
+// roundStatsCountersExist is the condition that a round has every raw counter and map ADDed to by a result
+func roundStatsCountersExist() string {
+	conditions := []string{"attribute_exists(#stats.correctCount)"}
+	for _, name := range roundStatsMaps {
+		conditions = append(conditions, "attribute_exists(#stats."+name+")")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// addRoundResultCounters increments a round's raw stat counters, tile trackers and distributions for a result
+// Fails with a ConditionalCheckFailedException if the round doesn't have raw counters or maps yet (see roundStatsMaps)
+func (db *DB) addRoundResultCounters(ctx context.Context, sport, playDate string, result *Result) error {
+	now, err := attributevalue.Marshal(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to marshal timestamp: %w", err)
+	}
+
+	update, names, values := roundResultCounterUpdate(result)
+	values[":now"] = now
+
+	_, err = db.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(db.roundsTableName),
+		Key:                       roundKey(sport, playDate),
+		UpdateExpression:          aws.String("SET lastUpdated = :now " + update),
+		ConditionExpression:       aws.String(roundStatsCountersExist()),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	return err
+}
+
+// backfillRoundCounters writes the raw counters and maps for a round saved before they were tracked
+// Counters and maps that already exist are left alone. New maps start empty since past results can't be bucketed
+// Returns an error "round not found" if the round doesn't exist
+func (db *DB) backfillRoundCounters(ctx context.Context, sport, playDate string) error {
+	// GetRound reconstructs the counters from the derived values
+	round, err := db.GetRound(ctx, sport, playDate)
+	if err != nil {
+		return err
+	}
+	if round == nil {
+		return fmt.Errorf("round not found")
+	}
+
+	sets := []string{
+		"#stats.correctCount = if_not_exists(#stats.correctCount, :correct)",
+		"#stats.totalCorrectScore = if_not_exists(#stats.totalCorrectScore, :score)",
+		"#stats.totalTileFlips = if_not_exists(#stats.totalTileFlips, :flips)",
+	}
+	for _, name := range roundStatsMaps {
+		sets = append(sets, "#stats."+name+" = if_not_exists(#stats."+name+", :empty)")
+	}
+
+	stats := round.Stats.Stats
+	_, err = db.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(db.roundsTableName),
+		Key:              roundKey(sport, playDate),
+		UpdateExpression: aws.String("SET " + strings.Join(sets, ", ")),
+		// Don't create a round that was deleted since it was read
+		ConditionExpression:      aws.String("attribute_exists(#stats)"),
+		ExpressionAttributeNames: map[string]string{"#stats": "stats"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":correct": &types.AttributeValueMemberN{Value: strconv.Itoa(stats.CorrectCount)},
+			":score":   &types.AttributeValueMemberN{Value: strconv.Itoa(stats.TotalCorrectScore)},
+			":flips":   &types.AttributeValueMemberN{Value: strconv.Itoa(stats.TotalTileFlips)},
+			":empty":   &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return fmt.Errorf("round not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to backfill round stats: %w", err)
+	}
+
+	return nil
+}
+
+// roundResultCounterUpdate builds the ADD clause that applies a result to a round's raw stat counters
+// Flips of the same tile are combined since DynamoDB rejects overlapping paths in one expression
+// Example: "ADD #stats.totalPlays :one, ..., #stats.scoreDistribution.#scoreBucket :one, ..., #stats.mostTileFlippedTracker.#tile0 :count0"
+func roundResultCounterUpdate(result *Result) (string, map[string]string, map[string]types.AttributeValue) {
+	correct, score := 0, 0
+	if result.IsCorrect {
+		correct, score = 1, result.Score
+	}
+
+	names := map[string]string{"#stats": "stats"}
+	values := map[string]types.AttributeValue{
+		":one":     &types.AttributeValueMemberN{Value: "1"},
+		":correct": &types.AttributeValueMemberN{Value: strconv.Itoa(correct)},
+		":score":   &types.AttributeValueMemberN{Value: strconv.Itoa(score)},
+		":flips":   &types.AttributeValueMemberN{Value: strconv.Itoa(len(result.FlippedTiles))},
+	}
+	adds := []string{
+		"#stats.totalPlays :one",
+		"#stats.correctCount :correct",
+		"#stats.totalCorrectScore :score",
+		"#stats.totalTileFlips :flips",
+	}
+
+	// Distribution buckets can contain characters like "+", so they are always passed as names
+	names["#tilesFlipped"] = tilesFlippedBucket(result.FlippedTiles)
+	names["#incorrectGuesses"] = incorrectGuessesBucket(result.IncorrectGuesses)
+	adds = append(adds,
+		"#stats.tilesFlippedDistribution.#tilesFlipped :one",
+		"#stats.incorrectGuessesDistribution.#incorrectGuesses :one",
+	)
+	if result.IsCorrect {
+		names["#scoreBucket"] = scoreBucket(result.Score)
+		adds = append(adds, "#stats.scoreDistribution.#scoreBucket :one")
+	}
+
+	// Only count recognized tiles, matching incrementTileTracker
+	var tiles []string
+	for _, tile := range result.FlippedTiles {
+		if contains(AllTiles(), tile) {
+			tiles = append(tiles, tile)
+		}
+	}
+
+	if len(tiles) > 0 {
+		names["#first"] = tiles[0]
+		names["#last"] = tiles[len(tiles)-1]
+		adds = append(adds,
+			"#stats.firstTileFlippedTracker.#first :one",
+			"#stats.lastTileFlippedTracker.#last :one",
+		)
+		if result.IsCorrect {
+			adds = append(adds, "#stats.lastTileFlippedCorrectTracker.#last :one")
+		} else {
+			adds = append(adds, "#stats.lastTileFlippedIncorrectTracker.#last :one")
+		}
+
+		counts := map[string]int{}
+		for _, tile := range tiles {
+			counts[tile]++
+		}
+		// Iterate in AllTiles order so the expression is deterministic
+		i := 0
+		for _, tile := range AllTiles() {
+			if counts[tile] == 0 {
+				continue
+			}
+			name := fmt.Sprintf("#tile%d", i)
+			value := fmt.Sprintf(":count%d", i)
+			names[name] = tile
+			values[value] = &types.AttributeValueMemberN{Value: strconv.Itoa(counts[tile])}
+			adds = append(adds,
+				"#stats.mostTileFlippedTracker."+name+" "+value,
+				"#stats.tileFlippedResultsTracker."+name+" :one",
+				"#stats.tileFlippedScoreTracker."+name+" :score",
+			)
+			i++
+		}
+	}
+
+	return "ADD " + strings.Join(adds, ", "), names, values
+}
+
+// isConditionalCheckFailed reports whether a write was rejected by its ConditionExpression
+// The SDK wraps service errors, so the exception has to be unwrapped rather than type asserted
+func isConditionalCheckFailed(err error) bool {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionalCheckFailed)
+}
+
+// isTransactionConditionFailed reports whether a transaction was cancelled because one of its conditions failed
+func isTransactionConditionFailed(err error) bool {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return false
+	}
+	for _, reason := range canceled.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			return true
+		}
+	}
+	return false
+}
+
+// roundKey returns the primary key of a round
+func roundKey(sport, playDate string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"playDate": &types.AttributeValueMemberS{Value: playDate},
+		"sport":    &types.AttributeValueMemberS{Value: sport},
+	}
+}
+
 // DeleteRound deletes a round by playDate and sport
 func (db *DB) DeleteRound(ctx context.Context, sport, playDate string) error {
 	_, err := db.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
@@ -165,10 +584,20 @@ func (db *DB) DeleteRound(ctx context.Context, sport, playDate string) error {
 }
 
 // GetRoundsBySport retrieves minimal round information for a specific sport, optionally filtered by date range
-// Returns only roundId, sport, and playDate fields using DynamoDB ProjectionExpression for efficiency
-// Uses the SportPlayDateIndex GSI for efficient querying and automatic sorting by playDate
+// Built on GetRoundStatsBySport, since each round's stats are needed to rate its difficulty
 func (db *DB) GetRoundsBySport(ctx context.Context, sport, startDate, endDate string) ([]*RoundSummary, error) {
-	// Build key condition expression for sport (partition key of GSI)
+	rounds, err := db.GetRoundStatsBySport(ctx, sport, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return newRoundSummaries(rounds), nil
+}
+
+// GetRoundStatsBySport retrieves the keys and stats of a sport's rounds, optionally filtered by date range
+// Returns only roundId, sport, playDate and stats using DynamoDB ProjectionExpression for efficiency
+// Queries the table by its sport partition key a page at a time, so results come back sorted by playDate
+func (db *DB) GetRoundStatsBySport(ctx context.Context, sport, startDate, endDate string) ([]*Round, error) {
+	// Build key condition expression for sport (partition key)
 	keyConditionExpression := "sport = :sport"
 	expressionAttributeValues := map[string]types.AttributeValue{
 		":sport": &types.AttributeValueMemberS{Value: sport},
@@ -187,25 +616,28 @@ func (db *DB) GetRoundsBySport(ctx context.Context, sport, startDate, endDate st
 		expressionAttributeValues[":endDate"] = &types.AttributeValueMemberS{Value: endDate}
 	}
 
-	result, err := db.client.Query(ctx, &dynamodb.QueryInput{
+	paginator := dynamodb.NewQueryPaginator(db.client, &dynamodb.QueryInput{
 		TableName:                 aws.String(db.roundsTableName),
 		KeyConditionExpression:    aws.String(keyConditionExpression),
 		ExpressionAttributeValues: expressionAttributeValues,
-		ProjectionExpression:      aws.String("roundId, sport, playDate"),
+		ProjectionExpression:      aws.String("roundId, sport, playDate, #stats"),
+		ExpressionAttributeNames:  map[string]string{"#stats": "stats"},
 		ScanIndexForward:          aws.Bool(false), // Sort descending (latest to earliest)
 	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to query rounds: %w", err)
-	}
 
-	var rounds []*RoundSummary
-	for _, item := range result.Items {
-		var round RoundSummary
-		err = attributevalue.UnmarshalMap(item, &round)
+	var rounds []*Round
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal round: %w", err)
+			return nil, fmt.Errorf("failed to query rounds: %w", err)
+		}
+		for _, item := range page.Items {
+			var round Round
+			if err := attributevalue.UnmarshalMap(item, &round); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal round: %w", err)
+			}
+			rounds = append(rounds, &round)
 		}
-		rounds = append(rounds, &round)
 	}
 
 	return rounds, nil
@@ -238,8 +670,9 @@ func (db *DB) GetUserStats(ctx context.Context, userId string) (*UserStats, erro
 
 // CreateUserStats creates new user statistics
 func (db *DB) CreateUserStats(ctx context.Context, stats *UserStats) error {
-	// Set timestamp
+	// Set timestamp and initial version
 	stats.UserCreated = time.Now()
+	stats.Version = 1
 
 	// Marshal the stats to DynamoDB format
 	item, err := attributevalue.MarshalMap(stats)
@@ -254,7 +687,7 @@ func (db *DB) CreateUserStats(ctx context.Context, stats *UserStats) error {
 		ConditionExpression: aws.String("attribute_not_exists(userId)"),
 	})
 	if err != nil {
-		if _, ok := err.(*types.ConditionalCheckFailedException); ok {
+		if isConditionalCheckFailed(err) {
 			return fmt.Errorf("user stats already exist")
 		}
 		return fmt.Errorf("failed to create user stats: %w", err)
@@ -264,20 +697,799 @@ func (db *DB) CreateUserStats(ctx context.Context, stats *UserStats) error {
 }
 
 // UpdateUserStats updates existing user statistics
+// The write only succeeds if the stored version still matches stats.Version, i.e. nobody else has
+// written the item since it was read. Returns an error "user stats version conflict" otherwise
 func (db *DB) UpdateUserStats(ctx context.Context, stats *UserStats) error {
+	expectedVersion := stats.Version
+	stats.Version++
+
 	// Marshal the stats to DynamoDB format
 	item, err := attributevalue.MarshalMap(stats)
 	if err != nil {
+		stats.Version = expectedVersion
 		return fmt.Errorf("failed to marshal user stats: %w", err)
 	}
 
+	// Items saved before versioning have no version attribute and are read as version 0
 	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(db.userStatsTableName),
-		Item:      item,
+		TableName:                aws.String(db.userStatsTableName),
+		Item:                     item,
+		ConditionExpression:      aws.String("attribute_exists(userId) AND (attribute_not_exists(#version) OR #version = :expectedVersion)"),
+		ExpressionAttributeNames: map[string]string{"#version": "version"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":expectedVersion": &types.AttributeValueMemberN{Value: strconv.Itoa(expectedVersion)},
+		},
 	})
 	if err != nil {
+		stats.Version = expectedVersion
+		if isConditionalCheckFailed(err) {
+			return fmt.Errorf("user stats version conflict")
+		}
 		return fmt.Errorf("failed to update user stats: %w", err)
 	}
 
 	return nil
 }
+
+// GetGameSession retrieves a game session by sessionId
+func (db *DB) GetGameSession(ctx context.Context, sessionId string) (*GameSession, error) {
+	result, err := db.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(db.gameSessionsTableName),
+		Key: map[string]types.AttributeValue{
+			"sessionId": &types.AttributeValueMemberS{Value: sessionId},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game session: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil // Not found
+	}
+
+	var session GameSession
+	err = attributevalue.UnmarshalMap(result.Item, &session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// CreateGameSession creates a new game session
+func (db *DB) CreateGameSession(ctx context.Context, session *GameSession) error {
+	// Set timestamps
+	now := time.Now()
+	session.Created = now
+	session.LastUpdated = now
+	session.ExpiresAt = now.Add(GameSessionTTL).Unix()
+
+	// Marshal the session to DynamoDB format
+	item, err := attributevalue.MarshalMap(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game session: %w", err)
+	}
+
+	// Check if item already exists using ConditionExpression
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(db.gameSessionsTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(sessionId)"),
+	})
+	if err != nil {
+		if isConditionalCheckFailed(err) {
+			return fmt.Errorf("game session already exists")
+		}
+		return fmt.Errorf("failed to create game session: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGameSession updates an existing game session
+func (db *DB) UpdateGameSession(ctx context.Context, session *GameSession) error {
+	session.LastUpdated = time.Now()
+
+	// Marshal the session to DynamoDB format
+	item, err := attributevalue.MarshalMap(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game session: %w", err)
+	}
+
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.gameSessionsTableName),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update game session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubmission retrieves a result submission by its submission key
+func (db *DB) GetSubmission(ctx context.Context, submissionKey string) (*Submission, error) {
+	result, err := db.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(db.submissionsTableName),
+		Key: map[string]types.AttributeValue{
+			"submissionKey": &types.AttributeValueMemberS{Value: submissionKey},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil // Not found
+	}
+
+	var submission Submission
+	err = attributevalue.UnmarshalMap(result.Item, &submission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal submission: %w", err)
+	}
+
+	return &submission, nil
+}
+
+// CreateSubmission records a result submission. Fails if the submission key has already been used
+func (db *DB) CreateSubmission(ctx context.Context, submission *Submission) error {
+	// Set timestamp
+	submission.Created = time.Now()
+
+	// Marshal the submission to DynamoDB format
+	item, err := attributevalue.MarshalMap(submission)
+	if err != nil {
+		return fmt.Errorf("failed to marshal submission: %w", err)
+	}
+
+	// Check if item already exists using ConditionExpression
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(db.submissionsTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(submissionKey)"),
+	})
+	if err != nil {
+		if isConditionalCheckFailed(err) {
+			return fmt.Errorf("submission already exists")
+		}
+		return fmt.Errorf("failed to create submission: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSubmission deletes a result submission by its submission key
+func (db *DB) DeleteSubmission(ctx context.Context, submissionKey string) error {
+	_, err := db.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(db.submissionsTableName),
+		Key: map[string]types.AttributeValue{
+			"submissionKey": &types.AttributeValueMemberS{Value: submissionKey},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete submission: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSubmissionStatus records the step a result submission has reached. Does nothing if the key isn't stored
+func (db *DB) UpdateSubmissionStatus(ctx context.Context, submissionKey, status string) error {
+	_, err := db.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(db.submissionsTableName),
+		Key: map[string]types.AttributeValue{
+			"submissionKey": &types.AttributeValueMemberS{Value: submissionKey},
+		},
+		UpdateExpression:         aws.String("SET #status = :status"),
+		ConditionExpression:      aws.String("attribute_exists(submissionKey)"),
+		ExpressionAttributeNames: map[string]string{"#status": "status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status": &types.AttributeValueMemberS{Value: status},
+		},
+	})
+	if err != nil && !isConditionalCheckFailed(err) {
+		return fmt.Errorf("failed to update submission: %w", err)
+	}
+
+	return nil
+}
+
+// sportPlayDateKey returns the play history sort key for a round
+// Example: "basketball#2025-11-15"
+func sportPlayDateKey(sport, playDate string) string {
+	return sport + "#" + playDate
+}
+
+// PutPlayHistoryEntry saves a round played by a user, replacing any existing entry for the same round
+func (db *DB) PutPlayHistoryEntry(ctx context.Context, entry *PlayHistoryEntry) error {
+	entry.SportPlayDate = sportPlayDateKey(entry.Sport, entry.PlayDate)
+	if entry.Created.IsZero() {
+		entry.Created = time.Now()
+	}
+
+	// Marshal the entry to DynamoDB format
+	item, err := attributevalue.MarshalMap(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal play history entry: %w", err)
+	}
+
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.playHistoryTableName),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save play history entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetPlayHistoryEntry retrieves the entry for a single round played by a user
+func (db *DB) GetPlayHistoryEntry(ctx context.Context, userId, sport, playDate string) (*PlayHistoryEntry, error) {
+	result, err := db.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(db.playHistoryTableName),
+		Key: map[string]types.AttributeValue{
+			"userId":        &types.AttributeValueMemberS{Value: userId},
+			"sportPlayDate": &types.AttributeValueMemberS{Value: sportPlayDateKey(sport, playDate)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get play history entry: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil // Not found
+	}
+
+	var entry PlayHistoryEntry
+	err = attributevalue.UnmarshalMap(result.Item, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal play history entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// GetPlayHistory retrieves one page of a user's play history, optionally filtered by sport
+// Entries are sorted by sport, then by playDate in descending order (latest to earliest)
+// Pass the returned cursor back in to fetch the next page. An empty cursor means there are no more pages
+func (db *DB) GetPlayHistory(ctx context.Context, userId, sport string, limit int32, cursor string) ([]*PlayHistoryEntry, string, error) {
+	keyConditionExpression := "userId = :userId"
+	expressionAttributeValues := map[string]types.AttributeValue{
+		":userId": &types.AttributeValueMemberS{Value: userId},
+	}
+	if sport != "" {
+		keyConditionExpression += " AND begins_with(sportPlayDate, :sport)"
+		expressionAttributeValues[":sport"] = &types.AttributeValueMemberS{Value: sport + "#"}
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(db.playHistoryTableName),
+		KeyConditionExpression:    aws.String(keyConditionExpression),
+		ExpressionAttributeValues: expressionAttributeValues,
+		ScanIndexForward:          aws.Bool(false), // Sort descending (latest to earliest)
+		Limit:                     aws.Int32(limit),
+	}
+
+	if cursor != "" {
+		sportPlayDate, err := decodePlayHistoryCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"userId":        &types.AttributeValueMemberS{Value: userId},
+			"sportPlayDate": &types.AttributeValueMemberS{Value: sportPlayDate},
+		}
+	}
+
+	result, err := db.client.Query(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query play history: %w", err)
+	}
+
+	entries := []*PlayHistoryEntry{}
+	for _, item := range result.Items {
+		var entry PlayHistoryEntry
+		err = attributevalue.UnmarshalMap(item, &entry)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal play history entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	var nextCursor string
+	if lastKey, ok := result.LastEvaluatedKey["sportPlayDate"].(*types.AttributeValueMemberS); ok {
+		nextCursor = encodePlayHistoryCursor(lastKey.Value)
+	}
+
+	return entries, nextCursor, nil
+}
+
+// encodePlayHistoryCursor turns the last sort key of a page into an opaque cursor
+func encodePlayHistoryCursor(sportPlayDate string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(sportPlayDate))
+}
+
+// decodePlayHistoryCursor turns a cursor back into the sort key to resume after
+// Returns an error "invalid cursor" if the cursor wasn't produced by encodePlayHistoryCursor
+func decodePlayHistoryCursor(cursor string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.Contains(string(decoded), "#") {
+		return "", fmt.Errorf("invalid cursor")
+	}
+	return string(decoded), nil
+}
+
+// leaderboardKey returns the primary key of a player's entry on a leaderboard
+func leaderboardKey(board, userId string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"board":  &types.AttributeValueMemberS{Value: board},
+		"userId": &types.AttributeValueMemberS{Value: userId},
+	}
+}
+
+// AddLeaderboardResult adds a counted result to a player's entry on each board, creating entries if needed
+// Every entry is written in one transaction, conditional on the entries read first being unchanged,
+// and the whole write is retried when the player's entries change concurrently
+func (db *DB) AddLeaderboardResult(ctx context.Context, boards []LeaderboardBoardRef, userId, userName string, result *Result) error {
+	keys := make([]map[string]types.AttributeValue, len(boards))
+	for i, ref := range boards {
+		keys[i] = leaderboardKey(ref.Board, userId)
+	}
+
+	for attempt := 1; ; attempt++ {
+		existing, err := db.batchGetLeaderboardEntries(ctx, keys)
+		if err != nil {
+			return err
+		}
+
+		err = db.writeLeaderboardResult(ctx, boards, existing, userId, userName, result)
+		if isTransactionConditionFailed(err) && attempt < MaxLeaderboardWriteAttempts {
+			continue
+		}
+		if isTransactionConditionFailed(err) {
+			return fmt.Errorf("leaderboard update conflict")
+		}
+		return err
+	}
+}
+
+// writeLeaderboardResult saves a player's entries with the result applied in one transaction
+// Each put is conditional on the entry still having the rounds played it had in existing, or not existing yet
+func (db *DB) writeLeaderboardResult(ctx context.Context, boards []LeaderboardBoardRef, existing []*LeaderboardEntry, userId, userName string, result *Result) error {
+	current := map[string]*LeaderboardEntry{}
+	for _, entry := range existing {
+		current[entry.Board] = entry
+	}
+
+	items := make([]types.TransactWriteItem, len(boards))
+	for i, ref := range boards {
+		entry, ok := current[ref.Board]
+		condition := aws.String("attribute_not_exists(userId)")
+		var values map[string]types.AttributeValue
+		if ok {
+			condition = aws.String("roundsPlayed = :roundsPlayed")
+			values = map[string]types.AttributeValue{
+				":roundsPlayed": &types.AttributeValueMemberN{Value: strconv.Itoa(entry.RoundsPlayed)},
+			}
+		} else {
+			entry = &LeaderboardEntry{Board: ref.Board, UserId: userId}
+		}
+		entry.ExpiresAt = ref.ExpiresAt
+		applyLeaderboardResult(entry, userName, result)
+
+		item, err := attributevalue.MarshalMap(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal leaderboard entry: %w", err)
+		}
+		items[i] = types.TransactWriteItem{Put: &types.Put{
+			TableName:                 aws.String(db.leaderboardTableName),
+			Item:                      item,
+			ConditionExpression:       condition,
+			ExpressionAttributeValues: values,
+		}}
+	}
+
+	_, err := db.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	if err != nil && !isTransactionConditionFailed(err) {
+		return fmt.Errorf("failed to update leaderboard: %w", err)
+	}
+	return err
+}
+
+// putLeaderboardEntry saves a leaderboard entry, replacing any existing one. Used when rebuilding leaderboards
+func (db *DB) putLeaderboardEntry(ctx context.Context, entry *LeaderboardEntry) error {
+	item, err := attributevalue.MarshalMap(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal leaderboard entry: %w", err)
+	}
+
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.leaderboardTableName),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save leaderboard entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetLeaderboard retrieves one page of a leaderboard, ranked by score (highest first)
+// Uses the BoardScoreIndex GSI so only the requested page is read
+func (db *DB) GetLeaderboard(ctx context.Context, board string, limit int32, cursor string) ([]*LeaderboardEntry, string, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(db.leaderboardTableName),
+		IndexName:              aws.String(LeaderboardScoreIndex),
+		KeyConditionExpression: aws.String("board = :board"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":board": &types.AttributeValueMemberS{Value: board},
+		},
+		ScanIndexForward: aws.Bool(false), // Sort descending (highest score first)
+		Limit:            aws.Int32(limit),
+	}
+
+	position := 0
+	if cursor != "" {
+		after, err := decodeLeaderboardCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		position = after.Position
+		input.ExclusiveStartKey = leaderboardKey(board, after.UserId)
+		input.ExclusiveStartKey["score"] = &types.AttributeValueMemberN{Value: strconv.Itoa(after.Score)}
+	}
+
+	result, err := db.client.Query(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+
+	entries := []*LeaderboardEntry{}
+	for _, item := range result.Items {
+		var entry LeaderboardEntry
+		err = attributevalue.UnmarshalMap(item, &entry)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal leaderboard entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	err = rankLeaderboardPage(entries, position, func(score int) (int, error) {
+		return db.countLeaderboardAbove(ctx, board, score)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(result.LastEvaluatedKey) > 0 && len(entries) > 0 {
+		nextCursor = encodeLeaderboardCursor(entries[len(entries)-1], position+len(entries))
+	}
+
+	return entries, nextCursor, nil
+}
+
+// countLeaderboardAbove counts the entries on a leaderboard with a higher score, up to MaxLeaderboardRankCount
+// A COUNT query still reads every matching index entry, a page at a time, so counting stops at the cap
+// to keep ranking a player near the bottom of a large board from reading the whole board
+func (db *DB) countLeaderboardAbove(ctx context.Context, board string, score int) (int, error) {
+	paginator := dynamodb.NewQueryPaginator(db.client, &dynamodb.QueryInput{
+		TableName:              aws.String(db.leaderboardTableName),
+		IndexName:              aws.String(LeaderboardScoreIndex),
+		KeyConditionExpression: aws.String("board = :board AND score > :score"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":board": &types.AttributeValueMemberS{Value: board},
+			":score": &types.AttributeValueMemberN{Value: strconv.Itoa(score)},
+		},
+		Select: types.SelectCount,
+	})
+
+	count := 0
+	for paginator.HasMorePages() && count < MaxLeaderboardRankCount {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count leaderboard entries: %w", err)
+		}
+		count += int(page.Count)
+	}
+	return min(count, MaxLeaderboardRankCount), nil
+}
+
+// GetLeaderboardEntry retrieves a player's entry on a leaderboard with its rank
+func (db *DB) GetLeaderboardEntry(ctx context.Context, board, userId string) (*LeaderboardEntry, error) {
+	result, err := db.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(db.leaderboardTableName),
+		Key:       leaderboardKey(board, userId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard entry: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil // Not found
+	}
+
+	var entry LeaderboardEntry
+	err = attributevalue.UnmarshalMap(result.Item, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal leaderboard entry: %w", err)
+	}
+
+	above, err := db.countLeaderboardAbove(ctx, board, entry.Score)
+	if err != nil {
+		return nil, err
+	}
+	entry.Rank = above + 1
+
+	return &entry, nil
+}
+
+// GetLeaderboardEntriesForUsers retrieves the unranked entries of the given players on a leaderboard
+// Players without an entry are left out
+func (db *DB) GetLeaderboardEntriesForUsers(ctx context.Context, board string, userIds []string) ([]*LeaderboardEntry, error) {
+	keys := make([]map[string]types.AttributeValue, len(userIds))
+	for i, userId := range userIds {
+		keys[i] = leaderboardKey(board, userId)
+	}
+	return db.batchGetLeaderboardEntries(ctx, keys)
+}
+
+// batchGetLeaderboardEntries reads the leaderboard entries with the given keys, DynamoDBBatchGetLimit at a time,
+// retrying any that DynamoDB leaves unprocessed. Keys without an entry are left out
+func (db *DB) batchGetLeaderboardEntries(ctx context.Context, keys []map[string]types.AttributeValue) ([]*LeaderboardEntry, error) {
+	entries := []*LeaderboardEntry{}
+	for start := 0; start < len(keys); start += DynamoDBBatchGetLimit {
+		requests := map[string]types.KeysAndAttributes{
+			db.leaderboardTableName: {Keys: keys[start:min(start+DynamoDBBatchGetLimit, len(keys))], ConsistentRead: aws.Bool(true)},
+		}
+		for len(requests) > 0 {
+			result, err := db.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: requests})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get leaderboard entries: %w", err)
+			}
+
+			for _, item := range result.Responses[db.leaderboardTableName] {
+				var entry LeaderboardEntry
+				if err := attributevalue.UnmarshalMap(item, &entry); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal leaderboard entry: %w", err)
+				}
+				entries = append(entries, &entry)
+			}
+			requests = result.UnprocessedKeys
+		}
+	}
+	return entries, nil
+}
+
+// CreateLeague creates a new league
+// Returns an error "league already exists" if the leagueId or invite code is taken
+// GSIs can't enforce uniqueness, so the invite code is checked with a read first
+func (db *DB) CreateLeague(ctx context.Context, league *League) error {
+	existing, err := db.GetLeagueByInviteCode(ctx, league.InviteCode)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("league already exists")
+	}
+
+	// Set timestamp
+	league.Created = time.Now()
+
+	item, err := attributevalue.MarshalMap(league)
+	if err != nil {
+		return fmt.Errorf("failed to marshal league: %w", err)
+	}
+
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(db.leaguesTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(leagueId)"),
+	})
+	if err != nil {
+		if isConditionalCheckFailed(err) {
+			return fmt.Errorf("league already exists")
+		}
+		return fmt.Errorf("failed to create league: %w", err)
+	}
+
+	return nil
+}
+
+// GetLeague retrieves a league by leagueId
+func (db *DB) GetLeague(ctx context.Context, leagueId string) (*League, error) {
+	result, err := db.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(db.leaguesTableName),
+		Key: map[string]types.AttributeValue{
+			"leagueId": &types.AttributeValueMemberS{Value: leagueId},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil // Not found
+	}
+
+	var league League
+	err = attributevalue.UnmarshalMap(result.Item, &league)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal league: %w", err)
+	}
+
+	return &league, nil
+}
+
+// GetLeagueByInviteCode retrieves a league by its invite code using the InviteCodeIndex GSI
+func (db *DB) GetLeagueByInviteCode(ctx context.Context, inviteCode string) (*League, error) {
+	result, err := db.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(db.leaguesTableName),
+		IndexName:              aws.String(LeagueInviteCodeIndex),
+		KeyConditionExpression: aws.String("inviteCode = :inviteCode"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":inviteCode": &types.AttributeValueMemberS{Value: inviteCode},
+		},
+		Limit: aws.Int32(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leagues: %w", err)
+	}
+
+	if len(result.Items) == 0 {
+		return nil, nil // Not found
+	}
+
+	var league League
+	err = attributevalue.UnmarshalMap(result.Items[0], &league)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal league: %w", err)
+	}
+
+	return &league, nil
+}
+
+// AddLeagueMember adds a player to a league
+// Returns an error "league member already exists" if the player is already a member
+func (db *DB) AddLeagueMember(ctx context.Context, member *LeagueMember) error {
+	// Set timestamp
+	member.Joined = time.Now()
+
+	item, err := attributevalue.MarshalMap(member)
+	if err != nil {
+		return fmt.Errorf("failed to marshal league member: %w", err)
+	}
+
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(db.leagueMembersTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(userId)"),
+	})
+	if err != nil {
+		if isConditionalCheckFailed(err) {
+			return fmt.Errorf("league member already exists")
+		}
+		return fmt.Errorf("failed to add league member: %w", err)
+	}
+
+	return nil
+}
+
+// GetLeagueMembers retrieves every member of a league, sorted by userId
+func (db *DB) GetLeagueMembers(ctx context.Context, leagueId string) ([]*LeagueMember, error) {
+	paginator := dynamodb.NewQueryPaginator(db.client, &dynamodb.QueryInput{
+		TableName:              aws.String(db.leagueMembersTableName),
+		KeyConditionExpression: aws.String("leagueId = :leagueId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":leagueId": &types.AttributeValueMemberS{Value: leagueId},
+		},
+	})
+
+	members := []*LeagueMember{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query league members: %w", err)
+		}
+		for _, item := range page.Items {
+			var member LeagueMember
+			if err := attributevalue.UnmarshalMap(item, &member); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal league member: %w", err)
+			}
+			members = append(members, &member)
+		}
+	}
+
+	return members, nil
+}
+
+// GetUserLeagues retrieves every league a player belongs to, sorted by leagueId
+// Memberships are found with the UserLeaguesIndex GSI, then each league is read from the leagues table
+func (db *DB) GetUserLeagues(ctx context.Context, userId string) ([]*League, error) {
+	paginator := dynamodb.NewQueryPaginator(db.client, &dynamodb.QueryInput{
+		TableName:              aws.String(db.leagueMembersTableName),
+		IndexName:              aws.String(LeagueUserLeaguesIndex),
+		KeyConditionExpression: aws.String("userId = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userId": &types.AttributeValueMemberS{Value: userId},
+		},
+	})
+
+	leagues := []*League{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query user leagues: %w", err)
+		}
+		for _, item := range page.Items {
+			var member LeagueMember
+			if err := attributevalue.UnmarshalMap(item, &member); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal league member: %w", err)
+			}
+			league, err := db.GetLeague(ctx, member.LeagueID)
+			if err != nil {
+				return nil, err
+			}
+			if league != nil {
+				leagues = append(leagues, league)
+			}
+		}
+	}
+
+	return leagues, nil
+}
+
+// CreateRoundEdit saves the audit record of an edit to a round
+// Returns an error "round edit already exists" if an edit was recorded for the round at the same instant
+func (db *DB) CreateRoundEdit(ctx context.Context, edit *RoundEdit) error {
+	setRoundEditKeys(edit)
+
+	item, err := attributevalue.MarshalMap(edit)
+	if err != nil {
+		return fmt.Errorf("failed to marshal round edit: %w", err)
+	}
+
+	_, err = db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(db.roundEditsTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(editKey)"),
+	})
+	if err != nil {
+		if isConditionalCheckFailed(err) {
+			return fmt.Errorf("round edit already exists")
+		}
+		return fmt.Errorf("failed to create round edit: %w", err)
+	}
+
+	return nil
+}
+
+// GetRoundEdits retrieves every edit recorded for a round, oldest first
+func (db *DB) GetRoundEdits(ctx context.Context, sport, playDate string) ([]*RoundEdit, error) {
+	paginator := dynamodb.NewQueryPaginator(db.client, &dynamodb.QueryInput{
+		TableName:              aws.String(db.roundEditsTableName),
+		KeyConditionExpression: aws.String("sportPlayDate = :sportPlayDate"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":sportPlayDate": &types.AttributeValueMemberS{Value: sportPlayDateKey(sport, playDate)},
+		},
+	})
+
+	edits := []*RoundEdit{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query round edits: %w", err)
+		}
+		for _, item := range page.Items {
+			var edit RoundEdit
+			if err := attributevalue.UnmarshalMap(item, &edit); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal round edit: %w", err)
+			}
+			edits = append(edits, &edit)
+		}
+	}
+
+	return edits, nil
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 func TestNewDB(t *testing.T) {
@@ -118,16 +124,16 @@ func TestRoundMarshaling(t *testing.T) {
 				MostCommonLastTileFlipped:  "photo",
 				MostCommonTileFlipped:      "careerStats",
 				LeastCommonTileFlipped:     "jerseyNumbers",
-				FirstTileFlippedTracker: TileFlipTracker{
-					Bio:                  50,
-					PlayerInformation:    30,
-					DraftInformation:     10,
-					TeamsPlayedOn:        5,
-					JerseyNumbers:        2,
-					CareerStats:          1,
-					PersonalAchievements: 1,
-					Photo:                1,
-					YearsActive:          0,
+				FirstTileFlippedTracker: map[string]int{
+					TileBio:                  50,
+					TilePlayerInformation:    30,
+					TileDraftInformation:     10,
+					TileTeamsPlayedOn:        5,
+					TileJerseyNumbers:        2,
+					TileCareerStats:          1,
+					TilePersonalAchievements: 1,
+					TilePhoto:                1,
+					TileYearsActive:          0,
 				},
 			},
 		},
@@ -149,8 +155,8 @@ func TestRoundMarshaling(t *testing.T) {
 	if round.Stats.TotalPlays != 100 {
 		t.Errorf("Stats.TotalPlays = %v, want 100", round.Stats.TotalPlays)
 	}
-	if round.Stats.FirstTileFlippedTracker.Bio != 50 {
-		t.Errorf("FirstTileFlippedTracker.Bio = %v, want 50", round.Stats.FirstTileFlippedTracker.Bio)
+	if round.Stats.FirstTileFlippedTracker[TileBio] != 50 {
+		t.Errorf("FirstTileFlippedTracker[TileBio] = %v, want 50", round.Stats.FirstTileFlippedTracker[TileBio])
 	}
 }
 
@@ -222,59 +228,45 @@ func TestUserStatsMarshaling(t *testing.T) {
 	}
 }
 
-// Test TileFlipTracker structure
-func TestTileFlipTracker(t *testing.T) {
-	tracker := TileFlipTracker{
-		Bio:                  10,
-		PlayerInformation:    20,
-		DraftInformation:     30,
-		TeamsPlayedOn:        40,
-		JerseyNumbers:        50,
-		CareerStats:          60,
-		PersonalAchievements: 70,
-		Photo:                80,
-		YearsActive:          90,
+// TestTileFlipTrackerCompatibility checks trackers saved with a fixed field per tile read back as maps
+func TestTileFlipTrackerCompatibility(t *testing.T) {
+	legacy := map[string]types.AttributeValue{}
+	for i, tile := range AllTiles() {
+		legacy[tile] = &types.AttributeValueMemberN{Value: fmt.Sprint(i * 10)}
 	}
-
-	// Verify all fields
-	expected := map[string]int{
-		"Bio":                  10,
-		"PlayerInformation":    20,
-		"DraftInformation":     30,
-		"TeamsPlayedOn":        40,
-		"JerseyNumbers":        50,
-		"CareerStats":          60,
-		"PersonalAchievements": 70,
-		"Photo":                80,
-		"YearsActive":          90,
+	item := map[string]types.AttributeValue{
+		"totalPlays":              &types.AttributeValueMemberN{Value: "5"},
+		"firstTileFlippedTracker": &types.AttributeValueMemberM{Value: legacy},
 	}
 
-	if tracker.Bio != expected["Bio"] {
-		t.Errorf("Bio = %v, want %v", tracker.Bio, expected["Bio"])
-	}
-	if tracker.PlayerInformation != expected["PlayerInformation"] {
-		t.Errorf("PlayerInformation = %v, want %v", tracker.PlayerInformation, expected["PlayerInformation"])
-	}
-	if tracker.DraftInformation != expected["DraftInformation"] {
-		t.Errorf("DraftInformation = %v, want %v", tracker.DraftInformation, expected["DraftInformation"])
-	}
-	if tracker.TeamsPlayedOn != expected["TeamsPlayedOn"] {
-		t.Errorf("TeamsPlayedOn = %v, want %v", tracker.TeamsPlayedOn, expected["TeamsPlayedOn"])
+	var stats Stats
+	if err := attributevalue.UnmarshalMap(item, &stats); err != nil {
+		t.Fatalf("UnmarshalMap() error = %v", err)
 	}
-	if tracker.JerseyNumbers != expected["JerseyNumbers"] {
-		t.Errorf("JerseyNumbers = %v, want %v", tracker.JerseyNumbers, expected["JerseyNumbers"])
+	for i, tile := range AllTiles() {
+		if stats.FirstTileFlippedTracker[tile] != i*10 {
+			t.Errorf("FirstTileFlippedTracker[%s] = %d, want %d", tile, stats.FirstTileFlippedTracker[tile], i*10)
+		}
 	}
-	if tracker.CareerStats != expected["CareerStats"] {
-		t.Errorf("CareerStats = %v, want %v", tracker.CareerStats, expected["CareerStats"])
-	}
-	if tracker.PersonalAchievements != expected["PersonalAchievements"] {
-		t.Errorf("PersonalAchievements = %v, want %v", tracker.PersonalAchievements, expected["PersonalAchievements"])
+
+	// Trackers that haven't been created are left out rather than saved as NULL, which DynamoDB can't ADD to
+	marshaled, err := attributevalue.MarshalMap(&Stats{})
+	if err != nil {
+		t.Fatalf("MarshalMap() error = %v", err)
 	}
-	if tracker.Photo != expected["Photo"] {
-		t.Errorf("Photo = %v, want %v", tracker.Photo, expected["Photo"])
+	for _, name := range roundStatsMaps {
+		if _, ok := marshaled[name]; ok {
+			t.Errorf("empty stats marshaled %s = %v, want it left out", name, marshaled[name])
+		}
 	}
-	if tracker.YearsActive != expected["YearsActive"] {
-		t.Errorf("YearsActive = %v, want %v", tracker.YearsActive, expected["YearsActive"])
+}
+
+func TestRoundStatsCountersExist(t *testing.T) {
+	condition := roundStatsCountersExist()
+	for _, name := range append([]string{"correctCount"}, roundStatsMaps...) {
+		if !strings.Contains(condition, "attribute_exists(#stats."+name+")") {
+			t.Errorf("condition %q doesn't check %s", condition, name)
+		}
 	}
 }
 
@@ -477,3 +469,86 @@ func TestUserStatsWithUserName(t *testing.T) {
 		t.Errorf("Sports[0].TotalPlays = %v, want 50", stats.Sports[0].Stats.TotalPlays)
 	}
 }
+
+// Test the UpdateItem expression used to apply a result to round stats
+func TestRoundResultCounterUpdate(t *testing.T) {
+	tests := []struct {
+		name           string
+		result         *Result
+		expectedUpdate string
+		expectedNames  map[string]string
+		expectedValues map[string]string
+	}{
+		{
+			name:   "incorrect result without flips",
+			result: &Result{Score: 0, IsCorrect: false, FlippedTiles: []string{}, IncorrectGuesses: 7},
+			expectedUpdate: "ADD #stats.totalPlays :one, #stats.correctCount :correct, #stats.totalCorrectScore :score, #stats.totalTileFlips :flips, " +
+				"#stats.tilesFlippedDistribution.#tilesFlipped :one, #stats.incorrectGuessesDistribution.#incorrectGuesses :one",
+			expectedNames:  map[string]string{"#stats": "stats", "#tilesFlipped": "0", "#incorrectGuesses": "5+"},
+			expectedValues: map[string]string{":one": "1", ":correct": "0", ":score": "0", ":flips": "0"},
+		},
+		{
+			name:   "incorrect result with flips",
+			result: &Result{Score: 0, IsCorrect: false, FlippedTiles: []string{TileInitials}, IncorrectGuesses: 2},
+			expectedUpdate: "ADD #stats.totalPlays :one, #stats.correctCount :correct, #stats.totalCorrectScore :score, #stats.totalTileFlips :flips, " +
+				"#stats.tilesFlippedDistribution.#tilesFlipped :one, #stats.incorrectGuessesDistribution.#incorrectGuesses :one, " +
+				"#stats.firstTileFlippedTracker.#first :one, #stats.lastTileFlippedTracker.#last :one, #stats.lastTileFlippedIncorrectTracker.#last :one, " +
+				"#stats.mostTileFlippedTracker.#tile0 :count0, #stats.tileFlippedResultsTracker.#tile0 :one, #stats.tileFlippedScoreTracker.#tile0 :score",
+			expectedNames: map[string]string{
+				"#stats": "stats", "#tilesFlipped": "1", "#incorrectGuesses": "2",
+				"#first": TileInitials, "#last": TileInitials, "#tile0": TileInitials,
+			},
+			expectedValues: map[string]string{":one": "1", ":correct": "0", ":score": "0", ":flips": "1", ":count0": "1"},
+		},
+		{
+			name:   "correct result with repeated and unknown tiles",
+			result: &Result{Score: 80, IsCorrect: true, FlippedTiles: []string{TilePhoto, "unknown", TileBio, TilePhoto}},
+			expectedUpdate: "ADD #stats.totalPlays :one, #stats.correctCount :correct, #stats.totalCorrectScore :score, #stats.totalTileFlips :flips, " +
+				"#stats.tilesFlippedDistribution.#tilesFlipped :one, #stats.incorrectGuessesDistribution.#incorrectGuesses :one, " +
+				"#stats.scoreDistribution.#scoreBucket :one, " +
+				"#stats.firstTileFlippedTracker.#first :one, #stats.lastTileFlippedTracker.#last :one, #stats.lastTileFlippedCorrectTracker.#last :one, " +
+				"#stats.mostTileFlippedTracker.#tile0 :count0, #stats.tileFlippedResultsTracker.#tile0 :one, #stats.tileFlippedScoreTracker.#tile0 :score, " +
+				"#stats.mostTileFlippedTracker.#tile1 :count1, #stats.tileFlippedResultsTracker.#tile1 :one, #stats.tileFlippedScoreTracker.#tile1 :score",
+			expectedNames: map[string]string{
+				"#stats": "stats", "#tilesFlipped": "2", "#incorrectGuesses": "0", "#scoreBucket": "80",
+				"#first": TilePhoto, "#last": TilePhoto, "#tile0": TileBio, "#tile1": TilePhoto,
+			},
+			expectedValues: map[string]string{
+				":one": "1", ":correct": "1", ":score": "80", ":flips": "4", ":count0": "1", ":count1": "2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, names, values := roundResultCounterUpdate(tt.result)
+			if update != tt.expectedUpdate {
+				t.Errorf("update = %q, want %q", update, tt.expectedUpdate)
+			}
+			if !reflect.DeepEqual(names, tt.expectedNames) {
+				t.Errorf("names = %v, want %v", names, tt.expectedNames)
+			}
+			if len(values) != len(tt.expectedValues) {
+				t.Errorf("got %d values, want %d", len(values), len(tt.expectedValues))
+			}
+			for key, want := range tt.expectedValues {
+				got, ok := values[key].(*types.AttributeValueMemberN)
+				if !ok || got.Value != want {
+					t.Errorf("values[%s] = %v, want %s", key, values[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsConditionalCheckFailed(t *testing.T) {
+	if !isConditionalCheckFailed(fmt.Errorf("operation error: %w", &types.ConditionalCheckFailedException{})) {
+		t.Error("expected wrapped ConditionalCheckFailedException to be detected")
+	}
+	if isConditionalCheckFailed(fmt.Errorf("some other error")) {
+		t.Error("expected other errors not to be detected")
+	}
+	if isConditionalCheckFailed(nil) {
+		t.Error("expected nil error not to be detected")
+	}
+}
@@ -0,0 +1,626 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBTableWaitTimeout is how long Migrate waits for a table or index to become active
+const DynamoDBTableWaitTimeout = 5 * time.Minute
+
+// dynamoDBKey is a key attribute of a table or index
+type dynamoDBKey struct {
+	Name    string
+	Type    types.ScalarAttributeType
+	KeyType types.KeyType
+}
+
+// stringKey is a string key attribute, which is what nearly every table is keyed by
+func stringKey(name string, keyType types.KeyType) dynamoDBKey {
+	return dynamoDBKey{Name: name, Type: types.ScalarAttributeTypeS, KeyType: keyType}
+}
+
+// dynamoDBIndexSpec describes a global secondary index that projects all attributes
+type dynamoDBIndexSpec struct {
+	Name string
+	Keys []dynamoDBKey
+}
+
+// dynamoDBTableSpec describes a table the API expects to exist
+type dynamoDBTableSpec struct {
+	Name         string
+	Keys         []dynamoDBKey
+	Indexes      []dynamoDBIndexSpec
+	TTLAttribute string
+}
+
+// dynamoDBTableSpecs returns the tables used by DB, matching template.yaml
+func dynamoDBTableSpecs(db *DB) []dynamoDBTableSpec {
+	return []dynamoDBTableSpec{
+		{
+			Name: db.roundsTableName,
+			Keys: []dynamoDBKey{stringKey("sport", types.KeyTypeHash), stringKey("playDate", types.KeyTypeRange)},
+		},
+		{
+			Name: db.userStatsTableName,
+			Keys: []dynamoDBKey{stringKey("userId", types.KeyTypeHash)},
+		},
+		{
+			Name:         db.gameSessionsTableName,
+			Keys:         []dynamoDBKey{stringKey("sessionId", types.KeyTypeHash)},
+			TTLAttribute: "expiresAt",
+		},
+		{
+			Name: db.submissionsTableName,
+			Keys: []dynamoDBKey{stringKey("submissionKey", types.KeyTypeHash)},
+		},
+		{
+			Name: db.playHistoryTableName,
+			Keys: []dynamoDBKey{stringKey("userId", types.KeyTypeHash), stringKey("sportPlayDate", types.KeyTypeRange)},
+		},
+		{
+			Name: db.leaderboardTableName,
+			Keys: []dynamoDBKey{stringKey("board", types.KeyTypeHash), stringKey("userId", types.KeyTypeRange)},
+			Indexes: []dynamoDBIndexSpec{{
+				Name: LeaderboardScoreIndex,
+				Keys: []dynamoDBKey{stringKey("board", types.KeyTypeHash), {Name: "score", Type: types.ScalarAttributeTypeN, KeyType: types.KeyTypeRange}},
+			}},
+			TTLAttribute: "expiresAt",
+		},
+		{
+			Name: db.leaguesTableName,
+			Keys: []dynamoDBKey{stringKey("leagueId", types.KeyTypeHash)},
+			Indexes: []dynamoDBIndexSpec{{
+				Name: LeagueInviteCodeIndex,
+				Keys: []dynamoDBKey{stringKey("inviteCode", types.KeyTypeHash)},
+			}},
+		},
+		{
+			Name: db.leagueMembersTableName,
+			Keys: []dynamoDBKey{stringKey("leagueId", types.KeyTypeHash), stringKey("userId", types.KeyTypeRange)},
+			Indexes: []dynamoDBIndexSpec{{
+				Name: LeagueUserLeaguesIndex,
+				Keys: []dynamoDBKey{stringKey("userId", types.KeyTypeHash), stringKey("leagueId", types.KeyTypeRange)},
+			}},
+		},
+		{
+			Name: db.roundEditsTableName,
+			Keys: []dynamoDBKey{stringKey("sportPlayDate", types.KeyTypeHash), stringKey("editKey", types.KeyTypeRange)},
+		},
+		{
+			Name: db.schemaMigrationsTableName,
+			Keys: []dynamoDBKey{{Name: "version", Type: types.ScalarAttributeTypeN, KeyType: types.KeyTypeHash}},
+		},
+	}
+}
+
+// attributeDefinitions lists every key attribute of the table and its indexes once
+func (spec dynamoDBTableSpec) attributeDefinitions() []types.AttributeDefinition {
+	seen := map[string]bool{}
+	var definitions []types.AttributeDefinition
+	add := func(keys []dynamoDBKey) {
+		for _, key := range keys {
+			if seen[key.Name] {
+				continue
+			}
+			seen[key.Name] = true
+			definitions = append(definitions, types.AttributeDefinition{
+				AttributeName: aws.String(key.Name),
+				AttributeType: key.Type,
+			})
+		}
+	}
+
+	add(spec.Keys)
+	for _, index := range spec.Indexes {
+		add(index.Keys)
+	}
+	return definitions
+}
+
+// keySchema converts keys into a DynamoDB key schema
+func keySchema(keys []dynamoDBKey) []types.KeySchemaElement {
+	schema := make([]types.KeySchemaElement, len(keys))
+	for i, key := range keys {
+		schema[i] = types.KeySchemaElement{AttributeName: aws.String(key.Name), KeyType: key.KeyType}
+	}
+	return schema
+}
+
+// globalSecondaryIndex converts an index spec into its DynamoDB definition
+func (index dynamoDBIndexSpec) globalSecondaryIndex() types.GlobalSecondaryIndex {
+	return types.GlobalSecondaryIndex{
+		IndexName:  aws.String(index.Name),
+		KeySchema:  keySchema(index.Keys),
+		Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+	}
+}
+
+// createTableInput builds the CreateTable request for a table spec, including its indexes
+func (spec dynamoDBTableSpec) createTableInput() *dynamodb.CreateTableInput {
+	input := &dynamodb.CreateTableInput{
+		TableName:            aws.String(spec.Name),
+		AttributeDefinitions: spec.attributeDefinitions(),
+		KeySchema:            keySchema(spec.Keys),
+		BillingMode:          types.BillingModePayPerRequest,
+	}
+	for _, index := range spec.Indexes {
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, index.globalSecondaryIndex())
+	}
+	return input
+}
+
+// missingIndexes returns the indexes in the spec that the existing table doesn't have
+func (spec dynamoDBTableSpec) missingIndexes(table *types.TableDescription) []dynamoDBIndexSpec {
+	existing := map[string]bool{}
+	for _, index := range table.GlobalSecondaryIndexes {
+		existing[aws.ToString(index.IndexName)] = true
+	}
+
+	var missing []dynamoDBIndexSpec
+	for _, index := range spec.Indexes {
+		if !existing[index.Name] {
+			missing = append(missing, index)
+		}
+	}
+	return missing
+}
+
+// keySchemaMismatch describes how the existing table's key schema differs from the spec, or returns "" if it matches
+// Keys can't be changed on an existing table, so a mismatch means the configured table name points at the wrong table
+func (spec dynamoDBTableSpec) keySchemaMismatch(table *types.TableDescription) string {
+	want := describeKeySchema(keySchema(spec.Keys))
+	have := describeKeySchema(table.KeySchema)
+	if want == have {
+		return ""
+	}
+	return fmt.Sprintf("has key schema (%s), want (%s)", have, want)
+}
+
+// describeKeySchema formats a key schema as "name HASH, name RANGE", in the order DynamoDB lists it
+func describeKeySchema(schema []types.KeySchemaElement) string {
+	keys := make([]string, len(schema))
+	for i, key := range schema {
+		keys[i] = aws.ToString(key.AttributeName) + " " + string(key.KeyType)
+	}
+	return strings.Join(keys, ", ")
+}
+
+// dynamoDBDataMigration is a versioned change to existing items
+// Migrations must be safe to re-run, since a crash can stop one before it's recorded
+type dynamoDBDataMigration struct {
+	Version     int
+	Description string
+	Run         func(ctx context.Context, db *DB) error
+}
+
+// dynamoDBDataMigrations are applied in order and recorded in the schema migrations table
+// Released migrations must never be edited or renumbered; add a new one instead
+var dynamoDBDataMigrations = []dynamoDBDataMigration{
+	{
+		Version:     1,
+		Description: "backfill raw stat counters on rounds saved before they were tracked",
+		Run:         migrateRoundStatsCounters,
+	},
+	{
+		Version:     2,
+		Description: "move history embedded in user stats to the play history table",
+		Run:         migrateEmbeddedUserHistory,
+	},
+	{
+		Version:     3,
+		Description: "build daily and all-time leaderboards from play history",
+		Run:         migrateLeaderboardsFromPlayHistory,
+	},
+	{
+		Version:     4,
+		Description: "add missing tile tracker and distribution maps to round stats",
+		Run:         migrateRoundStatsMaps,
+	},
+	{
+		Version:     5,
+		Description: "add per-tile outcome tracker maps to round stats",
+		Run:         migrateRoundStatsMaps,
+	},
+	{
+		Version:     6,
+		Description: "rebuild leaderboards from play history, adding rolling 7 and 30 day boards",
+		Run:         migrateLeaderboardsFromPlayHistory,
+	},
+}
+
+// Migrate creates any missing tables and indexes, then applies pending data migrations
+func (db *DB) Migrate(ctx context.Context) error {
+	for _, spec := range dynamoDBTableSpecs(db) {
+		if err := db.ensureTable(ctx, spec); err != nil {
+			return err
+		}
+	}
+
+	applied, err := db.appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range dynamoDBDataMigrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		log.Printf("Applying DynamoDB migration %d: %s", migration.Version, migration.Description)
+		if err := migration.Run(ctx, db); err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		if err := db.recordMigration(ctx, migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureTable creates the table if it doesn't exist, otherwise checks its keys and adds any missing indexes and TTL
+func (db *DB) ensureTable(ctx context.Context, spec dynamoDBTableSpec) error {
+	described, err := db.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(spec.Name)})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		log.Printf("Creating DynamoDB table %s", spec.Name)
+		if _, err := db.client.CreateTable(ctx, spec.createTableInput()); err != nil {
+			return fmt.Errorf("failed to create table %s: %w", spec.Name, err)
+		}
+		if err := db.waitForTable(ctx, spec.Name); err != nil {
+			return err
+		}
+		return db.ensureTimeToLive(ctx, spec)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", spec.Name, err)
+	}
+	if mismatch := spec.keySchemaMismatch(described.Table); mismatch != "" {
+		return fmt.Errorf("table %s %s", spec.Name, mismatch)
+	}
+
+	// DynamoDB only allows one index to be created per UpdateTable call
+	for _, index := range spec.missingIndexes(described.Table) {
+		log.Printf("Adding index %s to DynamoDB table %s", index.Name, spec.Name)
+		gsi := index.globalSecondaryIndex()
+		_, err := db.client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName:            aws.String(spec.Name),
+			AttributeDefinitions: spec.attributeDefinitions(),
+			GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{{
+				Create: &types.CreateGlobalSecondaryIndexAction{
+					IndexName:  gsi.IndexName,
+					KeySchema:  gsi.KeySchema,
+					Projection: gsi.Projection,
+				},
+			}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add index %s to table %s: %w", index.Name, spec.Name, err)
+		}
+		if err := db.waitForTable(ctx, spec.Name); err != nil {
+			return err
+		}
+	}
+
+	return db.ensureTimeToLive(ctx, spec)
+}
+
+// waitForTable waits until the table and all of its indexes are active
+func (db *DB) waitForTable(ctx context.Context, tableName string) error {
+	waiter := dynamodb.NewTableExistsWaiter(db.client)
+	err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)}, DynamoDBTableWaitTimeout,
+		func(o *dynamodb.TableExistsWaiterOptions) {
+			o.Retryable = func(ctx context.Context, in *dynamodb.DescribeTableInput, out *dynamodb.DescribeTableOutput, err error) (bool, error) {
+				if err != nil {
+					var notFound *types.ResourceNotFoundException
+					return errors.As(err, &notFound), nil
+				}
+				if out.Table.TableStatus != types.TableStatusActive {
+					return true, nil
+				}
+				for _, index := range out.Table.GlobalSecondaryIndexes {
+					if index.IndexStatus != types.IndexStatusActive {
+						return true, nil
+					}
+				}
+				return false, nil
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("table %s did not become active: %w", tableName, err)
+	}
+	return nil
+}
+
+// ensureTimeToLive enables TTL on the table's expiry attribute if the spec has one
+func (db *DB) ensureTimeToLive(ctx context.Context, spec dynamoDBTableSpec) error {
+	if spec.TTLAttribute == "" {
+		return nil
+	}
+
+	described, err := db.client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(spec.Name)})
+	if err != nil {
+		return fmt.Errorf("failed to describe TTL on table %s: %w", spec.Name, err)
+	}
+	if ttl := described.TimeToLiveDescription; ttl != nil &&
+		(ttl.TimeToLiveStatus == types.TimeToLiveStatusEnabled || ttl.TimeToLiveStatus == types.TimeToLiveStatusEnabling) {
+		return nil
+	}
+
+	_, err = db.client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(spec.Name),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(spec.TTLAttribute),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable TTL on table %s: %w", spec.Name, err)
+	}
+	return nil
+}
+
+// appliedMigrations returns the versions recorded in the schema migrations table
+func (db *DB) appliedMigrations(ctx context.Context) (map[int]bool, error) {
+	applied := map[int]bool{}
+	err := db.scanTable(ctx, &dynamodb.ScanInput{
+		TableName:            aws.String(db.schemaMigrationsTableName),
+		ProjectionExpression: aws.String("version"),
+	}, func(item map[string]types.AttributeValue) error {
+		var migration struct {
+			Version int `dynamodbav:"version"`
+		}
+		if err := attributevalue.UnmarshalMap(item, &migration); err != nil {
+			return err
+		}
+		applied[migration.Version] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// recordMigration marks a data migration as applied
+func (db *DB) recordMigration(ctx context.Context, migration dynamoDBDataMigration) error {
+	_, err := db.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.schemaMigrationsTableName),
+		Item: map[string]types.AttributeValue{
+			"version":     &types.AttributeValueMemberN{Value: strconv.Itoa(migration.Version)},
+			"description": &types.AttributeValueMemberS{Value: migration.Description},
+			"appliedAt":   &types.AttributeValueMemberS{Value: time.Now().UTC().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+	return nil
+}
+
+// scanTable calls fn for every item returned by the scan, following pagination
+func (db *DB) scanTable(ctx context.Context, input *dynamodb.ScanInput, fn func(item map[string]types.AttributeValue) error) error {
+	paginator := dynamodb.NewScanPaginator(db.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// migrateRoundStatsCounters writes the raw counters for every round that doesn't have them yet
+// ApplyRoundResult does the same lazily; this brings rounds that never get another result up to date
+func migrateRoundStatsCounters(ctx context.Context, db *DB) error {
+	var keys []RoundSummary
+	err := db.scanTable(ctx, &dynamodb.ScanInput{
+		TableName:                aws.String(db.roundsTableName),
+		ProjectionExpression:     aws.String("sport, playDate"),
+		FilterExpression:         aws.String("attribute_not_exists(#stats.correctCount)"),
+		ExpressionAttributeNames: map[string]string{"#stats": "stats"},
+	}, func(item map[string]types.AttributeValue) error {
+		var key RoundSummary
+		if err := attributevalue.UnmarshalMap(item, &key); err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan rounds: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := db.backfillRoundCounters(ctx, key.Sport, key.PlayDate); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Backfilled stat counters on %d rounds", len(keys))
+	return nil
+}
+
+// migrateRoundStatsMaps adds the maps results are ADDed to (see roundStatsMaps) to every round missing one
+// Trackers saved as fixed fields already have a key per tile, so they read back as maps unchanged
+// ApplyRoundResult does the same lazily; this saves rounds their first result from needing a backfill
+func migrateRoundStatsMaps(ctx context.Context, db *DB) error {
+	var missing []string
+	for _, name := range roundStatsMaps {
+		missing = append(missing, "attribute_not_exists(#stats."+name+")")
+	}
+
+	var keys []RoundSummary
+	err := db.scanTable(ctx, &dynamodb.ScanInput{
+		TableName:                aws.String(db.roundsTableName),
+		ProjectionExpression:     aws.String("sport, playDate"),
+		FilterExpression:         aws.String(strings.Join(missing, " OR ")),
+		ExpressionAttributeNames: map[string]string{"#stats": "stats"},
+	}, func(item map[string]types.AttributeValue) error {
+		var key RoundSummary
+		if err := attributevalue.UnmarshalMap(item, &key); err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan rounds: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := db.backfillRoundCounters(ctx, key.Sport, key.PlayDate); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Added stat maps to %d rounds", len(keys))
+	return nil
+}
+
+// migrateEmbeddedUserHistory moves history embedded in user stats to the play history table
+// GetUserStats does the same lazily; this migrates users who haven't been back since
+func migrateEmbeddedUserHistory(ctx context.Context, db *DB) error {
+	var userIds []string
+	err := db.scanTable(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(db.userStatsTableName),
+	}, func(item map[string]types.AttributeValue) error {
+		var userStats UserStats
+		if err := attributevalue.UnmarshalMap(item, &userStats); err != nil {
+			return err
+		}
+		for _, sportStats := range userStats.Sports {
+			if len(sportStats.History) > 0 {
+				userIds = append(userIds, userStats.UserId)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan user stats: %w", err)
+	}
+	sort.Strings(userIds)
+
+	for _, userId := range userIds {
+		if err := db.moveEmbeddedUserHistory(ctx, userId); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Moved embedded history to the play history table for %d users", len(userIds))
+	return nil
+}
+
+// moveEmbeddedUserHistory moves one user's embedded history, re-reading the stats if a submission updates them meanwhile
+func (db *DB) moveEmbeddedUserHistory(ctx context.Context, userId string) error {
+	for attempt := 0; attempt < MaxUserStatsWriteAttempts; attempt++ {
+		userStats, err := db.GetUserStats(ctx, userId)
+		if err != nil {
+			return err
+		}
+		if userStats == nil {
+			return nil
+		}
+
+		entries := splitUserHistory(userStats)
+		if len(entries) == 0 {
+			return nil
+		}
+		for _, entry := range entries {
+			if err := db.PutPlayHistoryEntry(ctx, entry); err != nil {
+				return err
+			}
+		}
+
+		err = db.UpdateUserStats(ctx, userStats)
+		if err == nil || err.Error() != "user stats version conflict" {
+			return err
+		}
+	}
+
+	return fmt.Errorf("user stats for %s kept changing during migration", userId)
+}
+
+// addHistoryToLeaderboards adds a play history result to the entries of every board it's counted on, keyed by
+// board and user id. Archive and pre-release plays are skipped, matching countUserResult, as are rolling boards
+// expired by now
+func addHistoryToLeaderboards(entries map[string]*LeaderboardEntry, history *PlayHistoryEntry, now time.Time) {
+	if history.Result.Archive || history.Result.PreRelease {
+		return
+	}
+	for _, ref := range leaderboardResultBoards(history.Sport, history.PlayDate) {
+		if ref.ExpiresAt != 0 && ref.ExpiresAt <= now.Unix() {
+			continue
+		}
+		key := ref.Board + "|" + history.UserId
+		entry, ok := entries[key]
+		if !ok {
+			entry = &LeaderboardEntry{Board: ref.Board, UserId: history.UserId, ExpiresAt: ref.ExpiresAt}
+			entries[key] = entry
+		}
+		applyLeaderboardResult(entry, "", &history.Result)
+	}
+}
+
+// migrateLeaderboardsFromPlayHistory rebuilds every leaderboard entry from the play history table,
+// on every board a result is counted on (see leaderboardResultBoards)
+// Entries are overwritten with the totals, so re-running it doesn't double count
+// Runs after migration 2, so history that was embedded in user stats is included
+func migrateLeaderboardsFromPlayHistory(ctx context.Context, db *DB) error {
+	now := time.Now()
+	entries := map[string]*LeaderboardEntry{}
+	err := db.scanTable(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(db.playHistoryTableName),
+	}, func(item map[string]types.AttributeValue) error {
+		var history PlayHistoryEntry
+		if err := attributevalue.UnmarshalMap(item, &history); err != nil {
+			return err
+		}
+		addHistoryToLeaderboards(entries, &history, now)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan play history: %w", err)
+	}
+
+	userNames := map[string]string{}
+	for _, entry := range entries {
+		userName, ok := userNames[entry.UserId]
+		if !ok {
+			userStats, err := db.GetUserStats(ctx, entry.UserId)
+			if err != nil {
+				return err
+			}
+			if userStats != nil {
+				userName = userStats.UserName
+			}
+			userNames[entry.UserId] = userName
+		}
+		entry.UserName = userName
+
+		if err := db.putLeaderboardEntry(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Rebuilt %d leaderboard entries for %d users", len(entries), len(userNames))
+	return nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestDynamoDBTableSpecs(t *testing.T) {
+	db := &DB{
+		roundsTableName:           "Rounds",
+		userStatsTableName:        "UserStats",
+		gameSessionsTableName:     "GameSessions",
+		submissionsTableName:      "Submissions",
+		playHistoryTableName:      "PlayHistory",
+		leaderboardTableName:      "Leaderboard",
+		leaguesTableName:          "Leagues",
+		leagueMembersTableName:    "LeagueMembers",
+		schemaMigrationsTableName: "SchemaMigrations",
+	}
+
+	specs := dynamoDBTableSpecs(db)
+	names := map[string]dynamoDBTableSpec{}
+	for _, spec := range specs {
+		names[spec.Name] = spec
+	}
+	for _, name := range []string{"Rounds", "UserStats", "GameSessions", "Submissions", "PlayHistory", "Leaderboard", "Leagues", "LeagueMembers", "SchemaMigrations"} {
+		if _, ok := names[name]; !ok {
+			t.Errorf("missing table spec for %s", name)
+		}
+	}
+
+	for _, name := range []string{"GameSessions", "Leaderboard"} {
+		if names[name].TTLAttribute != "expiresAt" {
+			t.Errorf("%s TTLAttribute = %q, want expiresAt", name, names[name].TTLAttribute)
+		}
+	}
+
+	if indexes := names["Leaderboard"].Indexes; len(indexes) != 1 || indexes[0].Name != LeaderboardScoreIndex {
+		t.Errorf("Leaderboard indexes = %+v, want %s", indexes, LeaderboardScoreIndex)
+	}
+	if indexes := names["Leagues"].Indexes; len(indexes) != 1 || indexes[0].Name != LeagueInviteCodeIndex {
+		t.Errorf("Leagues indexes = %+v, want %s", indexes, LeagueInviteCodeIndex)
+	}
+	if indexes := names["LeagueMembers"].Indexes; len(indexes) != 1 || indexes[0].Name != LeagueUserLeaguesIndex {
+		t.Errorf("LeagueMembers indexes = %+v, want %s", indexes, LeagueUserLeaguesIndex)
+	}
+
+	// Rounds are queried by sport with a playDate range, so the table must be keyed that way
+	rounds := names["Rounds"].Keys
+	if len(rounds) != 2 || rounds[0].Name != "sport" || rounds[0].KeyType != types.KeyTypeHash ||
+		rounds[1].Name != "playDate" || rounds[1].KeyType != types.KeyTypeRange {
+		t.Errorf("Rounds keys = %+v, want sport HASH and playDate RANGE", rounds)
+	}
+}
+
+func TestDynamoDBTableSpecCreateTableInput(t *testing.T) {
+	spec := dynamoDBTableSpec{
+		Name: "Example",
+		Keys: []dynamoDBKey{stringKey("sport", types.KeyTypeHash), stringKey("playDate", types.KeyTypeRange)},
+		Indexes: []dynamoDBIndexSpec{
+			{Name: "PlayDateIndex", Keys: []dynamoDBKey{stringKey("playDate", types.KeyTypeHash), stringKey("sport", types.KeyTypeRange)}},
+			{Name: "ScoreIndex", Keys: []dynamoDBKey{stringKey("sport", types.KeyTypeHash), {Name: "score", Type: types.ScalarAttributeTypeN, KeyType: types.KeyTypeRange}}},
+		},
+	}
+
+	input := spec.createTableInput()
+
+	if aws.ToString(input.TableName) != "Example" || input.BillingMode != types.BillingModePayPerRequest {
+		t.Errorf("unexpected table name or billing mode: %s, %s", aws.ToString(input.TableName), input.BillingMode)
+	}
+
+	// Key attributes shared between the table and its indexes are defined once
+	definitions := map[string]types.ScalarAttributeType{}
+	for _, definition := range input.AttributeDefinitions {
+		if _, ok := definitions[aws.ToString(definition.AttributeName)]; ok {
+			t.Errorf("attribute %s defined more than once", aws.ToString(definition.AttributeName))
+		}
+		definitions[aws.ToString(definition.AttributeName)] = definition.AttributeType
+	}
+	expected := map[string]types.ScalarAttributeType{
+		"sport":    types.ScalarAttributeTypeS,
+		"playDate": types.ScalarAttributeTypeS,
+		"score":    types.ScalarAttributeTypeN,
+	}
+	if len(definitions) != len(expected) {
+		t.Errorf("AttributeDefinitions = %v, want %v", definitions, expected)
+	}
+	for name, attributeType := range expected {
+		if definitions[name] != attributeType {
+			t.Errorf("attribute %s type = %s, want %s", name, definitions[name], attributeType)
+		}
+	}
+
+	if len(input.GlobalSecondaryIndexes) != 2 {
+		t.Fatalf("GlobalSecondaryIndexes has %d entries, want 2", len(input.GlobalSecondaryIndexes))
+	}
+	if input.GlobalSecondaryIndexes[0].Projection.ProjectionType != types.ProjectionTypeAll {
+		t.Errorf("index projection = %s, want ALL", input.GlobalSecondaryIndexes[0].Projection.ProjectionType)
+	}
+}
+
+func TestDynamoDBTableSpecMissingIndexes(t *testing.T) {
+	spec := dynamoDBTableSpec{
+		Name: "Example",
+		Keys: []dynamoDBKey{stringKey("id", types.KeyTypeHash)},
+		Indexes: []dynamoDBIndexSpec{
+			{Name: "FirstIndex", Keys: []dynamoDBKey{stringKey("first", types.KeyTypeHash)}},
+			{Name: "SecondIndex", Keys: []dynamoDBKey{stringKey("second", types.KeyTypeHash)}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		existing []string
+		expected []string
+	}{
+		{name: "no indexes yet", existing: nil, expected: []string{"FirstIndex", "SecondIndex"}},
+		{name: "one index exists", existing: []string{"SecondIndex"}, expected: []string{"FirstIndex"}},
+		{name: "all indexes exist", existing: []string{"FirstIndex", "SecondIndex", "OtherIndex"}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &types.TableDescription{}
+			for _, name := range tt.existing {
+				table.GlobalSecondaryIndexes = append(table.GlobalSecondaryIndexes, types.GlobalSecondaryIndexDescription{IndexName: aws.String(name)})
+			}
+
+			missing := spec.missingIndexes(table)
+			if len(missing) != len(tt.expected) {
+				t.Fatalf("missingIndexes() = %+v, want %v", missing, tt.expected)
+			}
+			for i, index := range missing {
+				if index.Name != tt.expected[i] {
+					t.Errorf("missingIndexes()[%d] = %s, want %s", i, index.Name, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDynamoDBTableSpecKeySchemaMismatch(t *testing.T) {
+	spec := dynamoDBTableSpec{
+		Name: "PlayHistory",
+		Keys: []dynamoDBKey{stringKey("userId", types.KeyTypeHash), stringKey("sportPlayDate", types.KeyTypeRange)},
+	}
+
+	tests := []struct {
+		name     string
+		keys     []types.KeySchemaElement
+		mismatch bool
+	}{
+		{name: "same keys", keys: keySchema(spec.Keys), mismatch: false},
+		{name: "missing sort key", keys: []types.KeySchemaElement{{AttributeName: aws.String("userId"), KeyType: types.KeyTypeHash}}, mismatch: true},
+		{name: "different partition key", keys: []types.KeySchemaElement{
+			{AttributeName: aws.String("sessionId"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("sportPlayDate"), KeyType: types.KeyTypeRange},
+		}, mismatch: true},
+		{name: "keys swapped", keys: []types.KeySchemaElement{
+			{AttributeName: aws.String("sportPlayDate"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("userId"), KeyType: types.KeyTypeRange},
+		}, mismatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatch := spec.keySchemaMismatch(&types.TableDescription{KeySchema: tt.keys})
+			if (mismatch != "") != tt.mismatch {
+				t.Errorf("keySchemaMismatch() = %q, want a mismatch: %v", mismatch, tt.mismatch)
+			}
+		})
+	}
+}
+
+func TestDynamoDBDataMigrationsOrdered(t *testing.T) {
+	for i, migration := range dynamoDBDataMigrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %d has version %d, want versions numbered 1, 2, 3, ...", i, migration.Version)
+		}
+		if migration.Description == "" || migration.Run == nil {
+			t.Errorf("migration %d must have a description and a Run function", migration.Version)
+		}
+	}
+}
+
+func TestAddHistoryToLeaderboards(t *testing.T) {
+	entries := map[string]*LeaderboardEntry{}
+	history := []*PlayHistoryEntry{
+		{UserId: "user-1", Sport: SportBasketball, PlayDate: "2025-01-10", Result: Result{Score: 80, IsCorrect: true}},
+		{UserId: "user-1", Sport: SportBasketball, PlayDate: "2025-01-11", Result: Result{Score: 50}},
+		{UserId: "user-1", Sport: SportBasketball, PlayDate: "2025-01-05", Result: Result{Score: 90, IsCorrect: true, Archive: true}},
+		{UserId: "user-1", Sport: SportBasketball, PlayDate: "2025-01-14", Result: Result{Score: 100, IsCorrect: true, PreRelease: true}},
+	}
+	now := time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)
+	for _, entry := range history {
+		addHistoryToLeaderboards(entries, entry, now)
+	}
+
+	allTime := entries[leaderboardBoard(SportBasketball, LeaderboardBoardAllTime)+"|user-1"]
+	if allTime == nil {
+		t.Fatal("expected an all-time entry")
+	}
+	if allTime.Score != 130 || allTime.RoundsPlayed != 2 || allTime.CorrectCount != 1 || allTime.HighestScore != 80 {
+		t.Errorf("all-time entry = %+v, want score 130 over 2 rounds with 1 correct and highest 80", allTime)
+	}
+	if _, ok := entries[leaderboardBoard(SportBasketball, "2025-01-05")+"|user-1"]; ok {
+		t.Error("archive play should not be added to its round's board")
+	}
+	if _, ok := entries[leaderboardWindowBoard(SportBasketball, LeaderboardWindowWeek, "2025-01-05")+"|user-1"]; ok {
+		t.Error("archive play should not be added to rolling boards")
+	}
+	if _, ok := entries[leaderboardBoard(SportBasketball, "2025-01-14")+"|user-1"]; ok {
+		t.Error("pre-release play should not be added to its round's board")
+	}
+	week := entries[leaderboardWindowBoard(SportBasketball, LeaderboardWindowWeek, "2025-01-11")+"|user-1"]
+	if week == nil || week.Score != 130 || week.ExpiresAt == 0 {
+		t.Errorf("7d entry ending 2025-01-11 = %+v, want score 130 with an expiry", week)
+	}
+
+	// Rolling boards that have already expired aren't rebuilt
+	entries = map[string]*LeaderboardEntry{}
+	addHistoryToLeaderboards(entries, history[0], now.AddDate(0, 0, LeaderboardWindowRetentionDays+40))
+	if len(entries) != 2 {
+		t.Errorf("rebuilt %d entries long after the round, want only the daily and all-time boards", len(entries))
+	}
+}
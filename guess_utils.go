@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// nameSuffixes are generational suffixes that are ignored when comparing a guess to a player's name
+var nameSuffixes = map[string]bool{
+	"jr":  true,
+	"sr":  true,
+	"ii":  true,
+	"iii": true,
+	"iv":  true,
+	"v":   true,
+}
+
+// normalizeName reduces a name to a canonical form for guess comparison
+// Strips accents, lowercases, removes punctuation and drops generational suffixes
+// Example: "Ronald Acuña Jr." -> "ronald acuna"
+// Example: "Ken Griffey, Jr" -> "ken griffey"
+func normalizeName(name string) string {
+	// Decompose accented characters (e.g., "ñ" -> "n" + combining tilde) and drop the marks
+	var stripped strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			stripped.WriteRune(unicode.ToLower(r))
+		case r == '\'' || r == '’' || r == '.':
+			// Apostrophes and periods join letters (e.g., "O'Neal", "C.J.")
+		default:
+			// Hyphens, commas and whitespace separate words
+			stripped.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(stripped.String())
+
+	// Only drop a suffix when something remains, so a guess of just "Jr" never matches everything
+	for len(words) > 1 && nameSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// isCorrectGuess checks whether a guess matches the player's name or one of their nicknames
+// Comparison is accent-, case-, punctuation- and suffix-insensitive
+func isCorrectGuess(player *Player, guess string) bool {
+	if player == nil {
+		return false
+	}
+
+	normalizedGuess := normalizeName(guess)
+	if normalizedGuess == "" {
+		return false
+	}
+
+	if normalizedGuess == normalizeName(player.Name) {
+		return true
+	}
+
+	// Nicknames are stored as a comma separated list (e.g., "King James, LBJ")
+	for _, nickname := range strings.Split(player.Nicknames, ",") {
+		if normalizedNickname := normalizeName(nickname); normalizedNickname != "" && normalizedGuess == normalizedNickname {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactRound returns a copy of the round with the answer and every tile cleared
+// This is the "puzzle view" served to players who have not yet solved or revealed the round
+// Tile content is handed out one tile at a time through a game session (see FlipTile)
+func redactRound(round *Round) *Round {
+	if round == nil {
+		return nil
+	}
+
+	puzzle := *round
+	puzzle.Player = Player{Sport: round.Player.Sport}
+	puzzle.Stats.Name = ""
+
+	return &puzzle
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "simple name", input: "LeBron James", expected: "lebron james"},
+		{name: "accented name", input: "Ronald Acuña", expected: "ronald acuna"},
+		{name: "suffix with period", input: "Ronald Acuña Jr.", expected: "ronald acuna"},
+		{name: "suffix after comma", input: "Ken Griffey, Jr", expected: "ken griffey"},
+		{name: "roman numeral suffix", input: "Robert Griffin III", expected: "robert griffin"},
+		{name: "apostrophe", input: "Shaquille O'Neal", expected: "shaquille oneal"},
+		{name: "initials with periods", input: "C.J. Stroud", expected: "cj stroud"},
+		{name: "hyphenated name", input: "Karl-Anthony Towns", expected: "karl anthony towns"},
+		{name: "extra whitespace", input: "  Derek   Jeter ", expected: "derek jeter"},
+		{name: "only suffix is kept", input: "Jr.", expected: "jr"},
+		{name: "empty string", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := normalizeName(tt.input)
+			if result != tt.expected {
+				t.Errorf("normalizeName(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsCorrectGuess(t *testing.T) {
+	player := &Player{
+		Name:      "Ronald Acuña Jr.",
+		Nicknames: "El Abusador, Acuña Matata",
+	}
+
+	tests := []struct {
+		name     string
+		player   *Player
+		guess    string
+		expected bool
+	}{
+		{name: "exact name", player: player, guess: "Ronald Acuña Jr.", expected: true},
+		{name: "no accent", player: player, guess: "Ronald Acuna Jr.", expected: true},
+		{name: "no suffix", player: player, guess: "ronald acuna", expected: true},
+		{name: "different case", player: player, guess: "RONALD ACUÑA", expected: true},
+		{name: "matches nickname", player: player, guess: "el abusador", expected: true},
+		{name: "matches second nickname", player: player, guess: "Acuna Matata", expected: true},
+		{name: "last name only", player: player, guess: "Acuña", expected: false},
+		{name: "wrong player", player: player, guess: "Mike Trout", expected: false},
+		{name: "empty guess", player: player, guess: "", expected: false},
+		{name: "suffix only guess", player: player, guess: "Jr.", expected: false},
+		{name: "nil player", player: nil, guess: "Ronald Acuña", expected: false},
+		{name: "player without nicknames", player: &Player{Name: "Derek Jeter"}, guess: "derek jeter", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isCorrectGuess(tt.player, tt.guess)
+			if result != tt.expected {
+				t.Errorf("isCorrectGuess(%q) = %v, want %v", tt.guess, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedactRound(t *testing.T) {
+	round := &Round{
+		Sport:    SportBasketball,
+		PlayDate: "2026-02-10",
+		Theme:    "GOAT",
+		Player: Player{
+			Sport:              SportBasketball,
+			Name:               "LeBron James",
+			Nicknames:          "King James",
+			SportsReferenceURL: "https://www.basketball-reference.com/players/j/jamesle01.html",
+			Bio:                "Born: Dec 30, 1984",
+			Initials:           "L.J.",
+		},
+		Stats: RoundStats{
+			Name:  "LeBron James",
+			Stats: Stats{TotalPlays: 10},
+		},
+	}
+
+	puzzle := redactRound(round)
+
+	if puzzle.Player.Name != "" || puzzle.Player.Nicknames != "" || puzzle.Player.SportsReferenceURL != "" || puzzle.Stats.Name != "" {
+		t.Errorf("Expected answer fields to be redacted, got %+v", puzzle.Player)
+	}
+	if puzzle.Player.Bio != "" || puzzle.Player.Initials != "" {
+		t.Errorf("Expected tile fields to be redacted, got %+v", puzzle.Player)
+	}
+	if puzzle.Player.Sport != SportBasketball {
+		t.Errorf("Expected player sport to be kept, got %q", puzzle.Player.Sport)
+	}
+	if puzzle.Theme != "GOAT" || puzzle.Stats.TotalPlays != 10 {
+		t.Errorf("Expected non-answer round fields to be kept")
+	}
+
+	// The original round must not be modified
+	if round.Player.Name != "LeBron James" || round.Stats.Name != "LeBron James" {
+		t.Errorf("Expected original round to be unchanged")
+	}
+
+	if redactRound(nil) != nil {
+		t.Errorf("Expected nil round to stay nil")
+	}
+}
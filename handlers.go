@@ -1,7 +1,13 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,12 +15,29 @@ import (
 
 // Server holds dependencies for HTTP handlers
 type Server struct {
-	db *DB
+	db Store
+
+	// archiveStreakRule decides which streaks archive plays count toward (see ArchiveStreakRuleNone)
+	archiveStreakRule string
 }
 
-// NewServer creates a new Server with the given database
-func NewServer(db *DB) *Server {
-	return &Server{db: db}
+// NewServer creates a new Server with the given store
+// Archive plays don't affect streaks until SetArchiveStreakRule says otherwise
+func NewServer(db Store) *Server {
+	return &Server{db: db, archiveStreakRule: ArchiveStreakRuleNone}
+}
+
+// SetArchiveStreakRule sets which streaks archive plays count toward
+// Returns an error if the rule isn't one of the ArchiveStreakRule* constants
+func (s *Server) SetArchiveStreakRule(rule string) error {
+	switch rule {
+	case ArchiveStreakRuleNone, ArchiveStreakRuleWin, ArchiveStreakRuleAll:
+		s.archiveStreakRule = rule
+		return nil
+	default:
+		return fmt.Errorf("invalid archive streak rule '%s': expected '%s', '%s' or '%s'",
+			rule, ArchiveStreakRuleNone, ArchiveStreakRuleWin, ArchiveStreakRuleAll)
+	}
 }
 
 // GetRound handles GET /v1/round
@@ -43,7 +66,7 @@ func (s *Server) GetRound(c *gin.Context) {
 
 	playDate := c.Query(QueryParamPlayDate)
 	if playDate == "" {
-		playDate = time.Now().Format(DateFormatYYYYMMDD)
+		playDate = time.Now().In(getUserTimezone(c)).Format(DateFormatYYYYMMDD)
 	}
 
 	round, err := s.db.GetRound(c.Request.Context(), sport, playDate)
@@ -57,7 +80,8 @@ func (s *Server) GetRound(c *gin.Context) {
 		return
 	}
 
-	if round == nil {
+	// Unreleased rounds look missing so the schedule stays secret
+	if round == nil || !isRoundReleased(c, playDate) {
 		c.JSON(http.StatusNotFound, gin.H{
 			JSONFieldError:     StatusNotFound,
 			JSONFieldMessage:   "No round found for the specified sport and playDate",
@@ -67,9 +91,134 @@ func (s *Server) GetRound(c *gin.Context) {
 		return
 	}
 
+	// Hide the answer until the player has solved or revealed the round
+	if !s.canViewAnswer(c, sport, playDate) {
+		round = redactRound(round)
+	}
+
 	c.JSON(http.StatusOK, round)
 }
 
+// canViewAnswer reports whether the caller may see the unredacted round
+// Playtesters and admins always can. Authenticated players can once the round is in their history
+func (s *Server) canViewAnswer(c *gin.Context, sport, playDate string) bool {
+	if hasAnyRole(c, RolePlaytester, RoleAdmin) {
+		return true
+	}
+
+	userId := getContextUserId(c)
+	if userId == "" {
+		return false
+	}
+
+	played, err := s.hasPlayedRound(c.Request.Context(), userId, sport, playDate)
+	if err != nil {
+		// Fail closed: serve the puzzle view if we can't confirm the round was played
+		return false
+	}
+
+	return played
+}
+
+// GuessRound handles POST /v1/round/guess - checks a guess against the round's answer
+func (s *Server) GuessRound(c *gin.Context) {
+	sport := c.Query(QueryParamSport)
+	playDate := c.Query(QueryParamPlayDate)
+
+	if sport == "" || playDate == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Sport and playDate parameters are required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	var guess GuessRequest
+	if err := c.ShouldBindJSON(&guess); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid request body: " + err.Error(),
+			JSONFieldCode:      ErrorInvalidRequestBody,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	if guess.Guess == "" && !guess.Reveal {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Missing required field: guess",
+			JSONFieldCode:      ErrorMissingRequiredField,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	round, err := s.db.GetRound(c.Request.Context(), sport, playDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to retrieve round: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if round == nil || !isRoundReleased(c, playDate) {
+		c.JSON(http.StatusNotFound, gin.H{
+			JSONFieldError:     StatusNotFound,
+			JSONFieldMessage:   "Round not found for sport '" + sport + "' on date '" + playDate + "'",
+			JSONFieldCode:      ErrorRoundNotFound,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	response := GuessResponse{
+		IsCorrect: isCorrectGuess(&round.Player, guess.Guess),
+		Revealed:  guess.Reveal,
+	}
+
+	// Record the guess against the player's game session so results can be computed server-side
+	if guess.SessionID != "" {
+		session := s.loadGameSession(c, guess.SessionID)
+		if session == nil {
+			return
+		}
+		if session.Sport != sport || session.PlayDate != playDate {
+			c.JSON(http.StatusBadRequest, gin.H{
+				JSONFieldError:     StatusBadRequest,
+				JSONFieldMessage:   "Game session does not match the specified sport and playDate",
+				JSONFieldCode:      ErrorSessionMismatch,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+
+		if recordGuess(session, response.IsCorrect, response.Revealed) {
+			err = s.db.UpdateGameSession(c.Request.Context(), session)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					JSONFieldError:     StatusInternalServerError,
+					JSONFieldMessage:   "Failed to update game session: " + err.Error(),
+					JSONFieldCode:      ErrorDatabaseError,
+					JSONFieldTimestamp: time.Now(),
+				})
+				return
+			}
+		}
+	}
+
+	// Only hand out the answer once the round is over for this player
+	if response.IsCorrect || response.Revealed {
+		response.Player = &round.Player
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // CreateRound handles PUT /v1/round
 func (s *Server) CreateRound(c *gin.Context) {
 	var round Round
@@ -265,9 +414,10 @@ func (s *Server) GetRounds(c *gin.Context) {
 			startDate = FIRST_ROUND_DATE_STRING
 		}
 
+		// Only list released rounds so the schedule stays secret (see GetUpcomingRounds)
 		endDate := endDateQuery
-		if endDate == "" {
-			endDate = time.Now().Format(DateFormatYYYYMMDD)
+		if latest := latestReleasedDate(c, time.Now()); endDate == "" || (endDate > latest && !hasAnyRole(c, RolePlaytester, RoleAdmin)) {
+			endDate = latest
 		}
 
 		return startDate, endDate
@@ -298,6 +448,69 @@ func (s *Server) GetUpcomingRounds(c *gin.Context) {
 	})
 }
 
+// GetRoundsByDifficulty handles GET /v1/rounds/difficulty
+// Lists past rounds that have a difficulty rating, hardest first unless order=easiest
+// Defaults to every round from the first round date through today; later dates are never included
+func (s *Server) GetRoundsByDifficulty(c *gin.Context) {
+	sport := c.Query(QueryParamSport)
+	if sport == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Sport parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	order := c.DefaultQuery(QueryParamOrder, RoundDifficultyOrderHardest)
+	if order != RoundDifficultyOrderHardest && order != RoundDifficultyOrderEasiest {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid order '" + order + "'. Expected '" + RoundDifficultyOrderHardest + "' or '" + RoundDifficultyOrderEasiest + "'",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	// Released on the same boundary as GetRound, so the list never names a round the caller can't open
+	latest := latestReleasedDate(c, time.Now())
+	startDate := c.DefaultQuery(QueryParamStartDate, FIRST_ROUND_DATE_STRING)
+	endDate := c.DefaultQuery(QueryParamEndDate, latest)
+	if endDate > latest {
+		endDate = latest
+	}
+
+	summaries, err := s.db.GetRoundsBySport(c.Request.Context(), sport, startDate, endDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to retrieve rounds: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	rounds := make([]*RoundSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		if summary.Difficulty != nil {
+			rounds = append(rounds, summary)
+		}
+	}
+
+	// Summaries come latest first, so equally difficult rounds stay in that order
+	sort.SliceStable(rounds, func(i, j int) bool {
+		if order == RoundDifficultyOrderEasiest {
+			return *rounds[i].Difficulty < *rounds[j].Difficulty
+		}
+		return *rounds[i].Difficulty > *rounds[j].Difficulty
+	})
+
+	c.JSON(http.StatusOK, rounds)
+}
+
 // SubmitResults handles POST /v1/results
 func (s *Server) SubmitResults(c *gin.Context) {
 	sport := c.Query(QueryParamSport)
@@ -313,8 +526,33 @@ func (s *Server) SubmitResults(c *gin.Context) {
 		return
 	}
 
-	var result Result
-	if err := c.ShouldBindJSON(&result); err != nil {
+	if !isRoundReleased(c, playDate) {
+		c.JSON(http.StatusNotFound, gin.H{
+			JSONFieldError:     StatusNotFound,
+			JSONFieldMessage:   "Round not found for sport '" + sport + "' on date '" + playDate + "'",
+			JSONFieldCode:      ErrorRoundNotFound,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	sessionId := c.Query(QueryParamSessionId)
+	if sessionId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "sessionId parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	// The client may report the result it computed locally. It is only used to flag disagreements
+	var reportedResult *Result
+	var body Result
+	if err := c.ShouldBindJSON(&body); err == nil {
+		reportedResult = &body
+	} else if !errors.Is(err, io.EOF) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			JSONFieldError:     StatusBadRequest,
 			JSONFieldMessage:   "Invalid request body: " + err.Error(),
@@ -324,160 +562,217 @@ func (s *Server) SubmitResults(c *gin.Context) {
 		return
 	}
 
-	// potential hack catcher. Score cannot be higher than 100 or lower than 0
-	if result.Score > 100 || result.Score < 0 {
+	session := s.loadGameSession(c, sessionId)
+	if session == nil {
+		return
+	}
+	if session.Sport != sport || session.PlayDate != playDate {
 		c.JSON(http.StatusBadRequest, gin.H{
 			JSONFieldError:     StatusBadRequest,
-			JSONFieldMessage:   "Invalid request body: Score cannot be greater than 100 or less than 0",
-			JSONFieldCode:      ErrorInvalidRequestBody,
+			JSONFieldMessage:   "Game session does not match the specified sport and playDate",
+			JSONFieldCode:      ErrorSessionMismatch,
 			JSONFieldTimestamp: time.Now(),
 		})
 		return
 	}
 
-	round, err := s.db.GetRound(c.Request.Context(), sport, playDate)
+	// Resubmitting the same play-through returns the original result
+	if session.Submitted {
+		if session.Result != nil {
+			replaySubmission(c, *session.Result)
+		} else {
+			replaySubmission(c, buildResultFromSession(session))
+		}
+		return
+	}
+
+	// A player's first submission for a round is the one that counts
+	keys := submissionKeys(c, sport, playDate, sessionId)
+	existing, err := s.findSubmission(c.Request.Context(), keys)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			JSONFieldError:     StatusInternalServerError,
-			JSONFieldMessage:   "Failed to retrieve round: " + err.Error(),
+			JSONFieldMessage:   "Failed to retrieve submission: " + err.Error(),
 			JSONFieldCode:      ErrorDatabaseError,
 			JSONFieldTimestamp: time.Now(),
 		})
 		return
 	}
-	if round == nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			JSONFieldError:     StatusNotFound,
-			JSONFieldMessage:   "Round not found for sport '" + sport + "' on date '" + playDate + "'",
-			JSONFieldCode:      ErrorRoundNotFound,
-			JSONFieldTimestamp: time.Now(),
-		})
+	if existing != nil {
+		s.finishSubmission(c, keys, existing)
 		return
 	}
 
-	// Update round statistics
-	updateStatsWithResult(&round.Stats.Stats, &result)
+	// The session and scoring engine are the source of truth
+	result := buildResultFromSession(session)
+	if reportedResult != nil && reportedResult.Score != result.Score {
+		log.Printf("Score mismatch for session %s (%s %s): reported %d, computed %d",
+			sessionId, sport, playDate, reportedResult.Score, result.Score)
+		result.Flagged = true
+	}
 
-	// Save the updated round
-	err = s.db.UpdateRound(c.Request.Context(), round)
+	// Rounds from before the player's today are archive plays
+	// Use the user's timezone from the header (or UTC fallback) so today's round stays live until their midnight
+	today := time.Now().In(getUserTimezone(c)).Format(DateFormatYYYYMMDD)
+	result.Archive = playDate < today
+	result.PreRelease = isPreReleaseRound(playDate, time.Now())
+
+	// Claim the submission before touching any aggregates so concurrent repeats are only counted once
+	existing, err = s.claimSubmission(c.Request.Context(), keys, Submission{
+		Sport:     sport,
+		PlayDate:  playDate,
+		SessionID: sessionId,
+		Result:    result,
+		Status:    SubmissionStatusClaimed,
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			JSONFieldError:     StatusInternalServerError,
-			JSONFieldMessage:   "Failed to update round: " + err.Error(),
+			JSONFieldMessage:   "Failed to record submission: " + err.Error(),
 			JSONFieldCode:      ErrorDatabaseError,
 			JSONFieldTimestamp: time.Now(),
 		})
 		return
 	}
+	if existing != nil {
+		s.finishSubmission(c, keys, existing)
+		return
+	}
 
-	// Get user_id from bearer token (set by JWT middleware)
-	userIdToken, exists := c.Get(ConstantUserId)
-	if exists && userIdToken != "" {
-		userId, ok := userIdToken.(string)
-		if ok && userId != "" {
-			var overwriteUsername string
-			usernameToken, exists := c.Get(ConstantUsername)
-			if exists && usernameToken != "" {
-				overwriteUsername = usernameToken.(string)
-			}
-
-			// Fetch existing user stats or create new ones
-			userStats, err := s.db.GetUserStats(c.Request.Context(), userId)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					JSONFieldError:     StatusInternalServerError,
-					JSONFieldMessage:   "Failed to retrieve user stats: " + err.Error(),
-					JSONFieldCode:      ErrorDatabaseError,
+	// Update round statistics with atomic counters so concurrent submissions don't overwrite each other
+	// Only this step is undone by releasing the claim. Once the round is counted the claim is kept,
+	// and a retry finishes the remaining steps instead of counting the round again (see finishSubmission)
+	// Pre-release plays aren't counted, so playtesting doesn't show up in a round's stats before anyone can play it
+	if !result.PreRelease {
+		err = s.db.ApplyRoundResult(c.Request.Context(), sport, playDate, &result)
+		if err != nil {
+			s.releaseSubmission(c.Request.Context(), keys)
+			if err.Error() == "round not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					JSONFieldError:     StatusNotFound,
+					JSONFieldMessage:   "Round not found for sport '" + sport + "' on date '" + playDate + "'",
+					JSONFieldCode:      ErrorRoundNotFound,
 					JSONFieldTimestamp: time.Now(),
 				})
 				return
 			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				JSONFieldError:     StatusInternalServerError,
+				JSONFieldMessage:   "Failed to update round: " + err.Error(),
+				JSONFieldCode:      ErrorDatabaseError,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+	}
+	s.markSubmission(c.Request.Context(), keys, SubmissionStatusRoundCounted)
 
-			// Get user's timezone from header (or UTC fallback)
-			userLoc := getUserTimezone(c)
-			// Calculate today's date in the user's local timezone
-			today := time.Now().In(userLoc).Format(DateFormatYYYYMMDD)
-
-			// If user stats don't exist, create new user stats
-			if userStats == nil {
-				userStats = &UserStats{
-					UserId:             userId,
-					Sports:             []UserSportStats{},
-					CurrentDailyStreak: 1,
-					LastDayPlayed:      today, // Track real-life date in user's timezone, not round playDate
-					UserName:           overwriteUsername,
-				}
-			} else {
-				// Update daily streak based on real-life date in user's timezone (engagement-based tracking)
-				updateDailyStreak(userStats, today)
-			}
+	if err := s.countUserResult(c, keys, SubmissionStatusRoundCounted, sport, playDate, today, &result); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to count result: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	s.markSubmission(c.Request.Context(), keys, SubmissionStatusComplete)
 
-			// update username
-			if overwriteUsername != "" {
-				userStats.UserName = overwriteUsername
-			}
+	// Mark the session as submitted so the same play-through can't be counted twice
+	session.Submitted = true
+	session.Result = &result
+	err = s.db.UpdateGameSession(c.Request.Context(), session)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to update game session: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
 
-			// Find or create specific sport stats
-			var sportStats *UserSportStats
-			for i := range userStats.Sports {
-				if userStats.Sports[i].Sport == sport {
-					sportStats = &userStats.Sports[i]
-					break
-				}
-			}
+	c.JSON(http.StatusOK, result)
+}
 
-			// If sport stats don't exist, create new entry
-			if sportStats == nil {
-				newSportStats := UserSportStats{
-					Sport: sport,
-				}
-				userStats.Sports = append(userStats.Sports, newSportStats)
-				sportStats = &userStats.Sports[len(userStats.Sports)-1]
-			}
+// countUserResult applies a result to the signed-in caller's stats and leaderboards. Anonymous results only count toward the round
+// status is how far the submission has been counted, so a retry after the user stats were saved only updates the leaderboards
+func (s *Server) countUserResult(c *gin.Context, keys []string, status, sport, playDate, today string, result *Result) error {
+	// Get user_id from bearer token (set by JWT middleware)
+	userId := getContextUserId(c)
+	if userId == "" {
+		return nil
+	}
 
-			// Update sport-specific stats
-			updateStatsWithResult(&sportStats.Stats, &result)
-
-			// Check if history entry for this playDate already exists
-			historyExists := false
-			for i := range sportStats.History {
-				if sportStats.History[i].PlayDate == playDate {
-					// Update existing history entry instead of creating duplicate
-					sportStats.History[i].Result = result
-					historyExists = true
-					break
-				}
-			}
+	var overwriteUsername string
+	usernameToken, exists := c.Get(ConstantUsername)
+	if exists && usernameToken != "" {
+		overwriteUsername, _ = usernameToken.(string)
+	}
 
-			// Only append if this playDate doesn't already exist in history
-			if !historyExists {
-				roundHistory := RoundHistory{
-					PlayDate: playDate,
-					Result:   result,
-				}
-				sportStats.History = append(sportStats.History, roundHistory)
-			}
+	if status != SubmissionStatusUserCounted {
+		if err := s.saveUserResult(c.Request.Context(), userId, overwriteUsername, sport, playDate, today, result); err != nil {
+			return err
+		}
+		s.markSubmission(c.Request.Context(), keys, SubmissionStatusUserCounted)
+	}
 
-			// Save or update user stats in DynamoDB
-			if userStats.UserCreated.IsZero() {
-				err = s.db.CreateUserStats(c.Request.Context(), userStats)
-			} else {
-				err = s.db.UpdateUserStats(c.Request.Context(), userStats)
-			}
+	// Archive plays aren't ranked since the round's answer has been public since it ended,
+	// and pre-release plays since they're playtesting rather than competing
+	if result.Archive || result.PreRelease {
+		return nil
+	}
+	return s.recordLeaderboardResult(c.Request.Context(), userId, overwriteUsername, sport, playDate, result)
+}
 
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					JSONFieldError:     StatusInternalServerError,
-					JSONFieldMessage:   "Failed to update user stats: " + err.Error(),
-					JSONFieldCode:      ErrorDatabaseError,
-					JSONFieldTimestamp: time.Now(),
-				})
-				return
+// saveUserResult applies a result to a user's stats and saves them
+// Writes are conditional on the version that was read, so on a conflict the stats are re-read
+// and the result re-applied, up to MaxUserStatsWriteAttempts times
+func (s *Server) saveUserResult(ctx context.Context, userId, username, sport, playDate, today string, result *Result) error {
+	// Record the play first. The write is idempotent, so a retried submission can't duplicate it
+	err := s.db.PutPlayHistoryEntry(ctx, &PlayHistoryEntry{
+		UserId:   userId,
+		Sport:    sport,
+		PlayDate: playDate,
+		Result:   *result,
+	})
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; attempt <= MaxUserStatsWriteAttempts; attempt++ {
+		// Fetch existing user stats or create new ones
+		var userStats *UserStats
+		userStats, err = s.db.GetUserStats(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		isNew := userStats == nil
+		if !isNew {
+			// Move any history still embedded in the item out to the play history table
+			if _, err = s.migrateUserHistory(ctx, userStats); err != nil {
+				return err
 			}
 		}
+		userStats = recordUserResult(userStats, userId, username, sport, today, s.archiveStreakRule, result)
+
+		// Save or update user stats in DynamoDB
+		if isNew {
+			err = s.db.CreateUserStats(ctx, userStats)
+		} else {
+			err = s.db.UpdateUserStats(ctx, userStats)
+		}
+
+		// Another request wrote the stats first, re-read and try again
+		if err != nil && (err.Error() == "user stats version conflict" || err.Error() == "user stats already exist") {
+			log.Printf("User stats write conflict for user %s (attempt %d of %d)", userId, attempt, MaxUserStatsWriteAttempts)
+			continue
+		}
+		return err
 	}
 
-	c.JSON(http.StatusOK, result)
+	return err
 }
 
 // GetRoundStats handles GET /v1/stats/round
@@ -506,7 +801,8 @@ func (s *Server) GetRoundStats(c *gin.Context) {
 		return
 	}
 
-	if round == nil {
+	// Stats name the player, so unreleased rounds look missing too
+	if round == nil || !isRoundReleased(c, playDate) {
 		c.JSON(http.StatusNotFound, gin.H{
 			JSONFieldError:     StatusNotFound,
 			JSONFieldMessage:   "No statistics found for sport '" + sport + "' on date '" + playDate + "'",
@@ -516,7 +812,13 @@ func (s *Server) GetRoundStats(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, round.Stats)
+	// Stats.Name is the answer, so it's hidden the same way GetRound hides it
+	stats := round.Stats
+	if !s.canViewAnswer(c, sport, playDate) {
+		stats.Name = ""
+	}
+
+	c.JSON(http.StatusOK, stats)
 }
 
 // GetUserStats handles GET /v1/stats/user
@@ -564,6 +866,24 @@ func (s *Server) GetUserStats(c *gin.Context) {
 		return
 	}
 
+	// Move any history still embedded in the item out to the play history table
+	migrated, err := s.migrateUserHistory(c.Request.Context(), stats)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to migrate play history: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if migrated {
+		// Best effort: if this loses a race, the next submission clears the embedded history instead
+		if err := s.db.UpdateUserStats(c.Request.Context(), stats); err != nil {
+			log.Printf("Failed to clear embedded play history for user %s: %v", userId, err)
+		}
+	}
+
 	c.JSON(http.StatusOK, stats)
 }
 
@@ -647,6 +967,18 @@ func (s *Server) MigrateUserStats(c *gin.Context) {
 	userStats.UserId = userId
 	userStats.UserName = username
 
+	// Save the uploaded history to the play history table rather than the user stats item
+	_, err = s.migrateUserHistory(c.Request.Context(), &userStats)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to migrate play history: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
 	// Save user stats to DynamoDB
 	err = s.db.CreateUserStats(c.Request.Context(), &userStats)
 	if err != nil {
@@ -690,7 +1022,19 @@ func (s *Server) ScrapeAndCreateRound(c *gin.Context) {
 		return
 	}
 
-	// 4. Build and save round
+	// 4. In a dry run, return the round that would be created for review without saving it.
+	// An admin confirms it by sending the round (edited if need be) to PUT /v1/round
+	if params.DryRun {
+		round, err := buildRoundFromPlayer(player, params)
+		if err != nil {
+			respondWithScrapeError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, RoundPreview{Round: round, Warnings: scrapeWarnings(player)})
+		return
+	}
+
+	// 5. Build and save round
 	round, err := s.createRoundFromPlayer(c.Request.Context(), player, params)
 	if err != nil {
 		respondWithScrapeError(c, err)
@@ -2,10 +2,13 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,9 +19,9 @@ func init() {
 }
 
 // getTestServer creates a Server instance for testing
-// Note: This uses nil for the DB since these tests only validate input parameters
+// Backed by an in-memory store so handler flows can be exercised end to end
 func getTestServer() *Server {
-	return &Server{db: nil}
+	return NewServer(NewMemoryStore())
 }
 
 // TestHandleGetRound tests the handleGetRound function's input validation
@@ -66,6 +69,62 @@ func TestHandleGetRound(t *testing.T) {
 	}
 }
 
+// TestHandleGuessRound tests the GuessRound function's input validation
+func TestHandleGuessRound(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "missing query parameters",
+			queryParams:    "sport=basketball",
+			body:           `{"guess":"LeBron James"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "MISSING_REQUIRED_PARAMETER",
+		},
+		{
+			name:           "invalid request body",
+			queryParams:    "sport=basketball&playDate=2024-01-01",
+			body:           "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_REQUEST_BODY",
+		},
+		{
+			name:           "missing guess without reveal",
+			queryParams:    "sport=basketball&playDate=2024-01-01",
+			body:           `{"guess":""}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "MISSING_REQUIRED_FIELD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/v1/round/guess?"+tt.queryParams, bytes.NewReader([]byte(tt.body)))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			getTestServer().GuessRound(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedCode != "" {
+				var errResp map[string]interface{}
+				json.NewDecoder(w.Body).Decode(&errResp)
+				if code, ok := errResp["code"].(string); !ok || code != tt.expectedCode {
+					t.Errorf("Expected error code %s, got %v", tt.expectedCode, errResp["code"])
+				}
+			}
+		})
+	}
+}
+
 // TestHandleCreateRound tests the handleCreateRound function's input validation
 func TestHandleCreateRound(t *testing.T) {
 	tests := []struct {
@@ -243,6 +302,12 @@ func TestHandleSubmitResults(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedCode:   "MISSING_REQUIRED_PARAMETER",
 		},
+		{
+			name:           "missing sessionId parameter",
+			queryParams:    "sport=basketball&playDate=2024-01-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "MISSING_REQUIRED_PARAMETER",
+		},
 	}
 
 	for _, tt := range tests {
@@ -311,6 +376,46 @@ func TestHandleGetRoundStats(t *testing.T) {
 	}
 }
 
+// TestGetRoundStatsHidesAnswer checks that the stats of a released round don't name the player before it's played
+func TestGetRoundStatsHidesAnswer(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+	playDate := time.Now().UTC().Format(DateFormatYYYYMMDD)
+
+	err := server.db.CreateRound(ctx, &Round{
+		Sport:    "basketball",
+		PlayDate: playDate,
+		Player:   Player{Name: "LeBron James"},
+		Stats:    RoundStats{Name: "LeBron James"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	if err := server.db.ApplyRoundResult(ctx, "basketball", playDate, &Result{Score: 80, IsCorrect: true}); err != nil {
+		t.Fatalf("ApplyRoundResult() error = %v", err)
+	}
+
+	path := "/v1/stats/round?sport=basketball&playDate=" + playDate
+	w := performRequest(server.GetRoundStats, http.MethodGet, path, nil, "")
+	var stats RoundStats
+	json.NewDecoder(w.Body).Decode(&stats)
+	if w.Code != http.StatusOK || stats.Name != "" || stats.TotalPlays != 1 {
+		t.Errorf("anonymous GetRoundStats: status %d, stats %+v, want 200, one play and no name", w.Code, stats)
+	}
+
+	// Playtesters can always see the answer
+	w = httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, path, nil)
+	c.Set(ConstantRoles, []string{RolePlaytester})
+	server.GetRoundStats(c)
+	stats = RoundStats{}
+	json.NewDecoder(w.Body).Decode(&stats)
+	if w.Code != http.StatusOK || stats.Name != "LeBron James" {
+		t.Errorf("playtester GetRoundStats: status %d, name %q, want LeBron James", w.Code, stats.Name)
+	}
+}
+
 // TestHandleGetUserStats tests the handleGetUserStats function's input validation
 func TestHandleGetUserStats(t *testing.T) {
 	tests := []struct {
@@ -349,3 +454,401 @@ func TestHandleGetUserStats(t *testing.T) {
 		})
 	}
 }
+
+// performRequest runs a handler against a test request, optionally as a signed-in user
+func performRequest(handler gin.HandlerFunc, method, path string, body interface{}, userId string) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		payload, _ := json.Marshal(body)
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, reader)
+	c.Request.Header.Set("Content-Type", "application/json")
+	if userId != "" {
+		c.Set(ConstantUserId, userId)
+	}
+
+	handler(c)
+	return w
+}
+
+// TestGameFlow plays a round end to end against the in-memory store
+func TestGameFlow(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+	userId := "auth0|player"
+	// Today's round, so the result counts toward the live stats rather than as an archive play
+	playDate := time.Now().UTC().Format(DateFormatYYYYMMDD)
+
+	err := server.db.CreateRound(ctx, &Round{
+		RoundID:  "basketball#" + playDate,
+		Sport:    "basketball",
+		PlayDate: playDate,
+		Player:   Player{Sport: "basketball", Name: "LeBron James", Bio: "Born in Akron, Ohio"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+
+	// The answer is hidden before the round is played
+	w := performRequest(server.GetRound, http.MethodGet, "/v1/round?sport=basketball&playDate="+playDate, nil, userId)
+	var round Round
+	json.NewDecoder(w.Body).Decode(&round)
+	if w.Code != http.StatusOK || round.Player.Name != "" {
+		t.Fatalf("GetRound before playing: status %d, name %q, want 200 and a redacted name", w.Code, round.Player.Name)
+	}
+
+	w = performRequest(server.StartGameSession, http.MethodPost, "/v1/round/session?sport=basketball&playDate="+playDate, nil, userId)
+	var session GameSession
+	json.NewDecoder(w.Body).Decode(&session)
+	if w.Code != http.StatusCreated || session.SessionID == "" {
+		t.Fatalf("StartGameSession: status %d, sessionId %q", w.Code, session.SessionID)
+	}
+
+	w = performRequest(server.FlipTile, http.MethodPost, "/v1/round/flip?sessionId="+session.SessionID+"&tile="+TileBio, nil, userId)
+	var tile TileResponse
+	json.NewDecoder(w.Body).Decode(&tile)
+	if w.Code != http.StatusOK || tile.Value != "Born in Akron, Ohio" {
+		t.Fatalf("FlipTile: status %d, value %q", w.Code, tile.Value)
+	}
+
+	w = performRequest(server.GuessRound, http.MethodPost, "/v1/round/guess?sport=basketball&playDate="+playDate,
+		GuessRequest{Guess: "Kobe Bryant", SessionID: session.SessionID}, userId)
+	var guess GuessResponse
+	json.NewDecoder(w.Body).Decode(&guess)
+	if w.Code != http.StatusOK || guess.IsCorrect || guess.Player != nil {
+		t.Fatalf("wrong guess: status %d, response %+v", w.Code, guess)
+	}
+
+	w = performRequest(server.GuessRound, http.MethodPost, "/v1/round/guess?sport=basketball&playDate="+playDate,
+		GuessRequest{Guess: "lebron james", SessionID: session.SessionID}, userId)
+	json.NewDecoder(w.Body).Decode(&guess)
+	if w.Code != http.StatusOK || !guess.IsCorrect || guess.Player == nil {
+		t.Fatalf("correct guess: status %d, response %+v", w.Code, guess)
+	}
+
+	submitPath := "/v1/results?sport=basketball&playDate=" + playDate + "&sessionId=" + session.SessionID
+	w = performRequest(server.SubmitResults, http.MethodPost, submitPath, nil, userId)
+	var result Result
+	json.NewDecoder(w.Body).Decode(&result)
+	expectedScore := calculateScore("basketball", true, []string{TileBio}, 1)
+	if w.Code != http.StatusOK || result.Score != expectedScore || result.IncorrectGuesses != 1 {
+		t.Fatalf("SubmitResults: status %d, result %+v, want score %d", w.Code, result, expectedScore)
+	}
+	if w.Header().Get(HeaderIdempotentReplayed) != "" {
+		t.Error("first submission should not be marked as replayed")
+	}
+
+	// Submitting again replays the original result without counting it twice
+	w = performRequest(server.SubmitResults, http.MethodPost, submitPath, nil, userId)
+	if w.Code != http.StatusOK || w.Header().Get(HeaderIdempotentReplayed) != "true" {
+		t.Errorf("repeat submission: status %d, replayed header %q", w.Code, w.Header().Get(HeaderIdempotentReplayed))
+	}
+
+	stored, _ := server.db.GetRound(ctx, "basketball", playDate)
+	if stored.Stats.TotalPlays != 1 || stored.Stats.HighestScore != expectedScore {
+		t.Errorf("round stats: %d plays, highest score %d, want 1 and %d", stored.Stats.TotalPlays, stored.Stats.HighestScore, expectedScore)
+	}
+
+	userStats, _ := server.db.GetUserStats(ctx, userId)
+	if userStats == nil || len(userStats.Sports) != 1 || userStats.Sports[0].Stats.TotalPlays != 1 {
+		t.Fatalf("user stats = %+v, want one basketball play", userStats)
+	}
+
+	entry, _ := server.db.GetPlayHistoryEntry(ctx, userId, "basketball", playDate)
+	if entry == nil || entry.Score != expectedScore {
+		t.Errorf("play history entry = %+v, want score %d", entry, expectedScore)
+	}
+
+	// The answer is visible once the round is in the player's history
+	w = performRequest(server.GetRound, http.MethodGet, "/v1/round?sport=basketball&playDate="+playDate, nil, userId)
+	json.NewDecoder(w.Body).Decode(&round)
+	if round.Player.Name != "LeBron James" {
+		t.Errorf("GetRound after playing: name %q, want LeBron James", round.Player.Name)
+	}
+
+	// The counted result is on the round's leaderboard exactly once
+	w = performRequest(server.GetLeaderboard, http.MethodGet, "/v1/leaderboard?sport=basketball&playDate="+playDate, nil, userId)
+	var leaderboard Leaderboard
+	json.NewDecoder(w.Body).Decode(&leaderboard)
+	if w.Code != http.StatusOK || len(leaderboard.Items) != 1 || leaderboard.Items[0].Score != expectedScore || leaderboard.Items[0].RoundsPlayed != 1 {
+		t.Errorf("GetLeaderboard: status %d, items %+v, want one entry with score %d", w.Code, leaderboard.Items, expectedScore)
+	}
+	if leaderboard.Me == nil || leaderboard.Me.Rank != 1 {
+		t.Errorf("GetLeaderboard me = %+v, want rank 1", leaderboard.Me)
+	}
+}
+
+// TestSubmitArchiveResult submits a result for a past round, which is recorded as an archive play
+func TestSubmitArchiveResult(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+	userId := "auth0|player"
+
+	if err := server.db.CreateRound(ctx, &Round{Sport: "basketball", PlayDate: "2025-11-15", Player: Player{Name: "LeBron James"}}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+
+	w := performRequest(server.StartGameSession, http.MethodPost, "/v1/round/session?sport=basketball&playDate=2025-11-15", nil, userId)
+	var session GameSession
+	json.NewDecoder(w.Body).Decode(&session)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("StartGameSession: status %d", w.Code)
+	}
+
+	w = performRequest(server.SubmitResults, http.MethodPost, "/v1/results?sport=basketball&playDate=2025-11-15&sessionId="+session.SessionID, nil, userId)
+	var result Result
+	json.NewDecoder(w.Body).Decode(&result)
+	if w.Code != http.StatusOK || !result.Archive {
+		t.Fatalf("SubmitResults: status %d, result %+v, want an archive play", w.Code, result)
+	}
+
+	stored, _ := server.db.GetRound(ctx, "basketball", "2025-11-15")
+	if stored.Stats.TotalPlays != 0 || stored.Stats.ArchivePlays != 1 {
+		t.Errorf("round stats: %d plays, %d archive plays, want 0 and 1", stored.Stats.TotalPlays, stored.Stats.ArchivePlays)
+	}
+
+	userStats, _ := server.db.GetUserStats(ctx, userId)
+	if userStats == nil || userStats.CurrentDailyStreak != 0 || len(userStats.Sports) != 1 ||
+		userStats.Sports[0].Stats.TotalPlays != 0 || userStats.Sports[0].ArchivePlays != 1 {
+		t.Fatalf("user stats = %+v, want one basketball archive play and no streak", userStats)
+	}
+
+	entry, _ := server.db.GetPlayHistoryEntry(ctx, userId, "basketball", "2025-11-15")
+	if entry == nil || !entry.Archive {
+		t.Errorf("play history entry = %+v, want an archive play", entry)
+	}
+
+	w = performRequest(server.GetLeaderboard, http.MethodGet, "/v1/leaderboard?sport=basketball&playDate=2025-11-15", nil, userId)
+	var leaderboard Leaderboard
+	json.NewDecoder(w.Body).Decode(&leaderboard)
+	if len(leaderboard.Items) != 0 {
+		t.Errorf("GetLeaderboard items = %+v, want archive plays left off", leaderboard.Items)
+	}
+}
+
+// TestSubmitPreReleaseResult submits a playtester's result for an unreleased round, which stays out of
+// the round's stats and the leaderboards
+func TestSubmitPreReleaseResult(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+	userId := "auth0|playtester"
+	playDate := time.Now().UTC().AddDate(0, 0, 2).Format(DateFormatYYYYMMDD)
+
+	if err := server.db.CreateRound(ctx, &Round{Sport: "basketball", PlayDate: playDate, Player: Player{Name: "LeBron James"}}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+
+	asPlaytester := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set(ConstantRoles, []string{RolePlaytester})
+			handler(c)
+		}
+	}
+
+	w := performRequest(asPlaytester(server.StartGameSession), http.MethodPost, "/v1/round/session?sport=basketball&playDate="+playDate, nil, userId)
+	var session GameSession
+	json.NewDecoder(w.Body).Decode(&session)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("StartGameSession: status %d", w.Code)
+	}
+
+	w = performRequest(asPlaytester(server.SubmitResults), http.MethodPost, "/v1/results?sport=basketball&playDate="+playDate+"&sessionId="+session.SessionID, nil, userId)
+	var result Result
+	json.NewDecoder(w.Body).Decode(&result)
+	if w.Code != http.StatusOK || !result.PreRelease || result.Archive {
+		t.Fatalf("SubmitResults: status %d, result %+v, want a pre-release play", w.Code, result)
+	}
+
+	stored, _ := server.db.GetRound(ctx, "basketball", playDate)
+	if stored.Stats.TotalPlays != 0 || stored.Stats.ArchivePlays != 0 {
+		t.Errorf("round stats: %d plays, %d archive plays, want the pre-release play left out", stored.Stats.TotalPlays, stored.Stats.ArchivePlays)
+	}
+
+	entry, _ := server.db.GetPlayHistoryEntry(ctx, userId, "basketball", playDate)
+	if entry == nil || !entry.PreRelease {
+		t.Errorf("play history entry = %+v, want a pre-release play", entry)
+	}
+
+	for _, board := range []string{leaderboardBoard("basketball", playDate), leaderboardBoard("basketball", LeaderboardBoardAllTime)} {
+		if entry, _ := server.db.GetLeaderboardEntry(ctx, board, userId); entry != nil {
+			t.Errorf("leaderboard entry on %s = %+v, want pre-release plays left off", board, entry)
+		}
+	}
+}
+
+func TestGetRoundsByDifficulty(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+
+	// Easy, hard and unplayed rounds, plus one in the future
+	rounds := []struct {
+		playDate string
+		correct  bool
+		plays    int
+	}{
+		{"2026-02-10", true, RoundDifficultyMinPlays},
+		{"2026-02-11", false, RoundDifficultyMinPlays},
+		{"2026-02-12", true, 1},
+		{"2999-01-01", false, RoundDifficultyMinPlays},
+	}
+	for _, round := range rounds {
+		if err := server.db.CreateRound(ctx, &Round{Sport: SportBasketball, PlayDate: round.playDate}); err != nil {
+			t.Fatalf("CreateRound() error = %v", err)
+		}
+		result := &Result{IsCorrect: round.correct}
+		if round.correct {
+			result.Score = 100
+		}
+		for i := 0; i < round.plays; i++ {
+			if err := server.db.ApplyRoundResult(ctx, SportBasketball, round.playDate, result); err != nil {
+				t.Fatalf("ApplyRoundResult() error = %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedDates  []string
+	}{
+		{name: "hardest first", query: "sport=basketball&startDate=2026-02-01&endDate=2999-12-31", expectedStatus: http.StatusOK, expectedDates: []string{"2026-02-11", "2026-02-10"}},
+		{name: "easiest first", query: "sport=basketball&startDate=2026-02-01&order=easiest", expectedStatus: http.StatusOK, expectedDates: []string{"2026-02-10", "2026-02-11"}},
+		{name: "no rated rounds", query: "sport=football", expectedStatus: http.StatusOK, expectedDates: []string{}},
+		{name: "missing sport", query: "", expectedStatus: http.StatusBadRequest},
+		{name: "invalid order", query: "sport=basketball&order=random", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(server.GetRoundsByDifficulty, http.MethodGet, "/v1/rounds/difficulty?"+tt.query, nil, "")
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.expectedStatus, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var summaries []*RoundSummary
+			if err := json.NewDecoder(w.Body).Decode(&summaries); err != nil {
+				t.Fatalf("failed to decode rounds: %v", err)
+			}
+			dates := []string{}
+			for _, summary := range summaries {
+				if summary.Difficulty == nil {
+					t.Errorf("round %s has no difficulty", summary.PlayDate)
+				}
+				dates = append(dates, summary.PlayDate)
+			}
+			if !reflect.DeepEqual(dates, tt.expectedDates) {
+				t.Errorf("rounds = %v, want %v", dates, tt.expectedDates)
+			}
+		})
+	}
+
+	// A round is listed from the moment GetRound releases it in the caller's timezone
+	now := time.Now()
+	earliestToday := now.In(earliestTimezone).Format(DateFormatYYYYMMDD)
+	if err := server.db.CreateRound(ctx, &Round{Sport: SportFootball, PlayDate: earliestToday}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	for i := 0; i < RoundDifficultyMinPlays; i++ {
+		server.db.ApplyRoundResult(ctx, SportFootball, earliestToday, &Result{IsCorrect: true, Score: 100})
+	}
+	for _, timezone := range []string{"Pacific/Kiritimati", "America/Los_Angeles"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/rounds/difficulty?sport=football&endDate=2999-12-31", nil)
+		c.Request.Header.Set(HeaderUserTimezone, timezone)
+		server.GetRoundsByDifficulty(c)
+
+		var summaries []*RoundSummary
+		json.NewDecoder(w.Body).Decode(&summaries)
+		listed := len(summaries) == 1
+		if released := isRoundReleased(c, earliestToday); listed != released {
+			t.Errorf("%s: round %s listed = %v, but released = %v", timezone, earliestToday, listed, released)
+		}
+	}
+}
+
+func TestSetArchiveStreakRule(t *testing.T) {
+	server := getTestServer()
+	if server.archiveStreakRule != ArchiveStreakRuleNone {
+		t.Errorf("default archiveStreakRule = %q, want %q", server.archiveStreakRule, ArchiveStreakRuleNone)
+	}
+
+	for _, rule := range []string{ArchiveStreakRuleNone, ArchiveStreakRuleWin, ArchiveStreakRuleAll} {
+		if err := server.SetArchiveStreakRule(rule); err != nil || server.archiveStreakRule != rule {
+			t.Errorf("SetArchiveStreakRule(%q) error = %v, rule = %q", rule, err, server.archiveStreakRule)
+		}
+	}
+
+	if err := server.SetArchiveStreakRule("daily"); err == nil {
+		t.Error("SetArchiveStreakRule(\"daily\") error = nil, want an invalid rule error")
+	}
+	if server.archiveStreakRule != ArchiveStreakRuleAll {
+		t.Errorf("archiveStreakRule = %q after an invalid rule, want it unchanged", server.archiveStreakRule)
+	}
+}
+
+// TestFutureRoundsHidden checks unreleased rounds are only served to playtesters and admins
+func TestFutureRoundsHidden(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+	playDate := time.Now().UTC().AddDate(0, 0, 2).Format(DateFormatYYYYMMDD)
+
+	if err := server.db.CreateRound(ctx, &Round{Sport: "basketball", PlayDate: playDate, Player: Player{Name: "LeBron James"}}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+
+	asPlaytester := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set(ConstantRoles, []string{RolePlaytester})
+			handler(c)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		handler        gin.HandlerFunc
+		method         string
+		path           string
+		body           interface{}
+		expectedStatus int
+	}{
+		{name: "get round", handler: server.GetRound, method: http.MethodGet, path: "/v1/round?sport=basketball&playDate=" + playDate, expectedStatus: http.StatusNotFound},
+		{name: "round stats", handler: server.GetRoundStats, method: http.MethodGet, path: "/v1/stats/round?sport=basketball&playDate=" + playDate, expectedStatus: http.StatusNotFound},
+		{name: "start session", handler: server.StartGameSession, method: http.MethodPost, path: "/v1/round/session?sport=basketball&playDate=" + playDate, expectedStatus: http.StatusNotFound},
+		{name: "guess", handler: server.GuessRound, method: http.MethodPost, path: "/v1/round/guess?sport=basketball&playDate=" + playDate, body: GuessRequest{Reveal: true}, expectedStatus: http.StatusNotFound},
+		{name: "submit results", handler: server.SubmitResults, method: http.MethodPost, path: "/v1/results?sport=basketball&playDate=" + playDate + "&sessionId=abc", expectedStatus: http.StatusNotFound},
+		{name: "get round as a playtester", handler: asPlaytester(server.GetRound), method: http.MethodGet, path: "/v1/round?sport=basketball&playDate=" + playDate, expectedStatus: http.StatusOK},
+		{name: "start session as a playtester", handler: asPlaytester(server.StartGameSession), method: http.MethodPost, path: "/v1/round/session?sport=basketball&playDate=" + playDate, expectedStatus: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(tt.handler, tt.method, tt.path, tt.body, "auth0|player")
+			if w.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.expectedStatus, w.Body.String())
+			}
+		})
+	}
+
+	// Listing rounds stops at today unless the caller is a playtester
+	w := performRequest(server.GetRounds, http.MethodGet, "/v1/rounds?sport=basketball&endDate=2999-01-01", nil, "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GetRounds: status %d, want %d since the only round is unreleased", w.Code, http.StatusNotFound)
+	}
+	var rounds []*RoundSummary
+	w = performRequest(asPlaytester(server.GetRounds), http.MethodGet, "/v1/rounds?sport=basketball&endDate=2999-01-01", nil, "")
+	json.NewDecoder(w.Body).Decode(&rounds)
+	if len(rounds) != 1 {
+		t.Errorf("GetRounds as a playtester: rounds %+v, want the unreleased round", rounds)
+	}
+}
@@ -22,6 +22,40 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// getContextUserId returns the userId set by the JWT middleware, or an empty string for guests
+func getContextUserId(c *gin.Context) string {
+	userIdToken, exists := c.Get(ConstantUserId)
+	if !exists {
+		return ""
+	}
+
+	userId, ok := userIdToken.(string)
+	if !ok {
+		return ""
+	}
+	return userId
+}
+
+// hasAnyRole checks if the roles set by the JWT middleware include any of the given roles
+func hasAnyRole(c *gin.Context, roles ...string) bool {
+	rolesToken, exists := c.Get(ConstantRoles)
+	if !exists {
+		return false
+	}
+
+	userRoles, ok := rolesToken.([]string)
+	if !ok {
+		return false
+	}
+
+	for _, role := range roles {
+		if contains(userRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateSportsReferenceURL validates that a URL is safe to scrape
 // Returns an error if the URL is invalid or not whitelisted
 func ValidateSportsReferenceURL(urlStr string) error {
@@ -489,7 +523,7 @@ func abbreviatePositions(playerInfo string) string {
 // Returns the timezone location or UTC as fallback if header is missing/invalid
 func getUserTimezone(c *gin.Context) *time.Location {
 	// Get timezone from X-User-Timezone header
-	tzHeader := c.GetHeader("X-User-Timezone")
+	tzHeader := c.GetHeader(HeaderUserTimezone)
 
 	if tzHeader == "" {
 		// No header provided, use UTC as fallback
@@ -507,43 +541,201 @@ func getUserTimezone(c *gin.Context) *time.Location {
 	return loc
 }
 
+// earliestTimezone is the first timezone each day begins in (see EarliestTimezoneOffset)
+var earliestTimezone = time.FixedZone("UTC+14", int(EarliestTimezoneOffset.Seconds()))
+
+// latestReleasedDate returns the latest playDate released to the caller: today in their timezone (see getUserTimezone)
+// It's capped at today in the earliest timezone, so no timezone header can reach a round before anyone could play it
+func latestReleasedDate(c *gin.Context, now time.Time) string {
+	today := now.In(getUserTimezone(c)).Format(DateFormatYYYYMMDD)
+	if earliest := now.In(earliestTimezone).Format(DateFormatYYYYMMDD); today > earliest {
+		return earliest
+	}
+	return today
+}
+
+// isRoundReleased reports whether the caller may access the round for playDate
+// Playtesters and admins can access future rounds; everyone else waits until the playDate in their timezone
+func isRoundReleased(c *gin.Context, playDate string) bool {
+	return hasAnyRole(c, RolePlaytester, RoleAdmin) || playDate <= latestReleasedDate(c, time.Now())
+}
+
+// isPreReleaseRound reports whether the round for playDate hasn't been released in any timezone yet
+// Only playtesters and admins can play such a round (see isRoundReleased)
+func isPreReleaseRound(playDate string, now time.Time) bool {
+	return playDate > now.In(earliestTimezone).Format(DateFormatYYYYMMDD)
+}
+
+// daysBetween returns the number of calendar days from one YYYY-MM-DD date to another
+// Returns false if either date is empty or malformed
+func daysBetween(from, to string) (int, bool) {
+	fromDate, err := time.Parse(DateFormatYYYYMMDD, from)
+	if err != nil {
+		return 0, false
+	}
+	toDate, err := time.Parse(DateFormatYYYYMMDD, to)
+	if err != nil {
+		return 0, false
+	}
+	return int(toDate.Sub(fromDate).Hours() / 24), true
+}
+
+// advanceDailyStreak counts a play on currentDate towards a daily streak based on real-life calendar days
+// - Increments the streak if currentDate is exactly 1 day after the last day played
+// - Resets the streak to 1 if more than 1 day has passed since the last day played
+// - Keeps the streak unchanged if they already played on currentDate (prevents multiple increments on same day)
+// - Ignores a currentDate before the last day played, which happens when the user moves to an earlier timezone
+// A play always leaves a streak of at least 1, and longest is raised to match the current streak
+func advanceDailyStreak(current, longest *int, lastDayPlayed *string, currentDate string) {
+	if *lastDayPlayed != "" && currentDate < *lastDayPlayed {
+		return
+	}
+
+	if daysDiff, ok := daysBetween(*lastDayPlayed, currentDate); ok {
+		if daysDiff == 1 {
+			// Consecutive day - increment streak
+			*current++
+		} else if daysDiff > 1 {
+			// Missed a day - reset streak to 1
+			*current = 1
+		}
+	}
+
+	if *current < 1 {
+		*current = 1
+	}
+	if *current > *longest {
+		*longest = *current
+	}
+	*lastDayPlayed = currentDate
+}
+
+// advanceWinStreak extends a win streak with a correct round, or ends it with an incorrect one
+// longest is raised to match the current streak
+func advanceWinStreak(current, longest *int, isCorrect bool) {
+	if !isCorrect {
+		*current = 0
+		return
+	}
+
+	*current++
+	if *current > *longest {
+		*longest = *current
+	}
+}
+
 // updateDailyStreak updates the user's daily streak based on real-life calendar days (engagement-based)
 // This function tracks consecutive days the user plays ANY round, regardless of which round's playDate they choose
-// For existing users, it:
-// - Increments the streak if currentDate is exactly 1 day after the last real-life day they played
-// - Resets the streak to 1 if more than 1 day has passed since the last real-life day they played
-// - Keeps the streak unchanged if they already played on currentDate (prevents multiple increments on same day)
-// Always updates LastDayPlayed to currentDate
-// The currentDate parameter should be the real-life date (typically today's date from time.Now())
+// See advanceDailyStreak for the rules. Always updates LastDayPlayed to currentDate unless it's earlier
+// If exactly one day was missed and the user has a streak freeze, the freeze is spent (and logged) to keep the streak
+// The log keeps the last MaxStreakFreezeLogEntries freezes
+// A freeze is earned each time the streak reaches a multiple of StreakFreezeMilestoneDays, up to MaxStreakFreezes
+// The currentDate parameter should be the real-life date in the user's timezone (see getUserTimezone)
 func updateDailyStreak(userStats *UserStats, currentDate string) {
 	if userStats == nil {
 		return
 	}
 
-	// Check if we have a previous play date to compare against
-	if userStats.LastDayPlayed != "" {
-		// If they already played on this date, don't update the streak (prevents multiple increments)
-		if userStats.LastDayPlayed == currentDate {
-			return
+	previousStreak := userStats.CurrentDailyStreak
+	if daysDiff, ok := daysBetween(userStats.LastDayPlayed, currentDate); ok && daysDiff == 2 && userStats.StreakFreezes > 0 && previousStreak > 0 {
+		lastPlayed, _ := time.Parse(DateFormatYYYYMMDD, userStats.LastDayPlayed)
+		missedDate := lastPlayed.AddDate(0, 0, 1).Format(DateFormatYYYYMMDD)
+
+		userStats.StreakFreezes--
+		userStats.StreakFreezeLog = append(userStats.StreakFreezeLog, StreakFreeze{
+			MissedDate: missedDate,
+			UsedOn:     currentDate,
+			Streak:     previousStreak,
+		})
+		if excess := len(userStats.StreakFreezeLog) - MaxStreakFreezeLogEntries; excess > 0 {
+			userStats.StreakFreezeLog = append([]StreakFreeze(nil), userStats.StreakFreezeLog[excess:]...)
 		}
+		// The freeze covers the missed day without counting it, so today continues the streak
+		userStats.LastDayPlayed = missedDate
+	}
 
-		lastPlayed, err := time.Parse(DateFormatYYYYMMDD, userStats.LastDayPlayed)
-		currentParsed, err2 := time.Parse(DateFormatYYYYMMDD, currentDate)
+	advanceDailyStreak(&userStats.CurrentDailyStreak, &userStats.MaxDailyStreak, &userStats.LastDayPlayed, currentDate)
 
-		if err == nil && err2 == nil {
-			daysDiff := int(currentParsed.Sub(lastPlayed).Hours() / 24)
+	streak := userStats.CurrentDailyStreak
+	if streak > previousStreak && streak%StreakFreezeMilestoneDays == 0 && userStats.StreakFreezes < MaxStreakFreezes {
+		userStats.StreakFreezes++
+	}
+}
 
-			if daysDiff == 1 {
-				// Consecutive day - increment streak
-				userStats.CurrentDailyStreak++
-			} else if daysDiff > 1 {
-				// Missed a day - reset streak to 1
-				userStats.CurrentDailyStreak = 1
-			}
-			// If daysDiff == 0, same day - don't change streak (shouldn't happen due to check above)
+// archiveStreakEffects reports whether a result counts toward daily streaks and win streaks
+// Results for today's round always count. Archive plays follow the configured rule (see ArchiveStreakRuleNone)
+func archiveStreakEffects(rule string, archive bool) (daily, win bool) {
+	if !archive {
+		return true, true
+	}
+	switch rule {
+	case ArchiveStreakRuleAll:
+		return true, true
+	case ArchiveStreakRuleWin:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// recordUserResult applies a submitted result to a user's aggregate stats: streaks, username and sport stats
+// The result itself is kept in the play history table (see DB.PutPlayHistoryEntry)
+// Archive plays are only counted in the sport's ArchivePlays, and archiveStreakRule decides which streaks they affect
+// Creates new user stats if userStats is nil. Returns the updated stats
+func recordUserResult(userStats *UserStats, userId, username, sport, today, archiveStreakRule string, result *Result) *UserStats {
+	// If user stats don't exist, create new user stats
+	if userStats == nil {
+		userStats = &UserStats{
+			UserId:   userId,
+			Sports:   []UserSportStats{},
+			UserName: username,
 		}
 	}
 
-	// Update last day played to the current date
-	userStats.LastDayPlayed = currentDate
+	// Update streaks based on real-life date in user's timezone (engagement-based tracking), not round playDate
+	countsDaily, countsWin := archiveStreakEffects(archiveStreakRule, result.Archive)
+	if countsDaily {
+		updateDailyStreak(userStats, today)
+	}
+	if countsWin {
+		advanceWinStreak(&userStats.CurrentWinStreak, &userStats.MaxWinStreak, result.IsCorrect)
+	}
+
+	// update username
+	if username != "" {
+		userStats.UserName = username
+	}
+
+	// Find or create specific sport stats
+	var sportStats *UserSportStats
+	for i := range userStats.Sports {
+		if userStats.Sports[i].Sport == sport {
+			sportStats = &userStats.Sports[i]
+			break
+		}
+	}
+
+	// If sport stats don't exist, create new entry
+	if sportStats == nil {
+		newSportStats := UserSportStats{
+			Sport: sport,
+		}
+		userStats.Sports = append(userStats.Sports, newSportStats)
+		sportStats = &userStats.Sports[len(userStats.Sports)-1]
+	}
+
+	// Update sport-specific stats and streaks
+	if result.Archive {
+		sportStats.ArchivePlays++
+	} else {
+		updateStatsWithResult(&sportStats.Stats, result)
+	}
+	if countsDaily {
+		advanceDailyStreak(&sportStats.CurrentDailyStreak, &sportStats.MaxDailyStreak, &sportStats.LastDayPlayed, today)
+	}
+	if countsWin {
+		advanceWinStreak(&sportStats.CurrentWinStreak, &sportStats.MaxWinStreak, result.IsCorrect)
+	}
+
+	return userStats
 }
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 func TestContains(t *testing.T) {
@@ -54,6 +59,100 @@ func TestContains(t *testing.T) {
 	}
 }
 
+func TestHasAnyRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		roles    interface{}
+		setRoles bool
+		check    []string
+		expected bool
+	}{
+		{name: "no roles in context", setRoles: false, check: []string{RoleAdmin}, expected: false},
+		{name: "matching role", roles: []string{RolePlayer, RoleAdmin}, setRoles: true, check: []string{RoleAdmin}, expected: true},
+		{name: "one of several roles matches", roles: []string{RolePlaytester}, setRoles: true, check: []string{RoleAdmin, RolePlaytester}, expected: true},
+		{name: "no matching role", roles: []string{RolePlayer}, setRoles: true, check: []string{RoleAdmin, RolePlaytester}, expected: false},
+		{name: "invalid roles format", roles: "Admin", setRoles: true, check: []string{RoleAdmin}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.setRoles {
+				c.Set(ConstantRoles, tt.roles)
+			}
+
+			got := hasAnyRole(c, tt.check...)
+			if got != tt.expected {
+				t.Errorf("hasAnyRole(%v) = %v, want %v", tt.check, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLatestReleasedDate(t *testing.T) {
+	now := time.Date(2025, 11, 15, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timezone string
+		expected string
+	}{
+		{name: "no header uses UTC", expected: "2025-11-15"},
+		{name: "behind UTC", timezone: "America/Los_Angeles", expected: "2025-11-15"},
+		{name: "already tomorrow", timezone: "Asia/Tokyo", expected: "2025-11-16"},
+		{name: "earliest timezone", timezone: "Pacific/Kiritimati", expected: "2025-11-16"},
+		{name: "invalid header uses UTC", timezone: "Mars/Olympus_Mons", expected: "2025-11-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.timezone != "" {
+				c.Request.Header.Set(HeaderUserTimezone, tt.timezone)
+			}
+
+			if got := latestReleasedDate(c, now); got != tt.expected {
+				t.Errorf("latestReleasedDate() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsRoundReleased(t *testing.T) {
+	today := time.Now().UTC()
+	tomorrow := today.AddDate(0, 0, 2).Format(DateFormatYYYYMMDD) // a day past the earliest timezone's today
+
+	tests := []struct {
+		name     string
+		playDate string
+		roles    []string
+		expected bool
+	}{
+		{name: "past round", playDate: "2025-11-15", expected: true},
+		{name: "today's round", playDate: today.Format(DateFormatYYYYMMDD), expected: true},
+		{name: "future round", playDate: tomorrow, expected: false},
+		{name: "future round for a player", playDate: tomorrow, roles: []string{RolePlayer}, expected: false},
+		{name: "future round for a playtester", playDate: tomorrow, roles: []string{RolePlaytester}, expected: true},
+		{name: "future round for an admin", playDate: tomorrow, roles: []string{RoleAdmin}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.roles != nil {
+				c.Set(ConstantRoles, tt.roles)
+			}
+
+			if got := isRoundReleased(c, tt.playDate); got != tt.expected {
+				t.Errorf("isRoundReleased(%s) = %v, want %v", tt.playDate, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestIsValidYear(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -501,6 +600,288 @@ func TestUpdateDailyStreak(t *testing.T) {
 	}
 }
 
+func TestUpdateDailyStreakFreezes(t *testing.T) {
+	tests := []struct {
+		name            string
+		existing        UserStats
+		currentDate     string
+		expectedStreak  int
+		expectedFreezes int
+		expectedLog     []StreakFreeze
+	}{
+		{
+			name:            "one missed day spends a freeze",
+			existing:        UserStats{CurrentDailyStreak: 9, LastDayPlayed: "2025-11-13", StreakFreezes: 2},
+			currentDate:     "2025-11-15",
+			expectedStreak:  10,
+			expectedFreezes: 1,
+			expectedLog:     []StreakFreeze{{MissedDate: "2025-11-14", UsedOn: "2025-11-15", Streak: 9}},
+		},
+		{
+			name:            "one missed day without freezes resets",
+			existing:        UserStats{CurrentDailyStreak: 9, LastDayPlayed: "2025-11-13"},
+			currentDate:     "2025-11-15",
+			expectedStreak:  1,
+			expectedFreezes: 0,
+		},
+		{
+			name:            "two missed days reset and keep the freezes",
+			existing:        UserStats{CurrentDailyStreak: 9, LastDayPlayed: "2025-11-12", StreakFreezes: 2},
+			currentDate:     "2025-11-15",
+			expectedStreak:  1,
+			expectedFreezes: 2,
+		},
+		{
+			name:            "reaching a milestone earns a freeze",
+			existing:        UserStats{CurrentDailyStreak: 6, LastDayPlayed: "2025-11-14"},
+			currentDate:     "2025-11-15",
+			expectedStreak:  7,
+			expectedFreezes: 1,
+		},
+		{
+			name:            "a milestone reached with a freeze earns it back",
+			existing:        UserStats{CurrentDailyStreak: 13, LastDayPlayed: "2025-11-13", StreakFreezes: 1},
+			currentDate:     "2025-11-15",
+			expectedStreak:  14,
+			expectedFreezes: 1,
+			expectedLog:     []StreakFreeze{{MissedDate: "2025-11-14", UsedOn: "2025-11-15", Streak: 13}},
+		},
+		{
+			name:            "freezes are capped",
+			existing:        UserStats{CurrentDailyStreak: 20, LastDayPlayed: "2025-11-14", StreakFreezes: MaxStreakFreezes},
+			currentDate:     "2025-11-15",
+			expectedStreak:  21,
+			expectedFreezes: MaxStreakFreezes,
+		},
+		{
+			name:            "playing again on a milestone day doesn't earn another freeze",
+			existing:        UserStats{CurrentDailyStreak: 7, LastDayPlayed: "2025-11-15", StreakFreezes: 1},
+			currentDate:     "2025-11-15",
+			expectedStreak:  7,
+			expectedFreezes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStats := tt.existing
+			updateDailyStreak(&userStats, tt.currentDate)
+
+			if userStats.CurrentDailyStreak != tt.expectedStreak || userStats.StreakFreezes != tt.expectedFreezes {
+				t.Errorf("got streak %d with %d freezes, want %d with %d",
+					userStats.CurrentDailyStreak, userStats.StreakFreezes, tt.expectedStreak, tt.expectedFreezes)
+			}
+			if userStats.LastDayPlayed != tt.currentDate {
+				t.Errorf("LastDayPlayed = %q, want %q", userStats.LastDayPlayed, tt.currentDate)
+			}
+			if len(userStats.StreakFreezeLog) != len(tt.expectedLog) {
+				t.Fatalf("StreakFreezeLog = %+v, want %+v", userStats.StreakFreezeLog, tt.expectedLog)
+			}
+			for i := range tt.expectedLog {
+				if userStats.StreakFreezeLog[i] != tt.expectedLog[i] {
+					t.Errorf("StreakFreezeLog[%d] = %+v, want %+v", i, userStats.StreakFreezeLog[i], tt.expectedLog[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateDailyStreakFreezeLogCapped(t *testing.T) {
+	userStats := UserStats{CurrentDailyStreak: 9, LastDayPlayed: "2025-11-13", StreakFreezes: 1}
+	for i := 0; i < MaxStreakFreezeLogEntries; i++ {
+		userStats.StreakFreezeLog = append(userStats.StreakFreezeLog, StreakFreeze{MissedDate: fmt.Sprintf("2024-01-%02d", i+1)})
+	}
+
+	updateDailyStreak(&userStats, "2025-11-15")
+
+	if len(userStats.StreakFreezeLog) != MaxStreakFreezeLogEntries {
+		t.Fatalf("StreakFreezeLog has %d entries, want %d", len(userStats.StreakFreezeLog), MaxStreakFreezeLogEntries)
+	}
+	if first := userStats.StreakFreezeLog[0].MissedDate; first != "2024-01-02" {
+		t.Errorf("oldest logged freeze = %s, want the oldest entry dropped", first)
+	}
+	if last := userStats.StreakFreezeLog[MaxStreakFreezeLogEntries-1]; last.MissedDate != "2025-11-14" {
+		t.Errorf("newest logged freeze = %+v, want the freeze just spent", last)
+	}
+}
+
+func TestAdvanceDailyStreak(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         int
+		longest         int
+		lastDayPlayed   string
+		currentDate     string
+		expectedCurrent int
+		expectedLongest int
+		expectedLastDay string
+	}{
+		{name: "first play starts a streak", currentDate: "2025-11-15", expectedCurrent: 1, expectedLongest: 1, expectedLastDay: "2025-11-15"},
+		{name: "consecutive day raises the longest streak", current: 4, longest: 4, lastDayPlayed: "2025-11-14", currentDate: "2025-11-15", expectedCurrent: 5, expectedLongest: 5, expectedLastDay: "2025-11-15"},
+		{name: "reset keeps the longest streak", current: 4, longest: 9, lastDayPlayed: "2025-11-10", currentDate: "2025-11-15", expectedCurrent: 1, expectedLongest: 9, expectedLastDay: "2025-11-15"},
+		{name: "longest catches up with streaks from before it was tracked", current: 6, lastDayPlayed: "2025-11-15", currentDate: "2025-11-15", expectedCurrent: 6, expectedLongest: 6, expectedLastDay: "2025-11-15"},
+		{name: "earlier date after a timezone change is ignored", current: 3, longest: 3, lastDayPlayed: "2025-11-15", currentDate: "2025-11-14", expectedCurrent: 3, expectedLongest: 3, expectedLastDay: "2025-11-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest, lastDayPlayed := tt.current, tt.longest, tt.lastDayPlayed
+			advanceDailyStreak(&current, &longest, &lastDayPlayed, tt.currentDate)
+
+			if current != tt.expectedCurrent || longest != tt.expectedLongest || lastDayPlayed != tt.expectedLastDay {
+				t.Errorf("got current %d, longest %d, lastDayPlayed %q, want %d, %d, %q",
+					current, longest, lastDayPlayed, tt.expectedCurrent, tt.expectedLongest, tt.expectedLastDay)
+			}
+		})
+	}
+}
+
+func TestAdvanceWinStreak(t *testing.T) {
+	current, longest := 0, 0
+	for _, isCorrect := range []bool{true, true, true, false, true} {
+		advanceWinStreak(&current, &longest, isCorrect)
+	}
+
+	if current != 1 || longest != 3 {
+		t.Errorf("got current %d, longest %d, want 1, 3", current, longest)
+	}
+}
+
+func TestRecordUserResult(t *testing.T) {
+	result := &Result{Score: 80, IsCorrect: true, FlippedTiles: []string{TileBio}}
+
+	t.Run("creates new user stats", func(t *testing.T) {
+		got := recordUserResult(nil, "user-1", "jane", "basketball", "2025-11-15", ArchiveStreakRuleNone, result)
+
+		if got.UserId != "user-1" || got.UserName != "jane" {
+			t.Errorf("got userId %q, userName %q, want user-1, jane", got.UserId, got.UserName)
+		}
+		if got.CurrentDailyStreak != 1 || got.LastDayPlayed != "2025-11-15" {
+			t.Errorf("got streak %d, lastDayPlayed %q, want 1, 2025-11-15", got.CurrentDailyStreak, got.LastDayPlayed)
+		}
+		if len(got.Sports) != 1 || got.Sports[0].Stats.TotalPlays != 1 {
+			t.Fatalf("expected one basketball entry with one play, got %+v", got.Sports)
+		}
+		if len(got.Sports[0].History) != 0 {
+			t.Errorf("expected no embedded history, got %d entries", len(got.Sports[0].History))
+		}
+		if got.Version != 0 {
+			t.Errorf("Version = %d, want 0 (set by the database on create)", got.Version)
+		}
+	})
+
+	t.Run("updates existing user stats and keeps version", func(t *testing.T) {
+		existing := &UserStats{
+			UserId:             "user-1",
+			UserName:           "jane",
+			Version:            4,
+			CurrentDailyStreak: 2,
+			LastDayPlayed:      "2025-11-14",
+			Sports: []UserSportStats{
+				{
+					Sport: "basketball",
+					Stats: Stats{TotalPlays: 1},
+				},
+			},
+		}
+
+		got := recordUserResult(existing, "user-1", "", "basketball", "2025-11-15", ArchiveStreakRuleNone, result)
+
+		if got.Version != 4 {
+			t.Errorf("Version = %d, want 4", got.Version)
+		}
+		if got.UserName != "jane" {
+			t.Errorf("UserName = %q, want jane", got.UserName)
+		}
+		if got.CurrentDailyStreak != 3 {
+			t.Errorf("CurrentDailyStreak = %d, want 3", got.CurrentDailyStreak)
+		}
+		if got.Sports[0].Stats.TotalPlays != 2 {
+			t.Errorf("TotalPlays = %d, want 2", got.Sports[0].Stats.TotalPlays)
+		}
+	})
+
+	t.Run("tracks streaks per sport", func(t *testing.T) {
+		var got *UserStats
+		for _, play := range []struct {
+			sport     string
+			today     string
+			isCorrect bool
+		}{
+			{"baseball", "2025-11-13", true},
+			{"football", "2025-11-13", true},
+			{"baseball", "2025-11-14", true},
+			{"football", "2025-11-15", false},
+			{"baseball", "2025-11-15", true},
+		} {
+			got = recordUserResult(got, "user-1", "", play.sport, play.today, ArchiveStreakRuleNone, &Result{IsCorrect: play.isCorrect})
+		}
+
+		if got.CurrentDailyStreak != 3 || got.MaxDailyStreak != 3 {
+			t.Errorf("daily streak = %d (max %d), want 3 (max 3)", got.CurrentDailyStreak, got.MaxDailyStreak)
+		}
+		if got.CurrentWinStreak != 1 || got.MaxWinStreak != 3 {
+			t.Errorf("win streak = %d (max %d), want 1 (max 3)", got.CurrentWinStreak, got.MaxWinStreak)
+		}
+
+		baseball, football := got.Sports[0], got.Sports[1]
+		if baseball.CurrentDailyStreak != 3 || baseball.MaxDailyStreak != 3 || baseball.CurrentWinStreak != 3 || baseball.MaxWinStreak != 3 {
+			t.Errorf("baseball streaks = %+v, want 3 days and 3 wins", baseball)
+		}
+		if football.CurrentDailyStreak != 1 || football.MaxDailyStreak != 1 || football.CurrentWinStreak != 0 || football.MaxWinStreak != 1 {
+			t.Errorf("football streaks = %+v, want a 1 day streak and a broken win streak", football)
+		}
+	})
+
+	t.Run("adds a new sport", func(t *testing.T) {
+		existing := &UserStats{UserId: "user-1", Sports: []UserSportStats{{Sport: "baseball"}}}
+
+		got := recordUserResult(existing, "user-1", "", "football", "2025-11-15", ArchiveStreakRuleNone, result)
+
+		if len(got.Sports) != 2 || got.Sports[1].Sport != "football" {
+			t.Errorf("expected football to be added, got %+v", got.Sports)
+		}
+	})
+
+	archiveTests := []struct {
+		rule                string
+		expectedDailyStreak int
+		expectedWinStreak   int
+	}{
+		{rule: ArchiveStreakRuleNone, expectedDailyStreak: 2, expectedWinStreak: 2},
+		{rule: ArchiveStreakRuleWin, expectedDailyStreak: 2, expectedWinStreak: 0},
+		{rule: ArchiveStreakRuleAll, expectedDailyStreak: 3, expectedWinStreak: 0},
+	}
+	for _, tt := range archiveTests {
+		t.Run("archive play with rule "+tt.rule, func(t *testing.T) {
+			existing := &UserStats{
+				UserId:             "user-1",
+				CurrentDailyStreak: 2,
+				LastDayPlayed:      "2025-11-14",
+				CurrentWinStreak:   2,
+				Sports: []UserSportStats{
+					{Sport: "basketball", Stats: Stats{TotalPlays: 2}, CurrentDailyStreak: 2, LastDayPlayed: "2025-11-14", CurrentWinStreak: 2},
+				},
+			}
+
+			got := recordUserResult(existing, "user-1", "", "basketball", "2025-11-15", tt.rule, &Result{IsCorrect: false, Archive: true})
+
+			if got.CurrentDailyStreak != tt.expectedDailyStreak || got.CurrentWinStreak != tt.expectedWinStreak {
+				t.Errorf("streaks = %d days, %d wins, want %d days, %d wins",
+					got.CurrentDailyStreak, got.CurrentWinStreak, tt.expectedDailyStreak, tt.expectedWinStreak)
+			}
+			sport := got.Sports[0]
+			if sport.CurrentDailyStreak != tt.expectedDailyStreak || sport.CurrentWinStreak != tt.expectedWinStreak {
+				t.Errorf("basketball streaks = %d days, %d wins, want %d days, %d wins",
+					sport.CurrentDailyStreak, sport.CurrentWinStreak, tt.expectedDailyStreak, tt.expectedWinStreak)
+			}
+			if sport.Stats.TotalPlays != 2 || sport.ArchivePlays != 1 {
+				t.Errorf("TotalPlays = %d, ArchivePlays = %d, want 2, 1", sport.Stats.TotalPlays, sport.ArchivePlays)
+			}
+		})
+	}
+}
+
 func TestValidateSportsReferenceURL(t *testing.T) {
 	tests := []struct {
 		name      string
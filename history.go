@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// splitUserHistory removes the history embedded in a user's sport stats and returns it as play history entries
+// Items saved before play history moved to its own table still carry it
+func splitUserHistory(userStats *UserStats) []*PlayHistoryEntry {
+	if userStats == nil {
+		return nil
+	}
+
+	var entries []*PlayHistoryEntry
+	for i := range userStats.Sports {
+		for _, history := range userStats.Sports[i].History {
+			entries = append(entries, &PlayHistoryEntry{
+				UserId:   userStats.UserId,
+				Sport:    userStats.Sports[i].Sport,
+				PlayDate: history.PlayDate,
+				Result:   history.Result,
+			})
+		}
+		userStats.Sports[i].History = nil
+	}
+
+	return entries
+}
+
+// migrateUserHistory moves history embedded in a user's stats to the play history table
+// The embedded history is cleared in memory only; callers save the user stats afterwards
+// Returns true if there was anything to migrate
+func (s *Server) migrateUserHistory(ctx context.Context, userStats *UserStats) (bool, error) {
+	entries := splitUserHistory(userStats)
+	for _, entry := range entries {
+		if err := s.db.PutPlayHistoryEntry(ctx, entry); err != nil {
+			return false, err
+		}
+	}
+	return len(entries) > 0, nil
+}
+
+// hasPlayedRound reports whether the user has a result for the round
+// Falls back to the history embedded in user stats that haven't been migrated yet
+func (s *Server) hasPlayedRound(ctx context.Context, userId, sport, playDate string) (bool, error) {
+	entry, err := s.db.GetPlayHistoryEntry(ctx, userId, sport, playDate)
+	if err != nil {
+		return false, err
+	}
+	if entry != nil {
+		return true, nil
+	}
+
+	userStats, err := s.db.GetUserStats(ctx, userId)
+	if err != nil || userStats == nil {
+		return false, err
+	}
+
+	for _, sportStats := range userStats.Sports {
+		if sportStats.Sport != sport {
+			continue
+		}
+		for _, history := range sportStats.History {
+			if history.PlayDate == playDate {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// parsePageLimit parses the limit query parameter, falling back to the default page size when it's empty
+// Returns false if the limit isn't a number between 1 and maxLimit
+func parsePageLimit(limitStr string, defaultLimit, maxLimit int) (int, bool) {
+	if limitStr == "" {
+		return defaultLimit, true
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 || limit > maxLimit {
+		return 0, false
+	}
+	return limit, true
+}
+
+// GetPlayHistory handles GET /v1/stats/user/history - returns a page of the rounds a user has played
+func (s *Server) GetPlayHistory(c *gin.Context) {
+	userId := c.Query(QueryParamUserId)
+	if userId == "" {
+		// if userId is not part in query param, extract from bearer token instead
+		userId = getContextUserId(c)
+	}
+	if userId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "userId parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	sport := c.Query(QueryParamSport)
+	if sport != "" && !IsValidSport(sport) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid sport '" + sport + "'",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	limit, ok := parsePageLimit(c.Query(QueryParamLimit), DefaultPlayHistoryPageSize, MaxPlayHistoryPageSize)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "limit must be a number between 1 and " + strconv.Itoa(MaxPlayHistoryPageSize),
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	cursor := c.Query(QueryParamCursor)
+	if cursor != "" {
+		if _, err := decodePlayHistoryCursor(cursor); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				JSONFieldError:     StatusBadRequest,
+				JSONFieldMessage:   "Invalid cursor",
+				JSONFieldCode:      ErrorInvalidParameter,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	entries, nextCursor, err := s.db.GetPlayHistory(c.Request.Context(), userId, sport, int32(limit), cursor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to retrieve play history: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, PlayHistoryPage{
+		Items:      entries,
+		NextCursor: nextCursor,
+	})
+}
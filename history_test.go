@@ -0,0 +1,146 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSplitUserHistory(t *testing.T) {
+	userStats := &UserStats{
+		UserId: "user-1",
+		Sports: []UserSportStats{
+			{
+				Sport: "basketball",
+				History: []RoundHistory{
+					{PlayDate: "2025-11-14", Result: Result{Score: 80, IsCorrect: true}},
+					{PlayDate: "2025-11-15", Result: Result{Score: 0}},
+				},
+			},
+			{Sport: "baseball"},
+			{
+				Sport:   "football",
+				History: []RoundHistory{{PlayDate: "2025-11-15", Result: Result{Score: 60, IsCorrect: true}}},
+			},
+		},
+	}
+
+	entries := splitUserHistory(userStats)
+
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].UserId != "user-1" || entries[0].Sport != "basketball" || entries[0].PlayDate != "2025-11-14" || entries[0].Score != 80 {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[2].Sport != "football" || entries[2].Score != 60 {
+		t.Errorf("unexpected last entry %+v", entries[2])
+	}
+	for _, sportStats := range userStats.Sports {
+		if sportStats.History != nil {
+			t.Errorf("expected %s history to be cleared, got %v", sportStats.Sport, sportStats.History)
+		}
+	}
+
+	if entries := splitUserHistory(userStats); len(entries) != 0 {
+		t.Errorf("expected nothing left to split, got %d entries", len(entries))
+	}
+	if entries := splitUserHistory(nil); entries != nil {
+		t.Errorf("expected nil for nil user stats, got %v", entries)
+	}
+}
+
+func TestParsePageLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         string
+		expected      int
+		expectedValid bool
+	}{
+		{name: "empty uses default", limit: "", expected: 20, expectedValid: true},
+		{name: "valid limit", limit: "50", expected: 50, expectedValid: true},
+		{name: "maximum limit", limit: "100", expected: 100, expectedValid: true},
+		{name: "above maximum", limit: "101", expectedValid: false},
+		{name: "zero", limit: "0", expectedValid: false},
+		{name: "negative", limit: "-5", expectedValid: false},
+		{name: "not a number", limit: "ten", expectedValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, valid := parsePageLimit(tt.limit, DefaultPlayHistoryPageSize, MaxPlayHistoryPageSize)
+			if valid != tt.expectedValid {
+				t.Errorf("parsePageLimit(%q) valid = %v, want %v", tt.limit, valid, tt.expectedValid)
+			}
+			if valid && got != tt.expected {
+				t.Errorf("parsePageLimit(%q) = %d, want %d", tt.limit, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlayHistoryCursor(t *testing.T) {
+	cursor := encodePlayHistoryCursor("basketball#2025-11-15")
+
+	got, err := decodePlayHistoryCursor(cursor)
+	if err != nil {
+		t.Fatalf("decodePlayHistoryCursor() error = %v", err)
+	}
+	if got != "basketball#2025-11-15" {
+		t.Errorf("decodePlayHistoryCursor() = %q, want %q", got, "basketball#2025-11-15")
+	}
+
+	for _, invalid := range []string{"not base64!", encodePlayHistoryCursor("no-separator")} {
+		if _, err := decodePlayHistoryCursor(invalid); err == nil {
+			t.Errorf("decodePlayHistoryCursor(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestHandleGetPlayHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+	}{
+		{
+			name:           "missing userId",
+			queryParams:    "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid sport",
+			queryParams:    "?userId=user-1&sport=hockey",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid limit",
+			queryParams:    "?userId=user-1&limit=1000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid cursor",
+			queryParams:    "?userId=user-1&cursor=%21%21",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := getTestServer()
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/v1/stats/user/history"+tt.queryParams, nil)
+
+			server.GetPlayHistory(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
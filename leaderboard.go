@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// leaderboardBoard returns the board key for a sport and a playDate or LeaderboardBoardAllTime
+// Example: leaderboardBoard("basketball", "2025-11-15") = "basketball#2025-11-15"
+func leaderboardBoard(sport, period string) string {
+	return sport + "#" + period
+}
+
+// leaderboardCursor marks where the next page of a leaderboard starts
+// Position is the number of entries before the page, which page ranks are computed from
+type leaderboardCursor struct {
+	UserId   string `json:"u"`
+	Score    int    `json:"s"`
+	Position int    `json:"p"`
+}
+
+// encodeLeaderboardCursor turns the last entry of a page into an opaque cursor
+func encodeLeaderboardCursor(last *LeaderboardEntry, position int) string {
+	data, _ := json.Marshal(leaderboardCursor{UserId: last.UserId, Score: last.Score, Position: position})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeLeaderboardCursor turns a cursor back into the entry to resume after
+// Returns an error "invalid cursor" if the cursor wasn't produced by encodeLeaderboardCursor
+func decodeLeaderboardCursor(cursor string) (leaderboardCursor, error) {
+	var decoded leaderboardCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || json.Unmarshal(data, &decoded) != nil || decoded.UserId == "" || decoded.Position < 1 {
+		return leaderboardCursor{}, fmt.Errorf("invalid cursor")
+	}
+	return decoded, nil
+}
+
+// sortLeaderboardEntries orders entries by score (highest first), then by userId so ties page consistently
+func sortLeaderboardEntries(entries []*LeaderboardEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].UserId < entries[j].UserId
+	})
+}
+
+// leaderboardAfter reports whether entry sorts after the cursor in sortLeaderboardEntries order
+func leaderboardAfter(entry *LeaderboardEntry, cursor leaderboardCursor) bool {
+	if entry.Score != cursor.Score {
+		return entry.Score < cursor.Score
+	}
+	return entry.UserId > cursor.UserId
+}
+
+// rankLeaderboardPage fills in competition ranks ("1224") for a page of entries sorted by score
+// position is the number of entries before the page. countAbove counts entries with a higher score
+// and is only needed when the page doesn't start the leaderboard
+func rankLeaderboardPage(entries []*LeaderboardEntry, position int, countAbove func(score int) (int, error)) error {
+	for i, entry := range entries {
+		switch {
+		case i == 0 && position == 0:
+			entry.Rank = 1
+		case i == 0:
+			// The first entry may tie with the end of the previous page
+			above, err := countAbove(entry.Score)
+			if err != nil {
+				return err
+			}
+			entry.Rank = above + 1
+		case entry.Score == entries[i-1].Score:
+			entry.Rank = entries[i-1].Rank
+		default:
+			entry.Rank = position + i + 1
+		}
+	}
+	return nil
+}
+
+// pageLeaderboard returns one page of entries that are already sorted, and the cursor for the next page
+// Used by the stores that rank in memory
+func pageLeaderboard(entries []*LeaderboardEntry, limit int32, cursor string) ([]*LeaderboardEntry, string, error) {
+	start := 0
+	if cursor != "" {
+		after, err := decodeLeaderboardCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.Search(len(entries), func(i int) bool { return leaderboardAfter(entries[i], after) })
+	}
+
+	end := start + int(limit)
+	if end > len(entries) {
+		end = len(entries)
+	}
+	page := entries[start:end]
+
+	err := rankLeaderboardPage(page, start, func(score int) (int, error) {
+		return sort.Search(len(entries), func(i int) bool { return entries[i].Score <= score }), nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if end < len(entries) {
+		nextCursor = encodeLeaderboardCursor(page[len(page)-1], end)
+	}
+	return page, nextCursor, nil
+}
+
+// applyLeaderboardResult adds a counted result to a player's leaderboard entry
+// An empty userName keeps the name already on the entry
+func applyLeaderboardResult(entry *LeaderboardEntry, userName string, result *Result) {
+	if userName != "" {
+		entry.UserName = userName
+	}
+	entry.Score += result.Score
+	entry.RoundsPlayed++
+	if result.IsCorrect {
+		entry.CorrectCount++
+	}
+	if result.Score > entry.HighestScore {
+		entry.HighestScore = result.Score
+	}
+	entry.LastUpdated = time.Now()
+}
+
+// rankLeaderboardEntry sets the rank of a single entry given every entry on its board
+func rankLeaderboardEntry(entry *LeaderboardEntry, entries []*LeaderboardEntry) {
+	entry.Rank = 1
+	for _, other := range entries {
+		if other.Score > entry.Score {
+			entry.Rank++
+		}
+	}
+}
+
+// leaderboardWindowDays returns how many days a rolling window covers, or 0 if it isn't a rolling window
+func leaderboardWindowDays(window string) int {
+	switch window {
+	case LeaderboardWindowWeek:
+		return 7
+	case LeaderboardWindowMonth:
+		return 30
+	default:
+		return 0
+	}
+}
+
+// leaderboardWindowBoard returns the board key of the rolling window ending on endDate
+// Example: leaderboardWindowBoard("basketball", "7d", "2025-11-15") = "basketball#7d#2025-11-15"
+func leaderboardWindowBoard(sport, window, endDate string) string {
+	return leaderboardBoard(sport, window+"#"+endDate)
+}
+
+// leaderboardResultBoards returns every board a result for a round is counted on: the round's daily board,
+// the all-time board and the board of each rolling window that covers playDate, which are the windows ending
+// on playDate and on each of the following days of the window
+// Rolling windows are kept up to date this way so reading one is a single board query, and their boards
+// expire LeaderboardWindowRetentionDays after the window ends
+func leaderboardResultBoards(sport, playDate string) []LeaderboardBoardRef {
+	boards := []LeaderboardBoardRef{
+		{Board: leaderboardBoard(sport, playDate)},
+		{Board: leaderboardBoard(sport, LeaderboardBoardAllTime)},
+	}
+
+	date, err := time.Parse(DateFormatYYYYMMDD, playDate)
+	if err != nil {
+		return boards
+	}
+	for _, window := range []string{LeaderboardWindowWeek, LeaderboardWindowMonth} {
+		for day := 0; day < leaderboardWindowDays(window); day++ {
+			endDate := date.AddDate(0, 0, day)
+			boards = append(boards, LeaderboardBoardRef{
+				Board:     leaderboardWindowBoard(sport, window, endDate.Format(DateFormatYYYYMMDD)),
+				ExpiresAt: endDate.AddDate(0, 0, LeaderboardWindowRetentionDays).Unix(),
+			})
+		}
+	}
+	return boards
+}
+
+// recordLeaderboardResult adds a counted result to every board it's counted on (see leaderboardResultBoards)
+// The boards are written together, so a failure leaves none of them updated and the result can be recorded again
+func (s *Server) recordLeaderboardResult(ctx context.Context, userId, username, sport, playDate string, result *Result) error {
+	return s.db.AddLeaderboardResult(ctx, leaderboardResultBoards(sport, playDate), userId, username, result)
+}
+
+// leaderboardView is the board a leaderboard window reads and the dates it covers (empty for all time)
+type leaderboardView struct {
+	Board     string
+	Window    string
+	StartDate string
+	EndDate   string
+}
+
+// parseLeaderboardWindow reads the window and playDate query params into the board to read
+// playDate is the round, or the last day of a rolling window, and defaults to today in the user's timezone
+// Responds with 400 and returns false if either param is invalid
+func parseLeaderboardWindow(c *gin.Context, sport, defaultWindow string) (leaderboardView, bool) {
+	window := c.DefaultQuery(QueryParamWindow, defaultWindow)
+	if window != LeaderboardWindowRound && window != LeaderboardWindowAllTime && leaderboardWindowDays(window) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "window must be one of " + LeaderboardWindowRound + ", " + LeaderboardWindowWeek + ", " + LeaderboardWindowMonth + " or " + LeaderboardWindowAllTime,
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return leaderboardView{}, false
+	}
+
+	playDate := c.Query(QueryParamPlayDate)
+	if playDate == "" {
+		playDate = time.Now().In(getUserTimezone(c)).Format(DateFormatYYYYMMDD)
+	}
+	endDate, err := time.Parse(DateFormatYYYYMMDD, playDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid playDate format. Expected YYYY-MM-DD",
+			JSONFieldCode:      ErrorInvalidPlayDate,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return leaderboardView{}, false
+	}
+
+	// Every window is a single board, kept up to date as results are submitted
+	view := leaderboardView{Board: leaderboardBoard(sport, LeaderboardBoardAllTime), Window: window}
+	switch days := leaderboardWindowDays(window); {
+	case days > 0:
+		view.Board = leaderboardWindowBoard(sport, window, playDate)
+		view.StartDate = endDate.AddDate(0, 0, 1-days).Format(DateFormatYYYYMMDD)
+		view.EndDate = playDate
+	case window == LeaderboardWindowRound:
+		view.Board = leaderboardBoard(sport, playDate)
+		view.StartDate = playDate
+		view.EndDate = playDate
+	}
+	return view, true
+}
+
+// GetLeaderboard handles GET /v1/leaderboard - ranks players for a round, a rolling window or all time
+// Query params: sport (required), window (round, 7d, 30d or all; defaults to round),
+// playDate (the round, or the last day of a rolling window; defaults to today), limit and cursor
+func (s *Server) GetLeaderboard(c *gin.Context) {
+	sport := c.Query(QueryParamSport)
+	if sport == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "sport parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if !IsValidSport(sport) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid sport '" + sport + "'",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	view, ok := parseLeaderboardWindow(c, sport, LeaderboardWindowRound)
+	if !ok {
+		return
+	}
+
+	limit, ok := parsePageLimit(c.Query(QueryParamLimit), DefaultLeaderboardPageSize, MaxLeaderboardPageSize)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "limit must be a number between 1 and " + strconv.Itoa(MaxLeaderboardPageSize),
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	cursor := c.Query(QueryParamCursor)
+	if cursor != "" {
+		if _, err := decodeLeaderboardCursor(cursor); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				JSONFieldError:     StatusBadRequest,
+				JSONFieldMessage:   "Invalid cursor",
+				JSONFieldCode:      ErrorInvalidParameter,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	userId := getContextUserId(c)
+	leaderboard := Leaderboard{Sport: sport, Window: view.Window, StartDate: view.StartDate, EndDate: view.EndDate}
+
+	var err error
+	leaderboard.Items, leaderboard.NextCursor, err = s.db.GetLeaderboard(ctx, view.Board, int32(limit), cursor)
+	if err != nil {
+		respondLeaderboardError(c, err)
+		return
+	}
+	if userId != "" {
+		leaderboard.Me, err = s.db.GetLeaderboardEntry(ctx, view.Board, userId)
+		if err != nil {
+			respondLeaderboardError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+// respondLeaderboardError responds with the error from reading a leaderboard
+func respondLeaderboardError(c *gin.Context, err error) {
+	if err.Error() == "invalid cursor" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid cursor",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	log.Printf("Failed to retrieve leaderboard: %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{
+		JSONFieldError:     StatusInternalServerError,
+		JSONFieldMessage:   "Failed to retrieve leaderboard: " + err.Error(),
+		JSONFieldCode:      ErrorDatabaseError,
+		JSONFieldTimestamp: time.Now(),
+	})
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newLeaderboard builds sorted entries from scores, naming players user-1, user-2, ...
+func newLeaderboard(scores ...int) []*LeaderboardEntry {
+	entries := make([]*LeaderboardEntry, len(scores))
+	for i, score := range scores {
+		entries[i] = &LeaderboardEntry{UserId: fmt.Sprintf("user-%d", i+1), Score: score}
+	}
+	sortLeaderboardEntries(entries)
+	return entries
+}
+
+// ranks returns the ranks of entries in order
+func ranks(entries []*LeaderboardEntry) []int {
+	result := make([]int, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.Rank
+	}
+	return result
+}
+
+func TestPageLeaderboard(t *testing.T) {
+	entries := newLeaderboard(100, 90, 90, 90, 80, 70)
+
+	// Pages split in the middle of a tie keep the tied rank
+	var got []int
+	cursor := ""
+	pages := 0
+	for {
+		page, next, err := pageLeaderboard(entries, 2, cursor)
+		if err != nil {
+			t.Fatalf("pageLeaderboard() error = %v", err)
+		}
+		got = append(got, ranks(page)...)
+		pages++
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	expected := []int{1, 2, 2, 2, 5, 6}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("ranks = %v, want %v", got, expected)
+	}
+	if pages != 3 {
+		t.Errorf("got %d pages, want 3", pages)
+	}
+
+	if _, _, err := pageLeaderboard(entries, 2, "not-a-cursor"); err == nil || err.Error() != "invalid cursor" {
+		t.Errorf("pageLeaderboard() bad cursor error = %v, want invalid cursor", err)
+	}
+}
+
+func TestDecodeLeaderboardCursor(t *testing.T) {
+	entry := &LeaderboardEntry{UserId: "user-1", Score: 42}
+	decoded, err := decodeLeaderboardCursor(encodeLeaderboardCursor(entry, 3))
+	if err != nil {
+		t.Fatalf("decodeLeaderboardCursor() error = %v", err)
+	}
+	if decoded.UserId != "user-1" || decoded.Score != 42 || decoded.Position != 3 {
+		t.Errorf("decodeLeaderboardCursor() = %+v, want user-1, 42, 3", decoded)
+	}
+
+	for _, cursor := range []string{"", "!!", encodePlayHistoryCursor("basketball#2025-11-15")} {
+		if _, err := decodeLeaderboardCursor(cursor); err == nil {
+			t.Errorf("decodeLeaderboardCursor(%q) expected error", cursor)
+		}
+	}
+}
+
+func TestLeaderboardResultBoards(t *testing.T) {
+	refs := leaderboardResultBoards("basketball", "2025-11-28")
+	boards := []string{}
+	expiresAt := map[string]int64{}
+	for _, ref := range refs {
+		boards = append(boards, ref.Board)
+		expiresAt[ref.Board] = ref.ExpiresAt
+	}
+
+	if len(boards) != 2+7+30 {
+		t.Fatalf("leaderboardResultBoards() returned %d boards, want 39: %v", len(boards), boards)
+	}
+	for _, board := range []string{
+		"basketball#2025-11-28",
+		"basketball#all",
+		"basketball#7d#2025-11-28",
+		"basketball#7d#2025-12-04",
+		"basketball#30d#2025-12-27",
+	} {
+		if !contains(boards, board) {
+			t.Errorf("leaderboardResultBoards() = %v, want it to include %s", boards, board)
+		}
+	}
+	for _, board := range []string{"basketball#7d#2025-11-27", "basketball#7d#2025-12-05", "basketball#30d#2025-12-28"} {
+		if contains(boards, board) {
+			t.Errorf("leaderboardResultBoards() includes %s, a window that doesn't cover the round", board)
+		}
+	}
+
+	if expiresAt["basketball#2025-11-28"] != 0 || expiresAt["basketball#all"] != 0 {
+		t.Error("daily and all-time boards should never expire")
+	}
+	want := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC).AddDate(0, 0, LeaderboardWindowRetentionDays).Unix()
+	if expiresAt["basketball#7d#2025-12-04"] != want {
+		t.Errorf("7d board ending 2025-12-04 expires at %d, want %d", expiresAt["basketball#7d#2025-12-04"], want)
+	}
+
+	if boards := leaderboardResultBoards("basketball", "11/28/2025"); len(boards) != 2 {
+		t.Errorf("leaderboardResultBoards() with a malformed playDate = %v, want only the daily and all-time boards", boards)
+	}
+}
+
+func TestApplyLeaderboardResult(t *testing.T) {
+	entry := &LeaderboardEntry{UserName: "player"}
+	applyLeaderboardResult(entry, "", &Result{Score: 70, IsCorrect: true})
+	applyLeaderboardResult(entry, "", &Result{Score: 0, IsCorrect: false})
+	applyLeaderboardResult(entry, "renamed", &Result{Score: 40, IsCorrect: true})
+
+	if entry.Score != 110 || entry.RoundsPlayed != 3 || entry.CorrectCount != 2 || entry.HighestScore != 70 || entry.UserName != "renamed" {
+		t.Errorf("entry = %+v, want 110 points, 3 rounds, 2 correct, highest 70, named renamed", entry)
+	}
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+
+	results := []struct {
+		userId   string
+		playDate string
+		score    int
+	}{
+		{"alice", "2025-11-15", 90},
+		{"bob", "2025-11-15", 70},
+		{"carol", "2025-11-15", 70},
+		{"alice", "2025-11-14", 10},
+		{"bob", "2025-11-10", 60},
+		{"carol", "2025-10-01", 100},
+	}
+	for _, r := range results {
+		err := server.recordLeaderboardResult(ctx, r.userId, r.userId, "basketball", r.playDate, &Result{Score: r.score, IsCorrect: true})
+		if err != nil {
+			t.Fatalf("recordLeaderboardResult() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		userId         string
+		expectedStatus int
+		expectedUsers  []string
+		expectedScores []int
+		expectedMe     int // expected rank of the requesting user, 0 for none
+	}{
+		{
+			name:           "round leaderboard",
+			query:          "sport=basketball&playDate=2025-11-15",
+			userId:         "carol",
+			expectedStatus: http.StatusOK,
+			expectedUsers:  []string{"alice", "bob", "carol"},
+			expectedScores: []int{90, 70, 70},
+			expectedMe:     2,
+		},
+		{
+			name:           "user outside the first page still gets their rank",
+			query:          "sport=basketball&playDate=2025-11-15&limit=1",
+			userId:         "carol",
+			expectedStatus: http.StatusOK,
+			expectedUsers:  []string{"alice"},
+			expectedScores: []int{90},
+			expectedMe:     2,
+		},
+		{
+			name:           "rolling week sums daily boards",
+			query:          "sport=basketball&window=7d&playDate=2025-11-15",
+			userId:         "bob",
+			expectedStatus: http.StatusOK,
+			expectedUsers:  []string{"bob", "alice", "carol"},
+			expectedScores: []int{130, 100, 70},
+			expectedMe:     1,
+		},
+		{
+			name:           "rolling month",
+			query:          "sport=basketball&window=30d&playDate=2025-11-15",
+			userId:         "carol",
+			expectedStatus: http.StatusOK,
+			expectedUsers:  []string{"bob", "alice", "carol"},
+			expectedScores: []int{130, 100, 70},
+			expectedMe:     3,
+		},
+		{
+			name:           "rolling week that ended before the latest results",
+			query:          "sport=basketball&window=7d&playDate=2025-11-13",
+			expectedStatus: http.StatusOK,
+			expectedUsers:  []string{"bob"},
+			expectedScores: []int{60},
+		},
+		{
+			name:           "all time",
+			query:          "sport=basketball&window=all",
+			expectedStatus: http.StatusOK,
+			expectedUsers:  []string{"carol", "bob", "alice"},
+			expectedScores: []int{170, 130, 100},
+		},
+		{
+			name:           "missing sport",
+			query:          "window=all",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid window",
+			query:          "sport=basketball&window=1y",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid cursor",
+			query:          "sport=basketball&window=all&cursor=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(server.GetLeaderboard, http.MethodGet, "/v1/leaderboard?"+tt.query, nil, tt.userId)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.expectedStatus, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var leaderboard Leaderboard
+			if err := json.NewDecoder(w.Body).Decode(&leaderboard); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(leaderboard.Items) != len(tt.expectedUsers) {
+				t.Fatalf("items = %+v, want %v", leaderboard.Items, tt.expectedUsers)
+			}
+			for i, entry := range leaderboard.Items {
+				if entry.UserName != tt.expectedUsers[i] || entry.Score != tt.expectedScores[i] {
+					t.Errorf("item %d = %s with %d, want %s with %d", i, entry.UserName, entry.Score, tt.expectedUsers[i], tt.expectedScores[i])
+				}
+			}
+
+			if tt.expectedMe == 0 {
+				if leaderboard.Me != nil {
+					t.Errorf("me = %+v, want none", leaderboard.Me)
+				}
+			} else if leaderboard.Me == nil || leaderboard.Me.Rank != tt.expectedMe {
+				t.Errorf("me = %+v, want rank %d", leaderboard.Me, tt.expectedMe)
+			}
+		})
+	}
+}
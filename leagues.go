@@ -0,0 +1,447 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// leagueInviteCodeAlphabet leaves out characters that are easy to confuse (0/O, 1/I)
+// It has 32 characters, so a random byte maps onto it without bias
+const leagueInviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// maxLeagueCreateAttempts is how many times CreateLeague retries when a generated id or invite code is taken
+const maxLeagueCreateAttempts = 3
+
+// newLeagueID generates a random, unguessable league identifier
+func newLeagueID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate league id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newLeagueInviteCode generates a random invite code of LeagueInviteCodeLength characters
+// Example: "K7QMXR2P"
+func newLeagueInviteCode() (string, error) {
+	b := make([]byte, LeagueInviteCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+	for i := range b {
+		b[i] = leagueInviteCodeAlphabet[int(b[i])%len(leagueInviteCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// normalizeInviteCode makes invite codes case-insensitive and ignores surrounding whitespace
+func normalizeInviteCode(inviteCode string) string {
+	return strings.ToUpper(strings.TrimSpace(inviteCode))
+}
+
+// requireContextUserId returns the userId from the JWT token, responding 401 if there isn't one
+func requireContextUserId(c *gin.Context) (string, bool) {
+	userId := getContextUserId(c)
+	if userId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			JSONFieldError:     "Unauthorized",
+			JSONFieldMessage:   "User ID not found in token",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return "", false
+	}
+	return userId, true
+}
+
+// leagueMemberName returns the name a player is shown with in a league
+// Prefers the username from the JWT token, then the name saved with the player's stats
+func (s *Server) leagueMemberName(c *gin.Context, userId string) (string, error) {
+	if usernameToken, exists := c.Get(ConstantUsername); exists {
+		if username, ok := usernameToken.(string); ok && username != "" {
+			return username, nil
+		}
+	}
+
+	stats, err := s.db.GetUserStats(c.Request.Context(), userId)
+	if err != nil || stats == nil {
+		return "", err
+	}
+	return stats.UserName, nil
+}
+
+// respondLeagueDatabaseError responds with an unexpected error from the store
+func respondLeagueDatabaseError(c *gin.Context, action string, err error) {
+	log.Printf("Failed to %s: %v", action, err)
+	c.JSON(http.StatusInternalServerError, gin.H{
+		JSONFieldError:     StatusInternalServerError,
+		JSONFieldMessage:   "Failed to " + action + ": " + err.Error(),
+		JSONFieldCode:      ErrorDatabaseError,
+		JSONFieldTimestamp: time.Now(),
+	})
+}
+
+// CreateLeague handles POST /v1/leagues - creates a private league with the caller as its owner and first member
+// Body: {"name": "..."}. Responds with the league, including the invite code to share with friends
+func (s *Server) CreateLeague(c *gin.Context) {
+	userId, ok := requireContextUserId(c)
+	if !ok {
+		return
+	}
+
+	var requestBody struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid request body: " + err.Error(),
+			JSONFieldCode:      ErrorInvalidRequestBody,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	name := strings.TrimSpace(requestBody.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Missing required field: name",
+			JSONFieldCode:      ErrorMissingRequiredField,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if utf8.RuneCountInString(name) > MaxLeagueNameLength {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "name must be at most " + strconv.Itoa(MaxLeagueNameLength) + " characters",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	userName, err := s.leagueMemberName(c, userId)
+	if err != nil {
+		respondLeagueDatabaseError(c, "create league", err)
+		return
+	}
+
+	var league *League
+	for attempt := 1; league == nil; attempt++ {
+		leagueId, err := newLeagueID()
+		if err != nil {
+			respondLeagueDatabaseError(c, "create league", err)
+			return
+		}
+		inviteCode, err := newLeagueInviteCode()
+		if err != nil {
+			respondLeagueDatabaseError(c, "create league", err)
+			return
+		}
+
+		candidate := &League{LeagueID: leagueId, Name: name, InviteCode: inviteCode, OwnerId: userId}
+		err = s.db.CreateLeague(ctx, candidate)
+		if err != nil && err.Error() == "league already exists" && attempt < maxLeagueCreateAttempts {
+			continue // Generated id or invite code clashed, try new ones
+		}
+		if err != nil {
+			respondLeagueDatabaseError(c, "create league", err)
+			return
+		}
+		league = candidate
+	}
+
+	if err := s.db.AddLeagueMember(ctx, &LeagueMember{LeagueID: league.LeagueID, UserId: userId, UserName: userName}); err != nil {
+		respondLeagueDatabaseError(c, "add league owner", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, league)
+}
+
+// GetLeagues handles GET /v1/leagues - lists the leagues the caller belongs to, sorted by name
+func (s *Server) GetLeagues(c *gin.Context) {
+	userId, ok := requireContextUserId(c)
+	if !ok {
+		return
+	}
+
+	leagues, err := s.db.GetUserLeagues(c.Request.Context(), userId)
+	if err != nil {
+		respondLeagueDatabaseError(c, "retrieve leagues", err)
+		return
+	}
+
+	sort.SliceStable(leagues, func(i, j int) bool {
+		return strings.ToLower(leagues[i].Name) < strings.ToLower(leagues[j].Name)
+	})
+
+	c.JSON(http.StatusOK, leagues)
+}
+
+// JoinLeague handles POST /v1/leagues/join - adds the caller to the league with the given invite code
+// Body: {"inviteCode": "..."}. Invite codes are case-insensitive
+func (s *Server) JoinLeague(c *gin.Context) {
+	userId, ok := requireContextUserId(c)
+	if !ok {
+		return
+	}
+
+	var requestBody struct {
+		InviteCode string `json:"inviteCode"`
+	}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid request body: " + err.Error(),
+			JSONFieldCode:      ErrorInvalidRequestBody,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	inviteCode := normalizeInviteCode(requestBody.InviteCode)
+	if inviteCode == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Missing required field: inviteCode",
+			JSONFieldCode:      ErrorMissingRequiredField,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	league, err := s.db.GetLeagueByInviteCode(ctx, inviteCode)
+	if err != nil {
+		respondLeagueDatabaseError(c, "join league", err)
+		return
+	}
+	if league == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			JSONFieldError:     StatusNotFound,
+			JSONFieldMessage:   "No league found for invite code '" + inviteCode + "'",
+			JSONFieldCode:      ErrorLeagueNotFound,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	members, err := s.db.GetLeagueMembers(ctx, league.LeagueID)
+	if err != nil {
+		respondLeagueDatabaseError(c, "join league", err)
+		return
+	}
+	for _, member := range members {
+		if member.UserId == userId {
+			respondAlreadyLeagueMember(c, league)
+			return
+		}
+	}
+	// The limit is checked before adding, so players joining at the same moment can briefly exceed it
+	if len(members) >= MaxLeagueMembers {
+		c.JSON(http.StatusConflict, gin.H{
+			JSONFieldError:     StatusConflict,
+			JSONFieldMessage:   "League '" + league.Name + "' already has the maximum of " + strconv.Itoa(MaxLeagueMembers) + " members",
+			JSONFieldCode:      ErrorLeagueFull,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	userName, err := s.leagueMemberName(c, userId)
+	if err != nil {
+		respondLeagueDatabaseError(c, "join league", err)
+		return
+	}
+
+	err = s.db.AddLeagueMember(ctx, &LeagueMember{LeagueID: league.LeagueID, UserId: userId, UserName: userName})
+	if err != nil {
+		if err.Error() == "league member already exists" {
+			respondAlreadyLeagueMember(c, league)
+			return
+		}
+		respondLeagueDatabaseError(c, "join league", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, league)
+}
+
+// respondAlreadyLeagueMember responds to a player joining a league they're already in
+func respondAlreadyLeagueMember(c *gin.Context, league *League) {
+	c.JSON(http.StatusConflict, gin.H{
+		JSONFieldError:     StatusConflict,
+		JSONFieldMessage:   "Already a member of league '" + league.Name + "'",
+		JSONFieldCode:      ErrorAlreadyLeagueMember,
+		JSONFieldTimestamp: time.Now(),
+	})
+}
+
+// getLeagueForMember loads the league named by the leagueId query param and its members
+// Responds with an error and returns false if the league doesn't exist or userId isn't a member
+func (s *Server) getLeagueForMember(c *gin.Context, userId string) (*League, []*LeagueMember, bool) {
+	leagueId := c.Query(QueryParamLeagueId)
+	if leagueId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "leagueId parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return nil, nil, false
+	}
+
+	ctx := c.Request.Context()
+	league, err := s.db.GetLeague(ctx, leagueId)
+	if err != nil {
+		respondLeagueDatabaseError(c, "retrieve league", err)
+		return nil, nil, false
+	}
+	if league == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			JSONFieldError:     StatusNotFound,
+			JSONFieldMessage:   "No league found with id '" + leagueId + "'",
+			JSONFieldCode:      ErrorLeagueNotFound,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return nil, nil, false
+	}
+
+	members, err := s.db.GetLeagueMembers(ctx, leagueId)
+	if err != nil {
+		respondLeagueDatabaseError(c, "retrieve league members", err)
+		return nil, nil, false
+	}
+	for _, member := range members {
+		if member.UserId == userId {
+			return league, members, true
+		}
+	}
+
+	// Leagues are private, so only members can see who's in them
+	c.JSON(http.StatusForbidden, gin.H{
+		JSONFieldError:     StatusForbidden,
+		JSONFieldMessage:   "Not a member of league '" + leagueId + "'",
+		JSONFieldCode:      ErrorNotLeagueMember,
+		JSONFieldTimestamp: time.Now(),
+	})
+	return nil, nil, false
+}
+
+// GetLeagueMembers handles GET /v1/leagues/members - lists a league's members in the order they joined
+// Query params: leagueId (required). Only members of the league can list it
+func (s *Server) GetLeagueMembers(c *gin.Context) {
+	userId, ok := requireContextUserId(c)
+	if !ok {
+		return
+	}
+
+	_, members, ok := s.getLeagueForMember(c, userId)
+	if !ok {
+		return
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Joined.Before(members[j].Joined)
+	})
+
+	c.JSON(http.StatusOK, members)
+}
+
+// GetLeagueLeaderboard handles GET /v1/leagues/leaderboard - ranks every member of a league for a round,
+// a rolling window or all time, from the members' entries on the sport's leaderboard
+// Query params: leagueId and sport (required), window (round, 7d, 30d or all; defaults to 7d) and playDate (defaults to today)
+func (s *Server) GetLeagueLeaderboard(c *gin.Context) {
+	userId, ok := requireContextUserId(c)
+	if !ok {
+		return
+	}
+
+	sport := c.Query(QueryParamSport)
+	if sport == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "sport parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if !IsValidSport(sport) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid sport '" + sport + "'",
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	view, ok := parseLeaderboardWindow(c, sport, DefaultLeagueWindow)
+	if !ok {
+		return
+	}
+
+	league, members, ok := s.getLeagueForMember(c, userId)
+	if !ok {
+		return
+	}
+
+	userIds := make([]string, 0, len(members))
+	for _, member := range members {
+		userIds = append(userIds, member.UserId)
+	}
+	entries, err := s.db.GetLeaderboardEntriesForUsers(c.Request.Context(), view.Board, userIds)
+	if err != nil {
+		respondLeagueDatabaseError(c, "retrieve league leaderboard", err)
+		return
+	}
+	entriesByUser := make(map[string]*LeaderboardEntry, len(entries))
+	for _, entry := range entries {
+		entriesByUser[entry.UserId] = entry
+	}
+
+	leaderboard := LeagueLeaderboard{
+		LeagueID:  league.LeagueID,
+		Name:      league.Name,
+		Sport:     sport,
+		Window:    view.Window,
+		StartDate: view.StartDate,
+		EndDate:   view.EndDate,
+		Items:     make([]*LeaderboardEntry, 0, len(members)),
+	}
+	for _, member := range members {
+		entry, ok := entriesByUser[member.UserId]
+		if !ok {
+			// Members who haven't played in the window are listed with no results
+			entry = &LeaderboardEntry{Board: view.Board, UserId: member.UserId, UserName: member.UserName}
+		}
+		leaderboard.Items = append(leaderboard.Items, entry)
+		if member.UserId == userId {
+			leaderboard.Me = entry
+		}
+	}
+
+	// Every member is listed, including those who haven't played, so the whole board is one page
+	sortLeaderboardEntries(leaderboard.Items)
+	if err := rankLeaderboardPage(leaderboard.Items, 0, nil); err != nil {
+		respondLeagueDatabaseError(c, "retrieve league leaderboard", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
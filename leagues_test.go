@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewLeagueInviteCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code, err := newLeagueInviteCode()
+		if err != nil {
+			t.Fatalf("newLeagueInviteCode() error = %v", err)
+		}
+		if len(code) != LeagueInviteCodeLength {
+			t.Errorf("newLeagueInviteCode() = %q, want %d characters", code, LeagueInviteCodeLength)
+		}
+		for _, r := range code {
+			if !strings.ContainsRune(leagueInviteCodeAlphabet, r) {
+				t.Errorf("newLeagueInviteCode() = %q, contains %q outside the alphabet", code, r)
+			}
+		}
+		if seen[code] {
+			t.Errorf("newLeagueInviteCode() repeated %q", code)
+		}
+		seen[code] = true
+	}
+
+	if len(leagueInviteCodeAlphabet) != 32 {
+		t.Errorf("alphabet has %d characters, want 32 so codes are unbiased", len(leagueInviteCodeAlphabet))
+	}
+}
+
+// TestLeagueFlow creates a league, joins it and reads its members and leaderboard against the in-memory store
+func TestLeagueFlow(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+
+	for _, userId := range []string{"alice", "bob", "carol"} {
+		if err := server.db.CreateUserStats(ctx, &UserStats{UserId: userId, UserName: "name-" + userId}); err != nil {
+			t.Fatalf("CreateUserStats() error = %v", err)
+		}
+	}
+
+	// Create
+	if w := performRequest(server.CreateLeague, http.MethodPost, "/v1/leagues", map[string]string{"name": "  "}, "alice"); w.Code != http.StatusBadRequest {
+		t.Errorf("create with a blank name status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if w := performRequest(server.CreateLeague, http.MethodPost, "/v1/leagues", map[string]string{"name": "Office"}, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("create without a user status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	w := performRequest(server.CreateLeague, http.MethodPost, "/v1/leagues", map[string]string{"name": " Office "}, "alice")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var league League
+	if err := json.NewDecoder(w.Body).Decode(&league); err != nil {
+		t.Fatalf("failed to decode league: %v", err)
+	}
+	if league.LeagueID == "" || league.Name != "Office" || league.OwnerId != "alice" || len(league.InviteCode) != LeagueInviteCodeLength {
+		t.Fatalf("created league = %+v", league)
+	}
+
+	// Join
+	joinTests := []struct {
+		name           string
+		userId         string
+		inviteCode     string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "join with a lowercase code", userId: "bob", inviteCode: strings.ToLower(league.InviteCode), expectedStatus: http.StatusOK},
+		{name: "join again", userId: "bob", inviteCode: league.InviteCode, expectedStatus: http.StatusConflict, expectedCode: ErrorAlreadyLeagueMember},
+		{name: "owner is already a member", userId: "alice", inviteCode: league.InviteCode, expectedStatus: http.StatusConflict, expectedCode: ErrorAlreadyLeagueMember},
+		{name: "unknown code", userId: "carol", inviteCode: "NOPE2345", expectedStatus: http.StatusNotFound, expectedCode: ErrorLeagueNotFound},
+		{name: "missing code", userId: "carol", expectedStatus: http.StatusBadRequest, expectedCode: ErrorMissingRequiredField},
+	}
+	for _, tt := range joinTests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(server.JoinLeague, http.MethodPost, "/v1/leagues/join", map[string]string{"inviteCode": tt.inviteCode}, tt.userId)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.expectedStatus, w.Body.String())
+			}
+			if tt.expectedCode != "" && !strings.Contains(w.Body.String(), tt.expectedCode) {
+				t.Errorf("body = %s, want code %s", w.Body.String(), tt.expectedCode)
+			}
+		})
+	}
+
+	// List leagues
+	w = performRequest(server.GetLeagues, http.MethodGet, "/v1/leagues", nil, "bob")
+	var leagues []*League
+	if err := json.NewDecoder(w.Body).Decode(&leagues); err != nil || len(leagues) != 1 || leagues[0].LeagueID != league.LeagueID {
+		t.Errorf("bob's leagues = %+v (%v), want the Office league", leagues, err)
+	}
+
+	// Members are private to the league
+	w = performRequest(server.GetLeagueMembers, http.MethodGet, "/v1/leagues/members?leagueId="+league.LeagueID, nil, "carol")
+	if w.Code != http.StatusForbidden {
+		t.Errorf("members for a non-member status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	w = performRequest(server.GetLeagueMembers, http.MethodGet, "/v1/leagues/members?leagueId=missing", nil, "alice")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("members of a missing league status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	w = performRequest(server.GetLeagueMembers, http.MethodGet, "/v1/leagues/members?leagueId="+league.LeagueID, nil, "bob")
+	var members []*LeagueMember
+	if err := json.NewDecoder(w.Body).Decode(&members); err != nil || len(members) != 2 || members[0].UserId != "alice" || members[0].UserName != "name-alice" {
+		t.Errorf("members = %+v (%v), want alice then bob", members, err)
+	}
+
+	// Leaderboard
+	for _, played := range []struct {
+		userId   string
+		sport    string
+		playDate string
+		score    int
+	}{
+		{"alice", "basketball", "2025-11-15", 40},
+		{"alice", "basketball", "2025-11-14", 30},
+		{"bob", "basketball", "2025-11-15", 90},
+		{"bob", "basketball", "2025-11-01", 100},   // outside the week
+		{"alice", "baseball", "2025-11-15", 100},   // other sport
+		{"carol", "basketball", "2025-11-15", 100}, // not a member
+	} {
+		err := server.recordLeaderboardResult(ctx, played.userId, "name-"+played.userId, played.sport, played.playDate,
+			&Result{Score: played.score, IsCorrect: true})
+		if err != nil {
+			t.Fatalf("recordLeaderboardResult() error = %v", err)
+		}
+	}
+
+	leaderboardTests := []struct {
+		name           string
+		query          string
+		expectedStart  string
+		expectedScores []int
+		expectedMe     int
+	}{
+		{name: "defaults to the week", query: "&playDate=2025-11-15", expectedStart: "2025-11-09", expectedScores: []int{90, 70}, expectedMe: 2},
+		{name: "round without bob", query: "&window=round&playDate=2025-11-14", expectedStart: "2025-11-14", expectedScores: []int{30, 0}, expectedMe: 1},
+		{name: "all time", query: "&window=all", expectedScores: []int{190, 70}, expectedMe: 2},
+	}
+	for _, tt := range leaderboardTests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(server.GetLeagueLeaderboard, http.MethodGet,
+				"/v1/leagues/leaderboard?leagueId="+league.LeagueID+"&sport=basketball"+tt.query, nil, "alice")
+			if w.Code != http.StatusOK {
+				t.Fatalf("leaderboard status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			var leaderboard LeagueLeaderboard
+			if err := json.NewDecoder(w.Body).Decode(&leaderboard); err != nil {
+				t.Fatalf("failed to decode leaderboard: %v", err)
+			}
+			if leaderboard.StartDate != tt.expectedStart {
+				t.Errorf("startDate = %q, want %q", leaderboard.StartDate, tt.expectedStart)
+			}
+			if len(leaderboard.Items) != len(tt.expectedScores) {
+				t.Fatalf("leaderboard items = %+v, want %d members", leaderboard.Items, len(tt.expectedScores))
+			}
+			for i, score := range tt.expectedScores {
+				if leaderboard.Items[i].Score != score || leaderboard.Items[i].UserName == "" {
+					t.Errorf("item %d = %+v, want a named member with score %d", i, leaderboard.Items[i], score)
+				}
+			}
+			if leaderboard.Me == nil || leaderboard.Me.UserName != "name-alice" || leaderboard.Me.Rank != tt.expectedMe {
+				t.Errorf("me = %+v, want alice at rank %d", leaderboard.Me, tt.expectedMe)
+			}
+		})
+	}
+
+	for _, query := range []string{
+		"leagueId=" + league.LeagueID,                                   // missing sport
+		"leagueId=" + league.LeagueID + "&sport=basketball&window=year", // unknown window
+		"sport=basketball",                                              // missing league
+	} {
+		if w := performRequest(server.GetLeagueLeaderboard, http.MethodGet, "/v1/leagues/leaderboard?"+query, nil, "alice"); w.Code != http.StatusBadRequest {
+			t.Errorf("leaderboard?%s status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -4,11 +4,44 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+
+	"github.com/joho/godotenv"
 )
 
 func main() {
+	// "migrate" creates missing tables and applies pending migrations, then exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		_ = godotenv.Load()
+		if err := MigrateStore(context.Background(), LoadConfig()); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Printf("Migrations complete")
+		return
+	}
+
+	// "import-rounds" scrapes and creates every round listed in a manifest, then exits
+	if len(os.Args) > 1 && os.Args[1] == "import-rounds" {
+		_ = godotenv.Load()
+		if err := ImportRounds(context.Background(), LoadConfig(), os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Round import failed: %v", err)
+		}
+		log.Printf("Round import complete")
+		return
+	}
+
+	// "fill-schedule" reports the coming days without a round and fills them from a queue of players, then exits
+	if len(os.Args) > 1 && os.Args[1] == "fill-schedule" {
+		_ = godotenv.Load()
+		if err := FillSchedule(context.Background(), LoadConfig(), os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Schedule fill failed: %v", err)
+		}
+		log.Printf("Schedule fill complete")
+		return
+	}
+
 	router := SetupRouter()
 
 	port := os.Getenv("PORT")
@@ -0,0 +1,848 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// memoryItem is a stored item in DynamoDB attribute value form
+// Items are marshaled on write and unmarshaled on read, so callers never share memory with the store
+// and the dynamodbav tags behave exactly as they do against DynamoDB
+type memoryItem = map[string]types.AttributeValue
+
+// MemoryStore is an in-memory Store for tests and running the server locally without DynamoDB
+// It mirrors the conditional-write semantics of DB. Data is lost when the process exits
+type MemoryStore struct {
+	mu            sync.Mutex
+	rounds        map[string]memoryItem // keyed by "<sport>#<playDate>"
+	userStats     map[string]memoryItem // keyed by userId
+	gameSessions  map[string]memoryItem // keyed by sessionId
+	submissions   map[string]memoryItem // keyed by submissionKey
+	playHistory   map[string]memoryItem // keyed by "<userId>|<sport>#<playDate>"
+	leaderboards  map[string]memoryItem // keyed by "<board>|<userId>"
+	leagues       map[string]memoryItem // keyed by leagueId
+	leagueMembers map[string]memoryItem // keyed by "<leagueId>|<userId>"
+	roundEdits    map[string]memoryItem // keyed by "<sport>#<playDate>|<editKey>"
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		rounds:        map[string]memoryItem{},
+		userStats:     map[string]memoryItem{},
+		gameSessions:  map[string]memoryItem{},
+		submissions:   map[string]memoryItem{},
+		playHistory:   map[string]memoryItem{},
+		leaderboards:  map[string]memoryItem{},
+		leagues:       map[string]memoryItem{},
+		leagueMembers: map[string]memoryItem{},
+		roundEdits:    map[string]memoryItem{},
+	}
+}
+
+// memoryPlayHistoryKey returns the map key for a play history entry
+func memoryPlayHistoryKey(userId, sportPlayDate string) string {
+	return userId + "|" + sportPlayDate
+}
+
+// GetRound retrieves a round by playDate and sport
+func (m *MemoryStore) GetRound(ctx context.Context, sport, playDate string) (*Round, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.getRound(sport, playDate)
+}
+
+// getRound retrieves a round. Callers must hold m.mu
+func (m *MemoryStore) getRound(sport, playDate string) (*Round, error) {
+	item, ok := m.rounds[sportPlayDateKey(sport, playDate)]
+	if !ok {
+		return nil, nil // Not found
+	}
+
+	var round Round
+	if err := attributevalue.UnmarshalMap(item, &round); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal round: %w", err)
+	}
+
+	// Stats are stored as raw counters, derive the averages, percentages and difficulty
+	computeRoundStats(&round)
+
+	return &round, nil
+}
+
+// putRound stores a round. Callers must hold m.mu
+func (m *MemoryStore) putRound(round *Round) error {
+	item, err := attributevalue.MarshalMap(round)
+	if err != nil {
+		return fmt.Errorf("failed to marshal round: %w", err)
+	}
+	m.rounds[sportPlayDateKey(round.Sport, round.PlayDate)] = item
+	return nil
+}
+
+// CreateRound creates a new round
+func (m *MemoryStore) CreateRound(ctx context.Context, round *Round) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.rounds[sportPlayDateKey(round.Sport, round.PlayDate)]; ok {
+		return fmt.Errorf("round already exists")
+	}
+
+	// Set timestamps
+	now := time.Now()
+	round.Created = now
+	round.LastUpdated = now
+
+	// Initialize stats if not provided
+	if round.Stats.PlayDate == "" {
+		round.Stats.PlayDate = round.PlayDate
+		round.Stats.Name = round.Player.Name
+		round.Stats.Sport = round.Sport
+	}
+
+	return m.putRound(round)
+}
+
+// UpdateRound updates an existing round
+func (m *MemoryStore) UpdateRound(ctx context.Context, round *Round) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	round.LastUpdated = time.Now()
+	return m.putRound(round)
+}
+
+// PatchRound sets the theme and tile fields present in a patch, leaving the rest of the round and its stats alone
+// The changes are recorded as a RoundEdit by editor (see newRoundEdit) under the same lock as the update
+// Returns the round as it was before the patch, or an error "round not found" if the round doesn't exist
+func (m *MemoryStore) PatchRound(ctx context.Context, sport, playDate string, patch *RoundPatch, editor string) (*Round, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous, err := m.getRound(sport, playDate)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, fmt.Errorf("round not found")
+	}
+
+	edit := newRoundEdit(previous, patch, editor)
+	if edit == nil {
+		// Every field already has its patched value, so there's nothing to write or record
+		return previous, nil
+	}
+	editItem, err := m.roundEditItem(edit)
+	if err != nil {
+		return nil, err
+	}
+
+	round := *previous
+	applyRoundPatch(&round, patch)
+	round.LastUpdated = edit.Edited
+
+	if err := m.putRound(&round); err != nil {
+		return nil, err
+	}
+	m.roundEdits[memoryRoundEditKey(edit)] = editItem
+	return previous, nil
+}
+
+// ApplyRoundResult adds a submitted result to a round's stats
+// Returns an error "round not found" if the round doesn't exist
+func (m *MemoryStore) ApplyRoundResult(ctx context.Context, sport, playDate string, result *Result) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	round, err := m.getRound(sport, playDate)
+	if err != nil {
+		return err
+	}
+	if round == nil {
+		return fmt.Errorf("round not found")
+	}
+
+	applyRoundResult(&round.Stats, result)
+	round.LastUpdated = time.Now()
+
+	return m.putRound(round)
+}
+
+// DeleteRound deletes a round by playDate and sport
+func (m *MemoryStore) DeleteRound(ctx context.Context, sport, playDate string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.rounds, sportPlayDateKey(sport, playDate))
+	return nil
+}
+
+// GetRoundsBySport retrieves minimal round information for a specific sport, optionally filtered by date range
+// Rounds are sorted by playDate in descending order (latest to earliest)
+func (m *MemoryStore) GetRoundsBySport(ctx context.Context, sport, startDate, endDate string) ([]*RoundSummary, error) {
+	rounds, err := m.GetRoundStatsBySport(ctx, sport, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return newRoundSummaries(rounds), nil
+}
+
+// GetRoundStatsBySport retrieves a sport's rounds with their stats, optionally filtered by date range
+// Rounds are sorted by playDate in descending order (latest to earliest)
+func (m *MemoryStore) GetRoundStatsBySport(ctx context.Context, sport, startDate, endDate string) ([]*Round, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rounds []*Round
+	for _, item := range m.rounds {
+		var round Round
+		if err := attributevalue.UnmarshalMap(item, &round); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal round: %w", err)
+		}
+		if round.Sport != sport {
+			continue
+		}
+		if (startDate != "" && round.PlayDate < startDate) || (endDate != "" && round.PlayDate > endDate) {
+			continue
+		}
+		rounds = append(rounds, &round)
+	}
+
+	sort.Slice(rounds, func(i, j int) bool {
+		return rounds[i].PlayDate > rounds[j].PlayDate
+	})
+
+	return rounds, nil
+}
+
+// GetUserStats retrieves user statistics by userId
+func (m *MemoryStore) GetUserStats(ctx context.Context, userId string) (*UserStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.userStats[userId]
+	if !ok {
+		return nil, nil // Not found
+	}
+
+	var stats UserStats
+	if err := attributevalue.UnmarshalMap(item, &stats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// CreateUserStats creates new user statistics
+func (m *MemoryStore) CreateUserStats(ctx context.Context, stats *UserStats) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.userStats[stats.UserId]; ok {
+		return fmt.Errorf("user stats already exist")
+	}
+
+	// Set timestamp and initial version
+	stats.UserCreated = time.Now()
+	stats.Version = 1
+
+	item, err := attributevalue.MarshalMap(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal user stats: %w", err)
+	}
+	m.userStats[stats.UserId] = item
+
+	return nil
+}
+
+// UpdateUserStats updates existing user statistics if the stored version still matches stats.Version
+// Returns an error "user stats version conflict" otherwise
+func (m *MemoryStore) UpdateUserStats(ctx context.Context, stats *UserStats) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.userStats[stats.UserId]
+	if !ok {
+		return fmt.Errorf("user stats version conflict")
+	}
+
+	var stored UserStats
+	if err := attributevalue.UnmarshalMap(existing, &stored); err != nil {
+		return fmt.Errorf("failed to unmarshal user stats: %w", err)
+	}
+	// Items saved before versioning are read as version 0 and accept any write, as in DB
+	if stored.Version != 0 && stored.Version != stats.Version {
+		return fmt.Errorf("user stats version conflict")
+	}
+
+	stats.Version++
+	item, err := attributevalue.MarshalMap(stats)
+	if err != nil {
+		stats.Version--
+		return fmt.Errorf("failed to marshal user stats: %w", err)
+	}
+	m.userStats[stats.UserId] = item
+
+	return nil
+}
+
+// GetGameSession retrieves a game session by sessionId
+func (m *MemoryStore) GetGameSession(ctx context.Context, sessionId string) (*GameSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.gameSessions[sessionId]
+	if !ok {
+		return nil, nil // Not found
+	}
+
+	var session GameSession
+	if err := attributevalue.UnmarshalMap(item, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// CreateGameSession creates a new game session
+func (m *MemoryStore) CreateGameSession(ctx context.Context, session *GameSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.gameSessions[session.SessionID]; ok {
+		return fmt.Errorf("game session already exists")
+	}
+
+	// Set timestamps
+	now := time.Now()
+	session.Created = now
+	session.LastUpdated = now
+	session.ExpiresAt = now.Add(GameSessionTTL).Unix()
+
+	item, err := attributevalue.MarshalMap(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game session: %w", err)
+	}
+	m.gameSessions[session.SessionID] = item
+
+	return nil
+}
+
+// UpdateGameSession updates an existing game session
+func (m *MemoryStore) UpdateGameSession(ctx context.Context, session *GameSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.LastUpdated = time.Now()
+
+	item, err := attributevalue.MarshalMap(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game session: %w", err)
+	}
+	m.gameSessions[session.SessionID] = item
+
+	return nil
+}
+
+// GetSubmission retrieves a result submission by its submission key
+func (m *MemoryStore) GetSubmission(ctx context.Context, submissionKey string) (*Submission, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.submissions[submissionKey]
+	if !ok {
+		return nil, nil // Not found
+	}
+
+	var submission Submission
+	if err := attributevalue.UnmarshalMap(item, &submission); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal submission: %w", err)
+	}
+
+	return &submission, nil
+}
+
+// CreateSubmission records a result submission. Fails if the submission key has already been used
+func (m *MemoryStore) CreateSubmission(ctx context.Context, submission *Submission) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.submissions[submission.SubmissionKey]; ok {
+		return fmt.Errorf("submission already exists")
+	}
+
+	// Set timestamp
+	submission.Created = time.Now()
+
+	item, err := attributevalue.MarshalMap(submission)
+	if err != nil {
+		return fmt.Errorf("failed to marshal submission: %w", err)
+	}
+	m.submissions[submission.SubmissionKey] = item
+
+	return nil
+}
+
+// DeleteSubmission deletes a result submission by its submission key
+func (m *MemoryStore) DeleteSubmission(ctx context.Context, submissionKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.submissions, submissionKey)
+	return nil
+}
+
+// UpdateSubmissionStatus records the step a result submission has reached. Does nothing if the key isn't stored
+func (m *MemoryStore) UpdateSubmissionStatus(ctx context.Context, submissionKey, status string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.submissions[submissionKey]
+	if !ok {
+		return nil
+	}
+	item["status"] = &types.AttributeValueMemberS{Value: status}
+
+	return nil
+}
+
+// PutPlayHistoryEntry saves a round played by a user, replacing any existing entry for the same round
+func (m *MemoryStore) PutPlayHistoryEntry(ctx context.Context, entry *PlayHistoryEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry.SportPlayDate = sportPlayDateKey(entry.Sport, entry.PlayDate)
+	if entry.Created.IsZero() {
+		entry.Created = time.Now()
+	}
+
+	item, err := attributevalue.MarshalMap(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal play history entry: %w", err)
+	}
+	m.playHistory[memoryPlayHistoryKey(entry.UserId, entry.SportPlayDate)] = item
+
+	return nil
+}
+
+// GetPlayHistoryEntry retrieves the entry for a single round played by a user
+func (m *MemoryStore) GetPlayHistoryEntry(ctx context.Context, userId, sport, playDate string) (*PlayHistoryEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.playHistory[memoryPlayHistoryKey(userId, sportPlayDateKey(sport, playDate))]
+	if !ok {
+		return nil, nil // Not found
+	}
+
+	var entry PlayHistoryEntry
+	if err := attributevalue.UnmarshalMap(item, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal play history entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// GetPlayHistory retrieves one page of a user's play history, optionally filtered by sport
+// Entries are sorted by sport, then by playDate in descending order (latest to earliest)
+func (m *MemoryStore) GetPlayHistory(ctx context.Context, userId, sport string, limit int32, cursor string) ([]*PlayHistoryEntry, string, error) {
+	var after string
+	if cursor != "" {
+		var err error
+		after, err = decodePlayHistoryCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var entries []*PlayHistoryEntry
+	for _, item := range m.playHistory {
+		var entry PlayHistoryEntry
+		if err := attributevalue.UnmarshalMap(item, &entry); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal play history entry: %w", err)
+		}
+		if entry.UserId != userId || (sport != "" && !strings.HasPrefix(entry.SportPlayDate, sport+"#")) {
+			continue
+		}
+		// Sort keys are read in descending order, so the next page starts below the cursor
+		if after != "" && entry.SportPlayDate >= after {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].SportPlayDate > entries[j].SportPlayDate
+	})
+
+	var nextCursor string
+	if limit > 0 && len(entries) > int(limit) {
+		entries = entries[:limit]
+		nextCursor = encodePlayHistoryCursor(entries[len(entries)-1].SportPlayDate)
+	}
+	if entries == nil {
+		entries = []*PlayHistoryEntry{}
+	}
+
+	return entries, nextCursor, nil
+}
+
+// memoryLeaderboardKey returns the map key for a leaderboard entry
+func memoryLeaderboardKey(board, userId string) string {
+	return board + "|" + userId
+}
+
+// getLeaderboardEntry retrieves an unranked leaderboard entry. Callers must hold m.mu
+func (m *MemoryStore) getLeaderboardEntry(board, userId string) (*LeaderboardEntry, error) {
+	item, ok := m.leaderboards[memoryLeaderboardKey(board, userId)]
+	if !ok {
+		return nil, nil // Not found
+	}
+
+	var entry LeaderboardEntry
+	if err := attributevalue.UnmarshalMap(item, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal leaderboard entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// leaderboardEntries returns every entry on a leaderboard, sorted by score. Callers must hold m.mu
+func (m *MemoryStore) leaderboardEntries(board string) ([]*LeaderboardEntry, error) {
+	entries := []*LeaderboardEntry{}
+	for _, item := range m.leaderboards {
+		var entry LeaderboardEntry
+		if err := attributevalue.UnmarshalMap(item, &entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal leaderboard entry: %w", err)
+		}
+		if entry.Board == board {
+			entries = append(entries, &entry)
+		}
+	}
+
+	sortLeaderboardEntries(entries)
+	return entries, nil
+}
+
+// AddLeaderboardResult adds a counted result to a player's entry on each board, creating entries if needed
+// Every entry is updated under one lock, so the boards are never left partly updated
+// Expired rolling board entries aren't removed, since in-memory data doesn't outlive the process anyway
+func (m *MemoryStore) AddLeaderboardResult(ctx context.Context, boards []LeaderboardBoardRef, userId, userName string, result *Result) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := map[string]memoryItem{}
+	for _, ref := range boards {
+		entry, err := m.getLeaderboardEntry(ref.Board, userId)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			entry = &LeaderboardEntry{Board: ref.Board, UserId: userId}
+		}
+		entry.ExpiresAt = ref.ExpiresAt
+
+		applyLeaderboardResult(entry, userName, result)
+
+		item, err := attributevalue.MarshalMap(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal leaderboard entry: %w", err)
+		}
+		items[memoryLeaderboardKey(ref.Board, userId)] = item
+	}
+
+	for key, item := range items {
+		m.leaderboards[key] = item
+	}
+	return nil
+}
+
+// GetLeaderboard retrieves one page of a leaderboard, ranked by score (highest first)
+func (m *MemoryStore) GetLeaderboard(ctx context.Context, board string, limit int32, cursor string) ([]*LeaderboardEntry, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries, err := m.leaderboardEntries(board)
+	if err != nil {
+		return nil, "", err
+	}
+	return pageLeaderboard(entries, limit, cursor)
+}
+
+// GetLeaderboardEntry retrieves a player's entry on a leaderboard with its rank
+func (m *MemoryStore) GetLeaderboardEntry(ctx context.Context, board, userId string) (*LeaderboardEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, err := m.getLeaderboardEntry(board, userId)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	entries, err := m.leaderboardEntries(board)
+	if err != nil {
+		return nil, err
+	}
+	rankLeaderboardEntry(entry, entries)
+	return entry, nil
+}
+
+// GetLeaderboardEntriesForUsers retrieves the unranked entries of the given players on a leaderboard
+// Players without an entry are left out
+func (m *MemoryStore) GetLeaderboardEntriesForUsers(ctx context.Context, board string, userIds []string) ([]*LeaderboardEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := []*LeaderboardEntry{}
+	for _, userId := range userIds {
+		entry, err := m.getLeaderboardEntry(board, userId)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// leagueItems returns every league, sorted by leagueId. Callers must hold m.mu
+func (m *MemoryStore) leagueItems() ([]*League, error) {
+	leagues := []*League{}
+	for _, item := range m.leagues {
+		var league League
+		if err := attributevalue.UnmarshalMap(item, &league); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal league: %w", err)
+		}
+		leagues = append(leagues, &league)
+	}
+
+	sort.Slice(leagues, func(i, j int) bool {
+		return leagues[i].LeagueID < leagues[j].LeagueID
+	})
+	return leagues, nil
+}
+
+// leagueMemberItems returns every league membership, sorted by leagueId then userId. Callers must hold m.mu
+func (m *MemoryStore) leagueMemberItems() ([]*LeagueMember, error) {
+	members := []*LeagueMember{}
+	for _, item := range m.leagueMembers {
+		var member LeagueMember
+		if err := attributevalue.UnmarshalMap(item, &member); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal league member: %w", err)
+		}
+		members = append(members, &member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].LeagueID != members[j].LeagueID {
+			return members[i].LeagueID < members[j].LeagueID
+		}
+		return members[i].UserId < members[j].UserId
+	})
+	return members, nil
+}
+
+// CreateLeague creates a new league
+// Returns an error "league already exists" if the leagueId or invite code is taken
+func (m *MemoryStore) CreateLeague(ctx context.Context, league *League) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	leagues, err := m.leagueItems()
+	if err != nil {
+		return err
+	}
+	for _, existing := range leagues {
+		if existing.LeagueID == league.LeagueID || existing.InviteCode == league.InviteCode {
+			return fmt.Errorf("league already exists")
+		}
+	}
+
+	// Set timestamp
+	league.Created = time.Now()
+
+	item, err := attributevalue.MarshalMap(league)
+	if err != nil {
+		return fmt.Errorf("failed to marshal league: %w", err)
+	}
+	m.leagues[league.LeagueID] = item
+
+	return nil
+}
+
+// GetLeague retrieves a league by leagueId
+func (m *MemoryStore) GetLeague(ctx context.Context, leagueId string) (*League, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.leagues[leagueId]
+	if !ok {
+		return nil, nil // Not found
+	}
+
+	var league League
+	if err := attributevalue.UnmarshalMap(item, &league); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal league: %w", err)
+	}
+
+	return &league, nil
+}
+
+// GetLeagueByInviteCode retrieves a league by its invite code
+func (m *MemoryStore) GetLeagueByInviteCode(ctx context.Context, inviteCode string) (*League, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	leagues, err := m.leagueItems()
+	if err != nil {
+		return nil, err
+	}
+	for _, league := range leagues {
+		if league.InviteCode == inviteCode {
+			return league, nil
+		}
+	}
+
+	return nil, nil // Not found
+}
+
+// AddLeagueMember adds a player to a league
+// Returns an error "league member already exists" if the player is already a member
+func (m *MemoryStore) AddLeagueMember(ctx context.Context, member *LeagueMember) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := member.LeagueID + "|" + member.UserId
+	if _, ok := m.leagueMembers[key]; ok {
+		return fmt.Errorf("league member already exists")
+	}
+
+	// Set timestamp
+	member.Joined = time.Now()
+
+	item, err := attributevalue.MarshalMap(member)
+	if err != nil {
+		return fmt.Errorf("failed to marshal league member: %w", err)
+	}
+	m.leagueMembers[key] = item
+
+	return nil
+}
+
+// GetLeagueMembers retrieves every member of a league, sorted by userId
+func (m *MemoryStore) GetLeagueMembers(ctx context.Context, leagueId string) ([]*LeagueMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	all, err := m.leagueMemberItems()
+	if err != nil {
+		return nil, err
+	}
+
+	members := []*LeagueMember{}
+	for _, member := range all {
+		if member.LeagueID == leagueId {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// GetUserLeagues retrieves every league a player belongs to, sorted by leagueId
+func (m *MemoryStore) GetUserLeagues(ctx context.Context, userId string) ([]*League, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members, err := m.leagueMemberItems()
+	if err != nil {
+		return nil, err
+	}
+
+	leagues := []*League{}
+	for _, member := range members {
+		if member.UserId != userId {
+			continue
+		}
+		item, ok := m.leagues[member.LeagueID]
+		if !ok {
+			continue
+		}
+		var league League
+		if err := attributevalue.UnmarshalMap(item, &league); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal league: %w", err)
+		}
+		leagues = append(leagues, &league)
+	}
+	return leagues, nil
+}
+
+// memoryRoundEditKey returns the map key for a round edit
+func memoryRoundEditKey(edit *RoundEdit) string {
+	return edit.SportPlayDate + "|" + edit.EditKey
+}
+
+// roundEditItem marshals an edit whose keys are set, returning an error "round edit already exists" if an edit
+// was recorded for the round at the same instant. Callers must hold m.mu
+func (m *MemoryStore) roundEditItem(edit *RoundEdit) (memoryItem, error) {
+	if _, ok := m.roundEdits[memoryRoundEditKey(edit)]; ok {
+		return nil, fmt.Errorf("round edit already exists")
+	}
+
+	item, err := attributevalue.MarshalMap(edit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal round edit: %w", err)
+	}
+	return item, nil
+}
+
+// CreateRoundEdit saves the audit record of an edit to a round
+// Returns an error "round edit already exists" if an edit was recorded for the round at the same instant
+func (m *MemoryStore) CreateRoundEdit(ctx context.Context, edit *RoundEdit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	setRoundEditKeys(edit)
+	item, err := m.roundEditItem(edit)
+	if err != nil {
+		return err
+	}
+	m.roundEdits[memoryRoundEditKey(edit)] = item
+
+	return nil
+}
+
+// GetRoundEdits retrieves every edit recorded for a round, oldest first
+func (m *MemoryStore) GetRoundEdits(ctx context.Context, sport, playDate string) ([]*RoundEdit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefix := sportPlayDateKey(sport, playDate) + "|"
+	edits := []*RoundEdit{}
+	for key, item := range m.roundEdits {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		var edit RoundEdit
+		if err := attributevalue.UnmarshalMap(item, &edit); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal round edit: %w", err)
+		}
+		edits = append(edits, &edit)
+	}
+
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].EditKey < edits[j].EditKey
+	})
+	return edits, nil
+}
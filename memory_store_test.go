@@ -0,0 +1,11 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	runStoreTests(t, func(t *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
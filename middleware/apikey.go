@@ -5,10 +5,27 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// namedAPIKeys parses ADMIN_API_KEYS, a comma-separated list of name:key pairs, into a map of key to name
+// Entries without both a name and a key are skipped
+func namedAPIKeys(value string) map[string]string {
+	keys := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		name, key, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		name = strings.TrimSpace(name)
+		key = strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			continue
+		}
+		keys[key] = name
+	}
+	return keys
+}
+
 func APIKeyMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		apiKey := c.GetHeader("X-API-Key")
@@ -19,22 +36,28 @@ func APIKeyMiddleware() gin.HandlerFunc {
 		}
 
 		// In production, check against database or secure store
+		// ADMIN_API_KEY is shared by every admin; ADMIN_API_KEYS gives each admin their own key
 		validKey := os.Getenv("ADMIN_API_KEY")
-		if validKey == "" {
+		namedKeys := namedAPIKeys(os.Getenv("ADMIN_API_KEYS"))
+		if validKey == "" && len(namedKeys) == 0 {
 			fmt.Println("Warning: ADMIN_API_KEY environment variable is not set")
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Server configuration error"})
 			c.Abort()
 			return
 		}
 
-		if apiKey != validKey {
+		name, named := namedKeys[apiKey]
+		if !named && (validKey == "" || apiKey != validKey) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
 			c.Abort()
 			return
 		}
 
-		// Mark as admin access
+		// Mark as admin access, and record which admin it is when the key is named
 		c.Set("isAdmin", true)
+		if named {
+			c.Set("adminName", name)
+		}
 		c.Next()
 	}
 }
@@ -18,20 +18,28 @@ func init() {
 func TestAPIKeyMiddleware(t *testing.T) {
 	// Save original env var and restore after test
 	originalKey := os.Getenv("ADMIN_API_KEY")
+	originalNamedKeys := os.Getenv("ADMIN_API_KEYS")
 	defer func() {
 		if originalKey != "" {
 			os.Setenv("ADMIN_API_KEY", originalKey)
 		} else {
 			os.Unsetenv("ADMIN_API_KEY")
 		}
+		if originalNamedKeys != "" {
+			os.Setenv("ADMIN_API_KEYS", originalNamedKeys)
+		} else {
+			os.Unsetenv("ADMIN_API_KEYS")
+		}
 	}()
 
 	tests := []struct {
 		name           string
 		envKeyValue    string
+		envNamedKeys   string
 		headerKey      string
 		expectedStatus int
 		expectedError  string
+		expectedAdmin  string
 		shouldAbort    bool
 	}{
 		{
@@ -65,6 +73,31 @@ func TestAPIKeyMiddleware(t *testing.T) {
 			expectedError:  "Server configuration error",
 			shouldAbort:    true,
 		},
+		{
+			name:           "named API key",
+			envKeyValue:    "test-valid-key",
+			envNamedKeys:   "alice:alice-key, bob:bob-key",
+			headerKey:      "bob-key",
+			expectedStatus: http.StatusOK,
+			expectedAdmin:  "bob",
+			shouldAbort:    false,
+		},
+		{
+			name:           "named API keys without a shared key",
+			envNamedKeys:   "alice:alice-key",
+			headerKey:      "alice-key",
+			expectedStatus: http.StatusOK,
+			expectedAdmin:  "alice",
+			shouldAbort:    false,
+		},
+		{
+			name:           "unknown key with only named keys",
+			envNamedKeys:   "alice:alice-key,broken-entry",
+			headerKey:      "broken-entry",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Invalid API key",
+			shouldAbort:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,6 +108,11 @@ func TestAPIKeyMiddleware(t *testing.T) {
 			} else {
 				os.Unsetenv("ADMIN_API_KEY")
 			}
+			if tt.envNamedKeys != "" {
+				os.Setenv("ADMIN_API_KEYS", tt.envNamedKeys)
+			} else {
+				os.Unsetenv("ADMIN_API_KEYS")
+			}
 
 			// Create test context
 			w := httptest.NewRecorder()
@@ -99,6 +137,11 @@ func TestAPIKeyMiddleware(t *testing.T) {
 			if tt.shouldAbort && !c.IsAborted() {
 				t.Errorf("Expected request to be aborted, but it wasn't")
 			}
+
+			// Check which admin the key belongs to
+			if admin := c.GetString("adminName"); admin != tt.expectedAdmin {
+				t.Errorf("Expected admin name %q, got %q", tt.expectedAdmin, admin)
+			}
 		})
 	}
 }
@@ -15,13 +15,16 @@ type Round struct {
 }
 
 // RoundSummary contains minimal round information for list views
+// Difficulty is computed from the round's stats (see roundDifficulty) and omitted until enough people have played
 type RoundSummary struct {
-	RoundID  string `json:"roundId" dynamodbav:"roundId"`
-	Sport    string `json:"sport" dynamodbav:"sport"`
-	PlayDate string `json:"playDate" dynamodbav:"playDate"`
+	RoundID    string   `json:"roundId" dynamodbav:"roundId"`
+	Sport      string   `json:"sport" dynamodbav:"sport"`
+	PlayDate   string   `json:"playDate" dynamodbav:"playDate"`
+	Difficulty *float64 `json:"difficulty,omitempty" dynamodbav:"-"`
 }
 
 // Player represents a player entity with comprehensive details
+// Each tile's content is held in one of the fields; tileRegistry maps tile keys to fields
 type Player struct {
 	Sport                string `json:"sport" dynamodbav:"sport"`
 	SportsReferenceURL   string `json:"sportsReferenceURL" dynamodbav:"sportsReferenceURL"`
@@ -40,67 +43,163 @@ type Player struct {
 }
 
 // Stats represents common statistics fields shared across different stat types
+// CorrectCount, TotalCorrectScore, TotalTileFlips and the trackers are raw counters that are only ever incremented
+// The percentages, averages and most/least common tiles are derived from them (see computeDerivedStats)
 type Stats struct {
-	TotalPlays                 int             `json:"totalPlays" dynamodbav:"totalPlays"`
-	PercentageCorrect          float64         `json:"percentageCorrect" dynamodbav:"percentageCorrect"`
-	HighestScore               int             `json:"highestScore" dynamodbav:"highestScore"`
-	AverageCorrectScore        float64         `json:"averageCorrectScore" dynamodbav:"averageCorrectScore"`
-	AverageNumberOfTileFlips   float64         `json:"averageNumberOfTileFlips" dynamodbav:"averageNumberOfTileFlips"`
-	MostCommonFirstTileFlipped string          `json:"mostCommonFirstTileFlipped" dynamodbav:"mostCommonFirstTileFlipped"`
-	MostCommonLastTileFlipped  string          `json:"mostCommonLastTileFlipped" dynamodbav:"mostCommonLastTileFlipped"`
-	MostCommonTileFlipped      string          `json:"mostCommonTileFlipped" dynamodbav:"mostCommonTileFlipped"`
-	LeastCommonTileFlipped     string          `json:"leastCommonTileFlipped" dynamodbav:"leastCommonTileFlipped"`
-	FirstTileFlippedTracker    TileFlipTracker `json:"firstTileFlippedTracker" dynamodbav:"firstTileFlippedTracker"`
-	LastTileFlippedTracker     TileFlipTracker `json:"lastTileFlippedTracker" dynamodbav:"lastTileFlippedTracker"`
-	MostTileFlippedTracker     TileFlipTracker `json:"mostTileFlippedTracker" dynamodbav:"mostTileFlippedTracker"`
-}
-
-// TileFlipTracker tracks tile flip counts
-type TileFlipTracker struct {
-	Bio                  int `json:"bio" dynamodbav:"bio"`
-	PlayerInformation    int `json:"playerInformation" dynamodbav:"playerInformation"`
-	DraftInformation     int `json:"draftInformation" dynamodbav:"draftInformation"`
-	TeamsPlayedOn        int `json:"teamsPlayedOn" dynamodbav:"teamsPlayedOn"`
-	JerseyNumbers        int `json:"jerseyNumbers" dynamodbav:"jerseyNumbers"`
-	CareerStats          int `json:"careerStats" dynamodbav:"careerStats"`
-	PersonalAchievements int `json:"personalAchievements" dynamodbav:"personalAchievements"`
-	Photo                int `json:"photo" dynamodbav:"photo"`
-	YearsActive          int `json:"yearsActive" dynamodbav:"yearsActive"`
-	Initials             int `json:"initials" dynamodbav:"initials"`
-	Nicknames            int `json:"nicknames" dynamodbav:"nicknames"`
+	TotalPlays                 int     `json:"totalPlays" dynamodbav:"totalPlays"`
+	CorrectCount               int     `json:"correctCount" dynamodbav:"correctCount"`
+	TotalCorrectScore          int     `json:"totalCorrectScore" dynamodbav:"totalCorrectScore"`
+	TotalTileFlips             int     `json:"totalTileFlips" dynamodbav:"totalTileFlips"`
+	PercentageCorrect          float64 `json:"percentageCorrect" dynamodbav:"percentageCorrect"`
+	HighestScore               int     `json:"highestScore" dynamodbav:"highestScore"`
+	AverageCorrectScore        float64 `json:"averageCorrectScore" dynamodbav:"averageCorrectScore"`
+	AverageNumberOfTileFlips   float64 `json:"averageNumberOfTileFlips" dynamodbav:"averageNumberOfTileFlips"`
+	MostCommonFirstTileFlipped string  `json:"mostCommonFirstTileFlipped" dynamodbav:"mostCommonFirstTileFlipped"`
+	MostCommonLastTileFlipped  string  `json:"mostCommonLastTileFlipped" dynamodbav:"mostCommonLastTileFlipped"`
+	MostCommonTileFlipped      string  `json:"mostCommonTileFlipped" dynamodbav:"mostCommonTileFlipped"`
+	LeastCommonTileFlipped     string  `json:"leastCommonTileFlipped" dynamodbav:"leastCommonTileFlipped"`
+
+	// Tile flip counts keyed by tile name (see tileRegistry)
+	// Every tile is present once computeDerivedStats has run
+	FirstTileFlippedTracker map[string]int `json:"firstTileFlippedTracker" dynamodbav:"firstTileFlippedTracker,omitempty"`
+	LastTileFlippedTracker  map[string]int `json:"lastTileFlippedTracker" dynamodbav:"lastTileFlippedTracker,omitempty"`
+	MostTileFlippedTracker  map[string]int `json:"mostTileFlippedTracker" dynamodbav:"mostTileFlippedTracker,omitempty"`
+
+	// Tile counters split by outcome, for per-tile solve rates (see GetTileAnalytics)
+	// The results and score trackers count each tile once per result; incorrect results add 0 to the score tracker
+	LastTileFlippedCorrectTracker   map[string]int `json:"lastTileFlippedCorrectTracker" dynamodbav:"lastTileFlippedCorrectTracker,omitempty"`
+	LastTileFlippedIncorrectTracker map[string]int `json:"lastTileFlippedIncorrectTracker" dynamodbav:"lastTileFlippedIncorrectTracker,omitempty"`
+	TileFlippedResultsTracker       map[string]int `json:"tileFlippedResultsTracker" dynamodbav:"tileFlippedResultsTracker,omitempty"`
+	TileFlippedScoreTracker         map[string]int `json:"tileFlippedScoreTracker" dynamodbav:"tileFlippedScoreTracker,omitempty"`
+
+	// Distributions of results, keyed by bucket (see scoreBucket, tilesFlippedBucket and incorrectGuessesBucket)
+	// Every bucket is present once computeDerivedStats has run, so they can be charted without gaps
+	ScoreDistribution            map[string]int `json:"scoreDistribution" dynamodbav:"scoreDistribution,omitempty"`
+	TilesFlippedDistribution     map[string]int `json:"tilesFlippedDistribution" dynamodbav:"tilesFlippedDistribution,omitempty"`
+	IncorrectGuessesDistribution map[string]int `json:"incorrectGuessesDistribution" dynamodbav:"incorrectGuessesDistribution,omitempty"`
 }
 
 // RoundStats represents statistics for a specific round
+// Difficulty is derived on read like the averages, and omitted until enough people have played (see roundDifficulty)
+// ArchivePlays counts results submitted after the round's playDate, which are left out of the other stats
 type RoundStats struct {
-	PlayDate string `json:"playDate" dynamodbav:"playDate"`
-	Name     string `json:"name" dynamodbav:"name"`
-	Sport    string `json:"sport" dynamodbav:"sport"`
-	Stats    `json:",inline" dynamodbav:",inline"`
+	PlayDate     string   `json:"playDate" dynamodbav:"playDate"`
+	Name         string   `json:"name" dynamodbav:"name"`
+	Sport        string   `json:"sport" dynamodbav:"sport"`
+	Difficulty   *float64 `json:"difficulty,omitempty" dynamodbav:"-"`
+	ArchivePlays int      `json:"archivePlays" dynamodbav:"archivePlays"`
+	Stats        `json:",inline" dynamodbav:",inline"`
 }
 
 // Result represents a game result submission
+// Flagged is set by the server when the score reported by the client doesn't match the scoring engine
+// Archive is set by the server when the round's playDate is before the player's today, making it an archive play
+// PreRelease is set by the server when a playtester or admin plays a round before it's released in any timezone
 type Result struct {
 	Score            int      `json:"score" dynamodbav:"score"`
 	IsCorrect        bool     `json:"isCorrect" dynamodbav:"isCorrect"`
 	FlippedTiles     []string `json:"flippedTiles" dynamodbav:"flippedTiles"`
 	IncorrectGuesses int      `json:"incorrectGuesses" dynamodbav:"incorrectGuesses"`
+	Flagged          bool     `json:"flagged,omitempty" dynamodbav:"flagged,omitempty"`
+	Archive          bool     `json:"archive,omitempty" dynamodbav:"archive,omitempty"`
+	PreRelease       bool     `json:"preRelease,omitempty" dynamodbav:"preRelease,omitempty"`
+}
+
+// GuessRequest represents a player's guess at the answer for a round
+// SessionID is optional. When provided, the guess is recorded against that game session
+type GuessRequest struct {
+	Guess     string `json:"guess"`
+	Reveal    bool   `json:"reveal"`
+	SessionID string `json:"sessionId"`
+}
+
+// GuessResponse represents the outcome of a guess
+// Player is only populated once the round is solved or revealed
+type GuessResponse struct {
+	IsCorrect bool    `json:"isCorrect"`
+	Revealed  bool    `json:"revealed"`
+	Player    *Player `json:"player,omitempty"`
+}
+
+// GameSession tracks a single play-through of a round on the server
+// Tiles are revealed one at a time through the session so results can be computed server-side
+type GameSession struct {
+	SessionID        string    `json:"sessionId" dynamodbav:"sessionId"`
+	Sport            string    `json:"sport" dynamodbav:"sport"`
+	PlayDate         string    `json:"playDate" dynamodbav:"playDate"`
+	UserId           string    `json:"userId,omitempty" dynamodbav:"userId,omitempty"`
+	FlippedTiles     []string  `json:"flippedTiles" dynamodbav:"flippedTiles"`
+	IncorrectGuesses int       `json:"incorrectGuesses" dynamodbav:"incorrectGuesses"`
+	IsCorrect        bool      `json:"isCorrect" dynamodbav:"isCorrect"`
+	Revealed         bool      `json:"revealed" dynamodbav:"revealed"`
+	Submitted        bool      `json:"submitted" dynamodbav:"submitted"`
+	Result           *Result   `json:"result,omitempty" dynamodbav:"result,omitempty"` // set once submitted
+	Created          time.Time `json:"created" dynamodbav:"created"`
+	LastUpdated      time.Time `json:"lastUpdated" dynamodbav:"lastUpdated"`
+	ExpiresAt        int64     `json:"-" dynamodbav:"expiresAt"` // DynamoDB TTL attribute (unix seconds)
+}
+
+// Submission records a result that has been counted toward the aggregates
+// One submission is stored per (player, sport, playDate) and per Idempotency-Key, so repeats can be replayed
+// Status is the last step of SubmitResults that was applied (see SubmissionStatusClaimed). Submissions stored
+// before steps were tracked have no status and were counted in full
+type Submission struct {
+	SubmissionKey string    `json:"submissionKey" dynamodbav:"submissionKey"`
+	Sport         string    `json:"sport" dynamodbav:"sport"`
+	PlayDate      string    `json:"playDate" dynamodbav:"playDate"`
+	SessionID     string    `json:"sessionId" dynamodbav:"sessionId"`
+	Result        Result    `json:"result" dynamodbav:"result"`
+	Status        string    `json:"status,omitempty" dynamodbav:"status,omitempty"`
+	Created       time.Time `json:"created" dynamodbav:"created"`
+}
+
+// TileResponse represents the content of a single flipped tile
+type TileResponse struct {
+	SessionID string `json:"sessionId"`
+	Tile      string `json:"tile"`
+	Value     string `json:"value"`
 }
 
 // UserStats represents comprehensive statistics for a user
+// Version is incremented on every write and used for optimistic locking (see DB.UpdateUserStats)
+// Daily streaks count consecutive real-life days played (in the user's timezone) and win streaks count
+// consecutive correct rounds. The Max fields hold the longest streak ever reached
 type UserStats struct {
 	UserId             string           `json:"userId" dynamodbav:"userId"`
+	Version            int              `json:"version" dynamodbav:"version"`
 	UserName           string           `json:"userName" dynamodbav:"userName"`
 	UserCreated        time.Time        `json:"userCreated" dynamodbav:"userCreated"`
 	CurrentDailyStreak int              `json:"currentDailyStreak" dynamodbav:"currentDailyStreak"`
+	MaxDailyStreak     int              `json:"maxDailyStreak" dynamodbav:"maxDailyStreak"`
 	LastDayPlayed      string           `json:"lastDayPlayed" dynamodbav:"lastDayPlayed"`
+	CurrentWinStreak   int              `json:"currentWinStreak" dynamodbav:"currentWinStreak"`
+	MaxWinStreak       int              `json:"maxWinStreak" dynamodbav:"maxWinStreak"`
+	StreakFreezes      int              `json:"streakFreezes" dynamodbav:"streakFreezes"`
+	StreakFreezeLog    []StreakFreeze   `json:"streakFreezeLog,omitempty" dynamodbav:"streakFreezeLog,omitempty"`
 	Sports             []UserSportStats `json:"sports" dynamodbav:"sports"`
 }
 
+// StreakFreeze records a streak freeze spent to cover a missed day
+// Streak is the daily streak the freeze saved, before the play on UsedOn extended it
+type StreakFreeze struct {
+	MissedDate string `json:"missedDate" dynamodbav:"missedDate"`
+	UsedOn     string `json:"usedOn" dynamodbav:"usedOn"`
+	Streak     int    `json:"streak" dynamodbav:"streak"`
+}
+
 // SportStats represents statistics for a specific sport for all users
+// Streaks here only count rounds of this sport; see UserStats for what they measure
+// History is only populated on items saved before play history moved to its own table (see PlayHistoryEntry)
 type UserSportStats struct {
-	Sport   string         `json:"sport" dynamodbav:"sport"`
-	Stats   Stats          `json:"stats" dynamodbav:"stats"`
-	History []RoundHistory `json:"history" dynamodbav:"history"`
+	Sport              string         `json:"sport" dynamodbav:"sport"`
+	Stats              Stats          `json:"stats" dynamodbav:"stats"`
+	CurrentDailyStreak int            `json:"currentDailyStreak" dynamodbav:"currentDailyStreak"`
+	MaxDailyStreak     int            `json:"maxDailyStreak" dynamodbav:"maxDailyStreak"`
+	LastDayPlayed      string         `json:"lastDayPlayed" dynamodbav:"lastDayPlayed"`
+	CurrentWinStreak   int            `json:"currentWinStreak" dynamodbav:"currentWinStreak"`
+	MaxWinStreak       int            `json:"maxWinStreak" dynamodbav:"maxWinStreak"`
+	ArchivePlays       int            `json:"archivePlays" dynamodbav:"archivePlays"`
+	History            []RoundHistory `json:"history,omitempty" dynamodbav:"history,omitempty"`
 }
 
 // RoundHistory represents the results of past rounds played
@@ -109,6 +208,187 @@ type RoundHistory struct {
 	Result
 }
 
+// PlayHistoryEntry represents a single round played by a user, stored in the play history table
+// Keyed by userId with "<sport>#<playDate>" as the sort key
+type PlayHistoryEntry struct {
+	UserId        string    `json:"userId" dynamodbav:"userId"`
+	SportPlayDate string    `json:"-" dynamodbav:"sportPlayDate"`
+	Sport         string    `json:"sport" dynamodbav:"sport"`
+	PlayDate      string    `json:"playDate" dynamodbav:"playDate"`
+	Created       time.Time `json:"created" dynamodbav:"created"`
+	Result
+}
+
+// PlayHistoryPage is one page of a user's play history
+// NextCursor is empty on the last page
+type PlayHistoryPage struct {
+	Items      []*PlayHistoryEntry `json:"items"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+}
+
+// LeaderboardEntry is a player's standing on one leaderboard
+// Keyed by board ("<sport>#<playDate>" or "<sport>#all") with userId as the sort key
+// Rank isn't stored; it's computed when the leaderboard is read
+type LeaderboardEntry struct {
+	Board        string    `json:"-" dynamodbav:"board"`
+	UserId       string    `json:"-" dynamodbav:"userId"`
+	UserName     string    `json:"userName" dynamodbav:"userName"`
+	Rank         int       `json:"rank" dynamodbav:"-"`
+	Score        int       `json:"score" dynamodbav:"score"`
+	RoundsPlayed int       `json:"roundsPlayed" dynamodbav:"roundsPlayed"`
+	CorrectCount int       `json:"correctCount" dynamodbav:"correctCount"`
+	HighestScore int       `json:"highestScore" dynamodbav:"highestScore"`
+	LastUpdated  time.Time `json:"lastUpdated" dynamodbav:"lastUpdated"`
+	ExpiresAt    int64     `json:"-" dynamodbav:"expiresAt,omitempty"` // DynamoDB TTL attribute (unix seconds), 0 never expires
+}
+
+// LeaderboardBoardRef is a board a result is recorded on, and when its entries expire (0 for never)
+type LeaderboardBoardRef struct {
+	Board     string
+	ExpiresAt int64
+}
+
+// Leaderboard is one page of a ranked leaderboard
+// Me is the requesting user's own standing, included even when they're not on this page
+type Leaderboard struct {
+	Sport      string              `json:"sport"`
+	Window     string              `json:"window"`
+	StartDate  string              `json:"startDate,omitempty"`
+	EndDate    string              `json:"endDate,omitempty"`
+	Items      []*LeaderboardEntry `json:"items"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+	Me         *LeaderboardEntry   `json:"me,omitempty"`
+}
+
+// League is a private group of players who share a leaderboard
+// Players join with the invite code, which is unique across leagues
+type League struct {
+	LeagueID   string    `json:"leagueId" dynamodbav:"leagueId"`
+	Name       string    `json:"name" dynamodbav:"name"`
+	InviteCode string    `json:"inviteCode" dynamodbav:"inviteCode"`
+	OwnerId    string    `json:"ownerId" dynamodbav:"ownerId"`
+	Created    time.Time `json:"created" dynamodbav:"created"`
+}
+
+// LeagueMember is a player's membership in a league
+// Keyed by leagueId with userId as the sort key
+type LeagueMember struct {
+	LeagueID string    `json:"leagueId" dynamodbav:"leagueId"`
+	UserId   string    `json:"userId" dynamodbav:"userId"`
+	UserName string    `json:"userName" dynamodbav:"userName"`
+	Joined   time.Time `json:"joined" dynamodbav:"joined"`
+}
+
+// LeagueLeaderboard ranks every member of a league by their results in a sport over a leaderboard window
+// Me is the requesting member's own standing
+type LeagueLeaderboard struct {
+	LeagueID  string              `json:"leagueId"`
+	Name      string              `json:"name"`
+	Sport     string              `json:"sport"`
+	Window    string              `json:"window"`
+	StartDate string              `json:"startDate,omitempty"`
+	EndDate   string              `json:"endDate,omitempty"`
+	Items     []*LeaderboardEntry `json:"items"`
+	Me        *LeaderboardEntry   `json:"me,omitempty"`
+}
+
+// TileAnalytics aggregates per-tile counters across every round of a sport in a date range
+// MostCommonLastTileBeforeCorrect is the tile most often flipped right before a correct guess
+type TileAnalytics struct {
+	Sport                           string               `json:"sport"`
+	StartDate                       string               `json:"startDate"`
+	EndDate                         string               `json:"endDate"`
+	Rounds                          int                  `json:"rounds"`
+	TotalPlays                      int                  `json:"totalPlays"`
+	CorrectCount                    int                  `json:"correctCount"`
+	MostCommonLastTileBeforeCorrect string               `json:"mostCommonLastTileBeforeCorrect"`
+	Tiles                           []*TileAnalyticsItem `json:"tiles"`
+}
+
+// TileAnalyticsItem is one tile's counters and rates, in tileRegistry order
+// LastFlippedSolveRate is the percentage of results ending on this tile that were correct
+// AverageScore is the average score of results that flipped this tile, counting incorrect results as 0
+type TileAnalyticsItem struct {
+	Tile                 string  `json:"tile"`
+	Flips                int     `json:"flips"`
+	ResultsFlipped       int     `json:"resultsFlipped"`
+	FirstFlipped         int     `json:"firstFlipped"`
+	LastFlippedCorrect   int     `json:"lastFlippedCorrect"`
+	LastFlippedIncorrect int     `json:"lastFlippedIncorrect"`
+	LastFlippedSolveRate float64 `json:"lastFlippedSolveRate"`
+	AverageScore         float64 `json:"averageScore"`
+}
+
+// ScheduleGaps lists the playDates in a range that have no round, per sport
+type ScheduleGaps struct {
+	StartDate    string              `json:"startDate"`
+	EndDate      string              `json:"endDate"`
+	TotalMissing int                 `json:"totalMissing"`
+	Sports       []SportScheduleGaps `json:"sports"`
+}
+
+// SportScheduleGaps lists the playDates without a round for one sport, earliest first
+type SportScheduleGaps struct {
+	Sport        string   `json:"sport"`
+	MissingDates []string `json:"missingDates"`
+}
+
+// ScheduleFillReport is the output of the fill-schedule command: the gaps found and what was done about them
+type ScheduleFillReport struct {
+	Gaps     *ScheduleGaps         `json:"gaps"`
+	Results  []RoundManifestResult `json:"results,omitempty"`
+	Unfilled int                   `json:"unfilled"` // playDates still without a round
+}
+
+// PlayerCandidate is one of the players matched by an ambiguous name search
+// Passing its SportsReferenceURL to POST /v1/round picks that player
+type PlayerCandidate struct {
+	Name               string `json:"name"`
+	YearsActive        string `json:"yearsActive,omitempty"`
+	Position           string `json:"position,omitempty"`
+	SportsReferenceURL string `json:"sportsReferenceURL"`
+}
+
+// RoundPreview is the round POST /v1/round would create in a dry run, with the problems found in the scraped clues
+type RoundPreview struct {
+	Round    *Round          `json:"round"`
+	Warnings []ScrapeWarning `json:"warnings"`
+}
+
+// ScrapeWarning flags a tile of a scraped player that should be reviewed before the round is saved
+type ScrapeWarning struct {
+	Tile    string `json:"tile"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RoundPatch is the body of PATCH /v1/round. Only the fields present are changed
+// Player maps tile keys (see tileRegistry) to their new content
+type RoundPatch struct {
+	Theme  *string           `json:"theme"`
+	Player map[string]string `json:"player"`
+}
+
+// RoundEdit is the audit record of an admin edit to a round
+// Keyed by "<sport>#<playDate>" with EditKey (the edit time, see RoundEditKeyFormat) as the sort key
+type RoundEdit struct {
+	SportPlayDate string        `json:"-" dynamodbav:"sportPlayDate"`
+	EditKey       string        `json:"-" dynamodbav:"editKey"`
+	Sport         string        `json:"sport" dynamodbav:"sport"`
+	PlayDate      string        `json:"playDate" dynamodbav:"playDate"`
+	Editor        string        `json:"editor" dynamodbav:"editor"`
+	Edited        time.Time     `json:"edited" dynamodbav:"edited"`
+	Changes       []RoundChange `json:"changes" dynamodbav:"changes"`
+}
+
+// RoundChange is one field changed by a RoundEdit
+// Field is "theme" or "player.<tile>"
+type RoundChange struct {
+	Field    string `json:"field" dynamodbav:"field"`
+	OldValue string `json:"oldValue" dynamodbav:"oldValue"`
+	NewValue string `json:"newValue" dynamodbav:"newValue"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error     string                 `json:"error"`
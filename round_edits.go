@@ -0,0 +1,193 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PatchRound handles PATCH /v1/round
+// Edits a round's theme and individual tile fields in place, keeping its stats, and records who changed what
+// The editor is the admin whose named API key (ADMIN_API_KEYS) authenticated the request
+func (s *Server) PatchRound(c *gin.Context) {
+	sport := c.Query(QueryParamSport)
+	playDate := c.Query(QueryParamPlayDate)
+	if sport == "" || playDate == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Sport and playDate parameters are required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	editor := c.GetString(ConstantAdminName)
+	if editor == "" {
+		c.JSON(http.StatusForbidden, gin.H{
+			JSONFieldError:     StatusForbidden,
+			JSONFieldMessage:   "Rounds can only be edited with a named admin API key, so the edit can be recorded against it",
+			JSONFieldCode:      ErrorNamedAdminKeyRequired,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	var patch RoundPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid request body: " + err.Error(),
+			JSONFieldCode:      ErrorInvalidRequestBody,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if patch.Theme == nil && len(patch.Player) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Nothing to change: provide a theme or player tile fields",
+			JSONFieldCode:      ErrorMissingRequiredField,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	for tile := range patch.Player {
+		if _, ok := lookupTile(tile); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				JSONFieldError:     StatusBadRequest,
+				JSONFieldMessage:   "Invalid tile '" + tile + "'. Valid tiles: " + strings.Join(AllTiles(), ", "),
+				JSONFieldCode:      ErrorInvalidTile,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	// The store records the edit in the same write as the change
+	previous, err := s.db.PatchRound(c.Request.Context(), sport, playDate, &patch, editor)
+	if err != nil {
+		if err.Error() == "round not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				JSONFieldError:     StatusNotFound,
+				JSONFieldMessage:   "Round not found for sport '" + sport + "' on playDate '" + playDate + "'",
+				JSONFieldCode:      ErrorRoundNotFound,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+		if err.Error() == "round edit conflict" {
+			c.JSON(http.StatusConflict, gin.H{
+				JSONFieldError:     StatusConflict,
+				JSONFieldMessage:   "The round kept changing while it was being edited, try again",
+				JSONFieldCode:      ErrorRoundEditConflict,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to update round: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	round, err := s.db.GetRound(c.Request.Context(), sport, playDate)
+	if err != nil || round == nil {
+		// The edit is saved, so fall back to applying it to the round as it was
+		round = previous
+		applyRoundPatch(round, &patch)
+	}
+
+	c.JSON(http.StatusOK, round)
+}
+
+// GetRoundEdits handles GET /v1/round/edits - lists the audit trail of a round's edits, oldest first
+func (s *Server) GetRoundEdits(c *gin.Context) {
+	sport := c.Query(QueryParamSport)
+	playDate := c.Query(QueryParamPlayDate)
+	if sport == "" || playDate == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Sport and playDate parameters are required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	edits, err := s.db.GetRoundEdits(c.Request.Context(), sport, playDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to retrieve round edits: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, edits)
+}
+
+// applyRoundPatch sets the fields present in a patch on a round and leaves everything else, including stats, alone
+// Unrecognized tiles are ignored; PatchRound rejects them before they reach the store
+func applyRoundPatch(round *Round, patch *RoundPatch) {
+	if patch.Theme != nil {
+		round.Theme = *patch.Theme
+	}
+	for tileName, value := range patch.Player {
+		if tile, ok := lookupTile(tileName); ok {
+			*tile.Field(&round.Player) = value
+		}
+	}
+}
+
+// roundPatchChanges lists the fields a patch changes on a round, theme first and then tiles in registry order
+func roundPatchChanges(round *Round, patch *RoundPatch) []RoundChange {
+	var changes []RoundChange
+	if patch.Theme != nil && *patch.Theme != round.Theme {
+		changes = append(changes, RoundChange{Field: RoundEditFieldTheme, OldValue: round.Theme, NewValue: *patch.Theme})
+	}
+	for _, tile := range tileRegistry {
+		value, ok := patch.Player[tile.Key]
+		if !ok {
+			continue
+		}
+		if old := *tile.Field(&round.Player); value != old {
+			changes = append(changes, RoundChange{Field: RoundEditFieldPlayerPrefix + tile.Key, OldValue: old, NewValue: value})
+		}
+	}
+	return changes
+}
+
+// newRoundEdit builds the audit record of the fields a patch changes on a round, edited now
+// Fields set to their current value aren't changes, so it returns nil for a patch that changes nothing
+func newRoundEdit(round *Round, patch *RoundPatch, editor string) *RoundEdit {
+	changes := roundPatchChanges(round, patch)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	edit := &RoundEdit{Sport: round.Sport, PlayDate: round.PlayDate, Editor: editor, Changes: changes}
+	setRoundEditKeys(edit)
+	return edit
+}
+
+// setRoundEditKeys stamps an edit with the current time unless it already has one, and fills in its keys
+func setRoundEditKeys(edit *RoundEdit) {
+	if edit.Edited.IsZero() {
+		edit.Edited = time.Now()
+	}
+	edit.SportPlayDate = sportPlayDateKey(edit.Sport, edit.PlayDate)
+	edit.EditKey = roundEditKey(edit.Edited)
+}
+
+// roundEditKey returns the sort key of an edit made at the given time (see RoundEditKeyFormat)
+func roundEditKey(edited time.Time) string {
+	return edited.UTC().Format(RoundEditKeyFormat)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// performPatchRound sends a PATCH /v1/round request, authenticated with the editor's named admin API key when one is given
+func performPatchRound(server *Server, query, editor string, body interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPatch, "/v1/round?"+query, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if editor != "" {
+		c.Set(ConstantAdminName, editor)
+	}
+
+	server.PatchRound(c)
+	return w
+}
+
+func TestPatchRound(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+
+	err := server.db.CreateRound(ctx, &Round{
+		Sport:    SportBasketball,
+		PlayDate: "2026-02-10",
+		Theme:    "Legends",
+		Player:   Player{Name: "LeBron James", Bio: "Born in Akron", Nicknames: "King James"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	if err := server.db.ApplyRoundResult(ctx, SportBasketball, "2026-02-10", &Result{Score: 80, IsCorrect: true}); err != nil {
+		t.Fatalf("ApplyRoundResult() error = %v", err)
+	}
+
+	theme := "Champions"
+	query := "sport=basketball&playDate=2026-02-10"
+	w := performPatchRound(server, query, "alice", RoundPatch{
+		Theme:  &theme,
+		Player: map[string]string{TileBio: "Born in Akron, Ohio", TileNicknames: "King James"},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var round Round
+	if err := json.NewDecoder(w.Body).Decode(&round); err != nil {
+		t.Fatalf("failed to decode round: %v", err)
+	}
+	if round.Theme != "Champions" || round.Player.Bio != "Born in Akron, Ohio" || round.Player.Name != "LeBron James" || round.Stats.TotalPlays != 1 {
+		t.Errorf("patched round = %+v, want the new theme and bio with the name and stats kept", round)
+	}
+
+	// Setting a field to its current value changes nothing, so it isn't recorded
+	if w := performPatchRound(server, query, "bob", RoundPatch{Theme: &theme}); w.Code != http.StatusOK {
+		t.Fatalf("no-op patch status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	w = performRequest(server.GetRoundEdits, http.MethodGet, "/v1/round/edits?"+query, nil, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("edits status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var edits []RoundEdit
+	if err := json.NewDecoder(w.Body).Decode(&edits); err != nil {
+		t.Fatalf("failed to decode edits: %v", err)
+	}
+	if len(edits) != 1 {
+		t.Fatalf("got %d edits, want 1: %+v", len(edits), edits)
+	}
+	want := []RoundChange{
+		{Field: RoundEditFieldTheme, OldValue: "Legends", NewValue: "Champions"},
+		{Field: RoundEditFieldPlayerPrefix + TileBio, OldValue: "Born in Akron", NewValue: "Born in Akron, Ohio"},
+	}
+	if edits[0].Editor != "alice" || edits[0].Edited.IsZero() || !reflect.DeepEqual(edits[0].Changes, want) {
+		t.Errorf("edit = %+v, want alice's changes %+v", edits[0], want)
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		editor         string
+		body           interface{}
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "missing playDate",
+			query:          "sport=basketball",
+			editor:         "alice",
+			body:           RoundPatch{Theme: &theme},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   ErrorMissingRequiredParameter,
+		},
+		{
+			name:           "shared admin key",
+			query:          query,
+			body:           RoundPatch{Theme: &theme},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   ErrorNamedAdminKeyRequired,
+		},
+		{
+			name:           "empty patch",
+			query:          query,
+			editor:         "alice",
+			body:           RoundPatch{},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   ErrorMissingRequiredField,
+		},
+		{
+			name:           "unknown tile",
+			query:          query,
+			editor:         "alice",
+			body:           RoundPatch{Player: map[string]string{"name": "Michael Jordan"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   ErrorInvalidTile,
+		},
+		{
+			name:           "missing round",
+			query:          "sport=basketball&playDate=2026-02-11",
+			editor:         "alice",
+			body:           RoundPatch{Theme: &theme},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   ErrorRoundNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performPatchRound(server, tt.query, tt.editor, tt.body)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.expectedStatus, w.Body.String())
+			}
+			var response map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			if response[JSONFieldCode] != tt.expectedCode {
+				t.Errorf("code = %v, want %s", response[JSONFieldCode], tt.expectedCode)
+			}
+		})
+	}
+}
+
+func TestRoundPatchChanges(t *testing.T) {
+	round := &Round{Theme: "Legends", Player: Player{Bio: "Born in Akron", Photo: "old.jpg", Initials: "LJ"}}
+	theme := "Champions"
+	same := "Legends"
+
+	tests := []struct {
+		name     string
+		patch    RoundPatch
+		expected []RoundChange
+	}{
+		{
+			name:  "theme and tiles in registry order",
+			patch: RoundPatch{Theme: &theme, Player: map[string]string{TileInitials: "L.J.", TileBio: "Born in Akron, Ohio"}},
+			expected: []RoundChange{
+				{Field: RoundEditFieldTheme, OldValue: "Legends", NewValue: "Champions"},
+				{Field: RoundEditFieldPlayerPrefix + TileBio, OldValue: "Born in Akron", NewValue: "Born in Akron, Ohio"},
+				{Field: RoundEditFieldPlayerPrefix + TileInitials, OldValue: "LJ", NewValue: "L.J."},
+			},
+		},
+		{
+			name:     "clearing a tile",
+			patch:    RoundPatch{Player: map[string]string{TilePhoto: ""}},
+			expected: []RoundChange{{Field: RoundEditFieldPlayerPrefix + TilePhoto, OldValue: "old.jpg", NewValue: ""}},
+		},
+		{
+			name:  "unchanged values",
+			patch: RoundPatch{Theme: &same, Player: map[string]string{TileBio: "Born in Akron"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundPatchChanges(round, &tt.patch); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("roundPatchChanges() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyRoundPatch(t *testing.T) {
+	theme := "Champions"
+	round := &Round{Theme: "Legends", Player: Player{Name: "LeBron James", Bio: "Born in Akron", Nicknames: "King James"}}
+
+	applyRoundPatch(round, &RoundPatch{Theme: &theme, Player: map[string]string{TileNicknames: "", "unknown": "ignored"}})
+
+	expected := &Round{Theme: "Champions", Player: Player{Name: "LeBron James", Bio: "Born in Akron"}}
+	if !reflect.DeepEqual(round, expected) {
+		t.Errorf("applyRoundPatch() = %+v, want %+v", round, expected)
+	}
+}
+
+func TestRoundEditKey(t *testing.T) {
+	earlier := time.Date(2026, 2, 10, 9, 0, 0, 5, time.UTC)
+	later := earlier.Add(time.Nanosecond * 995)
+
+	if roundEditKey(earlier) != "2026-02-10T09:00:00.000000005Z" {
+		t.Errorf("roundEditKey() = %s", roundEditKey(earlier))
+	}
+	// Keys must sort in time order, which a trimmed fractional second wouldn't
+	if roundEditKey(earlier) >= roundEditKey(later) {
+		t.Errorf("roundEditKey() = %s does not sort before %s", roundEditKey(earlier), roundEditKey(later))
+	}
+	// The key is always in UTC
+	if got := roundEditKey(earlier.In(time.FixedZone("EST", -5*3600))); got != roundEditKey(earlier) {
+		t.Errorf("roundEditKey() in another zone = %s, want %s", got, roundEditKey(earlier))
+	}
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RoundManifestRow is one round to schedule: the player to scrape for a sport and playDate
+// A manifest is a JSON array of rows or a CSV file with a header naming the columns (see the JSON tags)
+type RoundManifestRow struct {
+	Sport              string `json:"sport"`
+	PlayDate           string `json:"playDate,omitempty"` // left out of schedule queues, which fill-schedule assigns dates from
+	Name               string `json:"name,omitempty"`
+	SportsReferenceURL string `json:"sportsReferenceURL,omitempty"`
+	Theme              string `json:"theme,omitempty"`
+}
+
+// RoundManifestResult reports what happened to one manifest row
+// Failed rows carry the scrapeError code and message, so they can be fixed and the manifest run again
+type RoundManifestResult struct {
+	Row        int    `json:"row,omitempty"` // 1-based position in the manifest, not counting a CSV header
+	Sport      string `json:"sport"`
+	PlayDate   string `json:"playDate"`
+	Status     string `json:"status"`
+	RoundID    string `json:"roundId,omitempty"`
+	PlayerName string `json:"playerName,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// roundImporter creates the rounds of a manifest one at a time, pausing between scrapes
+// resolve, scrape and sleep are swappable so imports can be tested without Sports Reference
+type roundImporter struct {
+	server  *Server
+	delay   time.Duration
+	resolve func(params *scrapeParams) (string, *scrapeError)
+	scrape  func(playerURL, hostname, sport string) (*Player, error)
+	sleep   func(d time.Duration)
+	scraped bool // whether anything has been scraped yet, so only the pauses between scrapes are waited
+}
+
+// newRoundImporter returns an importer that scrapes Sports Reference, waiting delay between players
+func newRoundImporter(server *Server, delay time.Duration) *roundImporter {
+	return &roundImporter{
+		server:  server,
+		delay:   delay,
+		resolve: resolvePlayerURL,
+		scrape:  scrapePlayerData,
+		sleep:   time.Sleep,
+	}
+}
+
+// Import creates a round for each manifest row and reports the outcome of every row, in manifest order
+// Rows that fail validation or whose round already exists are reported without scraping anything
+func (imp *roundImporter) Import(ctx context.Context, rows []RoundManifestRow) []RoundManifestResult {
+	results := make([]RoundManifestResult, 0, len(rows))
+	for i, row := range rows {
+		result, _ := imp.importRow(ctx, i+1, row)
+		results = append(results, result)
+	}
+	return results
+}
+
+// importRow creates the round for a single row and reports the outcome, returning the error of a failed row
+func (imp *roundImporter) importRow(ctx context.Context, rowNumber int, row RoundManifestRow) (RoundManifestResult, *scrapeError) {
+	result := RoundManifestResult{Row: rowNumber, Sport: row.Sport, PlayDate: row.PlayDate}
+
+	round, err := imp.createRound(ctx, row)
+	if err != nil {
+		result.Status = RoundManifestStatusFailed
+		result.Code = err.ErrorCode
+		result.Message = err.Message
+		log.Printf("Row %d (%s %s): %s", rowNumber, row.Sport, row.PlayDate, err.Error())
+		return result, err
+	}
+
+	result.Status = RoundManifestStatusCreated
+	result.RoundID = round.RoundID
+	result.PlayerName = round.Player.Name
+	log.Printf("Row %d (%s %s): created %s", rowNumber, row.Sport, row.PlayDate, round.Player.Name)
+	return result, nil
+}
+
+// createRound validates, scrapes and saves the round for a single row
+func (imp *roundImporter) createRound(ctx context.Context, row RoundManifestRow) (*Round, *scrapeError) {
+	params, scrapeErr := validateScrapeParams(row.Sport, row.PlayDate, row.Name, row.SportsReferenceURL, row.Theme)
+	if scrapeErr != nil {
+		return nil, scrapeErr
+	}
+	if _, err := time.Parse(DateFormatYYYYMMDD, params.PlayDate); err != nil {
+		return nil, &scrapeError{
+			StatusCode: 400,
+			Message:    "Invalid playDate format: " + err.Error(),
+			ErrorCode:  ErrorInvalidPlayDate,
+			Err:        err,
+		}
+	}
+
+	// Check before scraping so a manifest can be run again after fixing its failed rows
+	existing, err := imp.server.db.GetRound(ctx, params.Sport, params.PlayDate)
+	if err != nil {
+		return nil, &scrapeError{
+			StatusCode: 500,
+			Message:    "Failed to check for an existing round: " + err.Error(),
+			ErrorCode:  ErrorDatabaseError,
+			Err:        err,
+		}
+	}
+	if existing != nil {
+		return nil, &scrapeError{
+			StatusCode: 409,
+			Message:    "Round already exists for sport '" + params.Sport + "' on playDate '" + params.PlayDate + "'",
+			ErrorCode:  ErrorRoundAlreadyExists,
+		}
+	}
+
+	if imp.scraped {
+		imp.sleep(imp.delay)
+	}
+	imp.scraped = true
+
+	playerURL, scrapeErr := imp.resolve(params)
+	if scrapeErr != nil {
+		return nil, scrapeErr
+	}
+
+	player, err := imp.scrape(playerURL, params.Hostname, params.Sport)
+	if err != nil {
+		return nil, &scrapeError{
+			StatusCode: 500,
+			Message:    "Failed to scrape player data: " + err.Error(),
+			ErrorCode:  ErrorScrapingError,
+			Err:        err,
+		}
+	}
+
+	return imp.server.createRoundFromPlayer(ctx, player, params)
+}
+
+// parseRoundManifest reads a manifest in the given format (RoundManifestFormatCSV or RoundManifestFormatJSON)
+// requiredColumns lists the CSV columns that must be present
+func parseRoundManifest(r io.Reader, format string, requiredColumns ...string) ([]RoundManifestRow, error) {
+	switch format {
+	case RoundManifestFormatJSON:
+		var rows []RoundManifestRow
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON manifest: %w", err)
+		}
+		return rows, nil
+	case RoundManifestFormatCSV:
+		return parseRoundManifestCSV(r, requiredColumns)
+	default:
+		return nil, fmt.Errorf("unsupported manifest format %q", format)
+	}
+}
+
+// parseRoundManifestCSV reads a CSV manifest whose header names its columns, in any order
+// Column names match RoundManifestRow's JSON tags, ignoring case
+func parseRoundManifestCSV(r io.Reader, requiredColumns []string) ([]RoundManifestRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV manifest is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV manifest header: %w", err)
+	}
+
+	fields := map[string]func(row *RoundManifestRow) *string{
+		"sport":              func(row *RoundManifestRow) *string { return &row.Sport },
+		"playdate":           func(row *RoundManifestRow) *string { return &row.PlayDate },
+		"name":               func(row *RoundManifestRow) *string { return &row.Name },
+		"sportsreferenceurl": func(row *RoundManifestRow) *string { return &row.SportsReferenceURL },
+		"theme":              func(row *RoundManifestRow) *string { return &row.Theme },
+	}
+	columns := make([]func(row *RoundManifestRow) *string, len(header))
+	seen := map[string]bool{}
+	for i, column := range header {
+		key := strings.ToLower(strings.TrimSpace(column))
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown CSV manifest column %q", column)
+		}
+		columns[i] = field
+		seen[key] = true
+	}
+	for _, column := range requiredColumns {
+		if !seen[strings.ToLower(column)] {
+			return nil, fmt.Errorf("CSV manifest must have a %s column", column)
+		}
+	}
+
+	var rows []RoundManifestRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV manifest: %w", err)
+		}
+
+		var row RoundManifestRow
+		for i, value := range record {
+			*columns[i](&row) = strings.TrimSpace(value)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// ImportRounds runs the "import-rounds" command: it creates a round for every row of a manifest file,
+// writes the per-row report to out as JSON and fails if any row failed
+// Usage: import-rounds [-delay 6s] <manifest.csv|manifest.json>
+func ImportRounds(ctx context.Context, cfg *Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import-rounds", flag.ContinueOnError)
+	delay := flags.Duration("delay", DefaultRoundManifestScrapeDelay, "pause between scraping players")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: import-rounds [-delay 6s] <manifest.csv|manifest.json>")
+	}
+	path := flags.Arg(0)
+
+	rows, _, err := readRoundManifestFile(path, "sport", "playDate")
+	if err != nil {
+		return err
+	}
+
+	store, err := NewStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Importing %d rounds from %s, %s between scrapes", len(rows), path, *delay)
+	results := newRoundImporter(NewServer(store), *delay).Import(ctx, rows)
+	if err := writeReport(out, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == RoundManifestStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", failed, len(results))
+	}
+	return nil
+}
+
+// writeRoundManifest writes rows as a manifest in the given format, the inverse of parseRoundManifest
+// CSV manifests get a playDate column only if a row has a playDate
+func writeRoundManifest(w io.Writer, format string, rows []RoundManifestRow) error {
+	switch format {
+	case RoundManifestFormatJSON:
+		return writeReport(w, rows)
+	case RoundManifestFormatCSV:
+		withPlayDate := false
+		for _, row := range rows {
+			withPlayDate = withPlayDate || row.PlayDate != ""
+		}
+
+		writer := csv.NewWriter(w)
+		header := []string{"sport", "playDate", "name", "sportsReferenceURL", "theme"}
+		if !withPlayDate {
+			header = append(header[:1], header[2:]...)
+		}
+		writer.Write(header)
+		for _, row := range rows {
+			record := []string{row.Sport, row.PlayDate, row.Name, row.SportsReferenceURL, row.Theme}
+			if !withPlayDate {
+				record = append(record[:1], record[2:]...)
+			}
+			writer.Write(record)
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV manifest: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported manifest format %q", format)
+	}
+}
+
+// readRoundManifestFile reads a .csv or .json manifest file, returning its rows and format
+func readRoundManifestFile(path string, requiredColumns ...string) ([]RoundManifestRow, string, error) {
+	format := roundManifestFormat(path)
+	if format == "" {
+		return nil, "", fmt.Errorf("manifest %s must be a .csv or .json file", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := parseRoundManifest(file, format, requiredColumns...)
+	if err != nil {
+		return nil, "", err
+	}
+	return rows, format, nil
+}
+
+// roundManifestFormat returns the manifest format of a file from its extension, or "" if it isn't CSV or JSON
+func roundManifestFormat(path string) string {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if format != RoundManifestFormatCSV && format != RoundManifestFormatJSON {
+		return ""
+	}
+	return format
+}
+
+// writeReport writes a command's report (or a JSON manifest) to out as indented JSON
+func writeReport(out io.Writer, report interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
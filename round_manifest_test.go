@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRoundManifest(t *testing.T) {
+	expected := []RoundManifestRow{
+		{Sport: SportBasketball, PlayDate: "2026-03-01", Name: "LeBron James", Theme: "Legends"},
+		{Sport: SportBaseball, PlayDate: "2026-03-01", SportsReferenceURL: "https://www.baseball-reference.com/players/t/troutmi01.shtml"},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		manifest string
+		expected []RoundManifestRow
+		wantErr  string
+	}{
+		{
+			name:     "csv with columns in any order",
+			format:   RoundManifestFormatCSV,
+			manifest: "playDate,Sport,name,sportsReferenceURL,theme\n2026-03-01,basketball,LeBron James,,Legends\n\n2026-03-01, baseball,,https://www.baseball-reference.com/players/t/troutmi01.shtml,\n",
+			expected: expected,
+		},
+		{
+			name:     "csv without optional columns",
+			format:   RoundManifestFormatCSV,
+			manifest: "sport,playDate,name\nfootball,2026-03-02,\"Allen, Josh\"\n",
+			expected: []RoundManifestRow{{Sport: SportFootball, PlayDate: "2026-03-02", Name: "Allen, Josh"}},
+		},
+		{
+			name:     "csv with an unknown column",
+			format:   RoundManifestFormatCSV,
+			manifest: "sport,playDate,player\nbasketball,2026-03-01,LeBron James\n",
+			wantErr:  `unknown CSV manifest column "player"`,
+		},
+		{
+			name:     "csv without a playDate column",
+			format:   RoundManifestFormatCSV,
+			manifest: "sport,name\nbasketball,LeBron James\n",
+			wantErr:  "CSV manifest must have a playDate column",
+		},
+		{
+			name:     "empty csv",
+			format:   RoundManifestFormatCSV,
+			manifest: "",
+			wantErr:  "CSV manifest is empty",
+		},
+		{
+			name:     "json",
+			format:   RoundManifestFormatJSON,
+			manifest: `[{"sport":"basketball","playDate":"2026-03-01","name":"LeBron James","theme":"Legends"},{"sport":"baseball","playDate":"2026-03-01","sportsReferenceURL":"https://www.baseball-reference.com/players/t/troutmi01.shtml"}]`,
+			expected: expected,
+		},
+		{
+			name:     "invalid json",
+			format:   RoundManifestFormatJSON,
+			manifest: `{"sport":"basketball"}`,
+			wantErr:  "failed to parse JSON manifest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseRoundManifest(strings.NewReader(tt.manifest), tt.format, "sport", "playDate")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseRoundManifest() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRoundManifest() error = %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.expected) {
+				t.Errorf("parseRoundManifest() = %+v, want %+v", rows, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteRoundManifest(t *testing.T) {
+	queue := []RoundManifestRow{
+		{Sport: SportFootball, Name: "Allen, Josh", Theme: "QBs"},
+		{Sport: SportBaseball, SportsReferenceURL: "https://www.baseball-reference.com/players/t/troutmi01.shtml"},
+	}
+	manifest := []RoundManifestRow{{Sport: SportBasketball, PlayDate: "2026-03-01", Name: "LeBron James"}}
+
+	tests := []struct {
+		name     string
+		format   string
+		rows     []RoundManifestRow
+		expected string
+	}{
+		{
+			name:     "csv queue without playDates",
+			format:   RoundManifestFormatCSV,
+			rows:     queue,
+			expected: "sport,name,sportsReferenceURL,theme\nfootball,\"Allen, Josh\",,QBs\nbaseball,,https://www.baseball-reference.com/players/t/troutmi01.shtml,\n",
+		},
+		{
+			name:     "csv manifest",
+			format:   RoundManifestFormatCSV,
+			rows:     manifest,
+			expected: "sport,playDate,name,sportsReferenceURL,theme\nbasketball,2026-03-01,LeBron James,,\n",
+		},
+		{
+			name:     "empty json queue",
+			format:   RoundManifestFormatJSON,
+			rows:     []RoundManifestRow{},
+			expected: "[]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeRoundManifest(&out, tt.format, tt.rows); err != nil {
+				t.Fatalf("writeRoundManifest() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("writeRoundManifest() = %q, want %q", out.String(), tt.expected)
+			}
+
+			// What is written reads back the same
+			rows, err := parseRoundManifest(&out, tt.format)
+			if err != nil {
+				t.Fatalf("parseRoundManifest() error = %v", err)
+			}
+			if len(rows) != len(tt.rows) || (len(rows) > 0 && !reflect.DeepEqual(rows, tt.rows)) {
+				t.Errorf("parseRoundManifest() = %+v, want %+v", rows, tt.rows)
+			}
+		})
+	}
+}
+
+// newTestRoundImporter returns an importer whose scrapes return a player named after the row,
+// recording the players it scraped and the pauses it took
+func newTestRoundImporter(server *Server, scraped *[]string, pauses *[]time.Duration) *roundImporter {
+	imp := newRoundImporter(server, 5*time.Second)
+	imp.resolve = func(params *scrapeParams) (string, *scrapeError) {
+		if params.Name == "Josh Allen" {
+			return "", &scrapeError{StatusCode: 400, Message: "Multiple players found", ErrorCode: ErrorMultiplePlayersFound}
+		}
+		if params.SportsReferenceURL != "" {
+			return params.SportsReferenceURL, nil
+		}
+		return "https://www." + params.Hostname + "/players/" + params.Name, nil
+	}
+	imp.scrape = func(playerURL, hostname, sport string) (*Player, error) {
+		*scraped = append(*scraped, playerURL)
+		if strings.HasSuffix(playerURL, "broken") {
+			return nil, fmt.Errorf("connection reset")
+		}
+		return &Player{Sport: sport, Name: playerURL[strings.LastIndex(playerURL, "/")+1:]}, nil
+	}
+	imp.sleep = func(d time.Duration) {
+		*pauses = append(*pauses, d)
+	}
+	return imp
+}
+
+func TestRoundImporterImport(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+	if err := server.db.CreateRound(ctx, &Round{Sport: SportBasketball, PlayDate: "2026-03-02", Player: Player{Name: "Existing"}}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+
+	var scraped []string
+	var pauses []time.Duration
+	imp := newTestRoundImporter(server, &scraped, &pauses)
+
+	results := imp.Import(ctx, []RoundManifestRow{
+		{Sport: SportBasketball, PlayDate: "2026-03-01", Name: "LeBron", Theme: "Legends"},
+		{Sport: SportBasketball, PlayDate: "2026-03-02", Name: "Jordan"},
+		{Sport: "soccer", PlayDate: "2026-03-01", Name: "Messi"},
+		{Sport: SportBaseball, PlayDate: "03/01/2026", Name: "Trout"},
+		{Sport: SportFootball, PlayDate: "2026-03-01", Name: "Josh Allen"},
+		{Sport: SportBaseball, PlayDate: "2026-03-01", Name: "broken"},
+		{Sport: SportBasketball, PlayDate: "2026-03-01", Name: "Curry"},
+		{Sport: SportFootball, PlayDate: "2026-03-02", SportsReferenceURL: "https://www.pro-football-reference.com/players/Mahomes"},
+	})
+
+	expected := []struct {
+		status string
+		code   string
+	}{
+		{RoundManifestStatusCreated, ""},
+		{RoundManifestStatusFailed, ErrorRoundAlreadyExists},
+		{RoundManifestStatusFailed, ErrorInvalidParameter},
+		{RoundManifestStatusFailed, ErrorInvalidPlayDate},
+		{RoundManifestStatusFailed, ErrorMultiplePlayersFound},
+		{RoundManifestStatusFailed, ErrorScrapingError},
+		{RoundManifestStatusFailed, ErrorRoundAlreadyExists}, // created by the first row
+		{RoundManifestStatusCreated, ""},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(expected), results)
+	}
+	for i, want := range expected {
+		if results[i].Row != i+1 || results[i].Status != want.status || results[i].Code != want.code {
+			t.Errorf("results[%d] = %+v, want row %d %s %s", i, results[i], i+1, want.status, want.code)
+		}
+	}
+	if results[0].RoundID == "" || results[0].PlayerName != "LeBron" || results[4].Message != "Multiple players found" {
+		t.Errorf("results = %+v, want the round ID and player of created rows and the message of failed rows", results)
+	}
+
+	round, _ := server.db.GetRound(ctx, SportBasketball, "2026-03-01")
+	if round == nil || round.Player.Name != "LeBron" || round.Theme != "Legends" {
+		t.Errorf("GetRound() = %+v, want LeBron's round with its theme", round)
+	}
+
+	// Rows that fail before reaching Sports Reference aren't scraped or waited for
+	if len(scraped) != 3 {
+		t.Errorf("scraped %v, want LeBron, broken and Mahomes", scraped)
+	}
+	if !reflect.DeepEqual(pauses, []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second}) {
+		t.Errorf("paused %v, want a pause before each lookup after the first", pauses)
+	}
+}
+
+func TestImportRounds(t *testing.T) {
+	cfg := &Config{StorageBackend: StorageBackendMemory}
+	dir := t.TempDir()
+
+	// Rows that fail validation never reach Sports Reference, so this runs offline
+	manifest := filepath.Join(dir, "season.csv")
+	if err := os.WriteFile(manifest, []byte("sport,playDate,name\nsoccer,2026-03-01,Messi\n"), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	var out bytes.Buffer
+	err := ImportRounds(context.Background(), cfg, []string{"-delay", "0s", manifest}, &out)
+	if err == nil || err.Error() != "1 of 1 rows failed" {
+		t.Errorf("ImportRounds() error = %v, want 1 of 1 rows failed", err)
+	}
+	var results []RoundManifestResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if len(results) != 1 || results[0].Code != ErrorInvalidParameter {
+		t.Errorf("report = %+v, want one INVALID_PARAMETER row", results)
+	}
+
+	for _, args := range [][]string{
+		{},
+		{filepath.Join(dir, "season.txt")},
+		{filepath.Join(dir, "missing.json")},
+	} {
+		if err := ImportRounds(context.Background(), cfg, args, &out); err == nil {
+			t.Errorf("ImportRounds(%v) succeeded, want an error", args)
+		}
+	}
+}
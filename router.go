@@ -19,17 +19,17 @@ func SetupRouter() *gin.Engine {
 	// Load configuration
 	cfg := LoadConfig()
 
-	// Initialize DynamoDB client
-	var err error
-	db, err := NewDB(cfg)
+	// Initialize the storage backend (DynamoDB unless STORAGE_BACKEND says otherwise)
+	db, err := NewStore(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize DynamoDB client: %v", err)
+		log.Fatalf("Failed to initialize storage backend: %v", err)
 	}
-	log.Printf("DynamoDB client initialized (Rounds Table: %s, User Stats Table: %s, Region: %s)",
-		cfg.RoundsTableName, cfg.UserStatsTableName, cfg.AWSRegion)
 
 	// Create server with database dependency injection
 	server := NewServer(db)
+	if err := server.SetArchiveStreakRule(cfg.ArchiveStreakRule); err != nil {
+		log.Fatalf("Failed to configure archive plays: %v", err)
+	}
 
 	// Set Gin mode based on environment
 	ginMode := os.Getenv("GIN_MODE")
@@ -46,9 +46,9 @@ func SetupRouter() *gin.Engine {
 
 	corsConfig := cors.Config{
 		AllowOrigins:     allowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type", "Authorization", "X-API-Key", "X-User-Timezone"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Content-Type", "Authorization", "X-API-Key", HeaderUserTimezone, HeaderGuestToken, HeaderIdempotencyKey},
+		ExposeHeaders:    []string{"Content-Length", HeaderIdempotentReplayed},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
@@ -62,19 +62,31 @@ func SetupRouter() *gin.Engine {
 	public.Use(middleware.OptionalJWTMiddleware())
 	{
 		public.GET("/round", server.GetRound)
+		public.POST("/round/guess", server.GuessRound)
+		public.POST("/round/session", server.StartGameSession)
+		public.GET("/round/session", server.GetGameSession)
+		public.POST("/round/flip", server.FlipTile)
 		public.GET("/stats/round", server.GetRoundStats)
+		public.GET("/leaderboard", server.GetLeaderboard)
 		public.POST("/results", server.SubmitResults)
 		public.GET("/rounds", server.GetRounds)
+		public.GET("/rounds/difficulty", server.GetRoundsByDifficulty)
 	}
 
 	// Public endpoints (with required JWT auth for authenticated users)
 	publicAuth := v1.Group("")
 	publicAuth.Use(middleware.JWTMiddleware())
 	{
-		publicAuth.GET("/stats/user", middleware.RequirePermission("read:athlete-unknown:user-stats"), server.GetUserStats)
-		publicAuth.POST("/stats/user/migrate", middleware.RequirePermission("migrate:athlete-unknown:user-stats"), server.MigrateUserStats)
-		publicAuth.GET("/upcoming-rounds", middleware.RequirePermission("read:athlete-unknown:upcoming-rounds"), server.GetUpcomingRounds)
+		publicAuth.GET("/stats/user", middleware.RequirePermission(PermissionReadUserStats), server.GetUserStats)
+		publicAuth.GET("/stats/user/history", middleware.RequirePermission(PermissionReadUserStats), server.GetPlayHistory)
+		publicAuth.POST("/stats/user/migrate", middleware.RequirePermission(PermissionMigrateUserStats), server.MigrateUserStats)
+		publicAuth.GET("/upcoming-rounds", middleware.RequirePermission(PermissionReadUpcomingRounds), server.GetUpcomingRounds)
 		publicAuth.PUT("/user/username", server.UpdateUsername)
+		publicAuth.POST("/leagues", middleware.RequirePermission(PermissionWriteLeagues), server.CreateLeague)
+		publicAuth.GET("/leagues", middleware.RequirePermission(PermissionReadLeagues), server.GetLeagues)
+		publicAuth.POST("/leagues/join", middleware.RequirePermission(PermissionWriteLeagues), server.JoinLeague)
+		publicAuth.GET("/leagues/members", middleware.RequirePermission(PermissionReadLeagues), server.GetLeagueMembers)
+		publicAuth.GET("/leagues/leaderboard", middleware.RequirePermission(PermissionReadLeagues), server.GetLeagueLeaderboard)
 	}
 
 	// Admin endpoints (API key auth)
@@ -83,7 +95,11 @@ func SetupRouter() *gin.Engine {
 	{
 		admin.PUT("/round", server.CreateRound)
 		admin.POST("/round", server.ScrapeAndCreateRound)
+		admin.PATCH("/round", server.PatchRound)
 		admin.DELETE("/round", server.DeleteRound)
+		admin.GET("/round/edits", server.GetRoundEdits)
+		admin.GET("/analytics/tiles", server.GetTileAnalytics)
+		admin.GET("/schedule/gaps", server.GetScheduleGaps)
 	}
 
 	// Health check
@@ -103,14 +119,30 @@ func HandleHome(c *gin.Context) {
 		"endpoints": []string{
 			"GET /health",
 			"GET /v1/round?sport={sport}&playDate={date}",
+			"POST /v1/round/guess?sport={sport}&playDate={date}",
+			"POST /v1/round/session?sport={sport}&playDate={date}",
+			"GET /v1/round/session?sessionId={sessionId}",
+			"POST /v1/round/flip?sessionId={sessionId}&tile={tile}",
 			"POST /v1/round",
+			"PATCH /v1/round?sport={sport}&playDate={date}",
 			"DELETE /v1/round?sport={sport}&playDate={date}",
+			"GET /v1/round/edits?sport={sport}&playDate={date}",
+			"GET /v1/analytics/tiles?sport={sport}&startDate={date}&endDate={date}",
+			"GET /v1/schedule/gaps?sport={sport}&days={days}",
 			"GET /v1/upcoming-rounds?sport={sport}&startDate={date}&endDate={date}",
-			"POST /v1/results?sport={sport}&playDate={date}",
+			"GET /v1/rounds/difficulty?sport={sport}&startDate={date}&endDate={date}&order={hardest|easiest}",
+			"POST /v1/results?sport={sport}&playDate={date}&sessionId={sessionId}",
 			"GET /v1/stats/round?sport={sport}&playDate={date}",
 			"GET /v1/stats/user?userId={userId}",
+			"GET /v1/stats/user/history?userId={userId}&sport={sport}&limit={limit}&cursor={cursor}",
+			"GET /v1/leaderboard?sport={sport}&window={window}&playDate={date}&limit={limit}&cursor={cursor}",
 			"POST /v1/stats/user/migrate",
 			"PUT /v1/user/username",
+			"POST /v1/leagues",
+			"GET /v1/leagues",
+			"POST /v1/leagues/join",
+			"GET /v1/leagues/members?leagueId={leagueId}",
+			"GET /v1/leagues/leaderboard?leagueId={leagueId}&sport={sport}&window={window}&playDate={date}",
 		},
 	}
 	c.JSON(200, response)
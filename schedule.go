@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetScheduleGaps handles GET /v1/schedule/gaps - lists the playDates over the next N days that have no round
+// Without a round players get ROUND_NOT_FOUND, so every date reported here needs filling before it arrives
+func (s *Server) GetScheduleGaps(c *gin.Context) {
+	sports := AllSports()
+	if sport := c.Query(QueryParamSport); sport != "" {
+		if !IsValidSport(sport) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				JSONFieldError:     StatusBadRequest,
+				JSONFieldMessage:   "Invalid sport parameter. Must be basketball, baseball, or football",
+				JSONFieldCode:      ErrorInvalidParameter,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+		sports = []string{sport}
+	}
+
+	days, ok := parsePageLimit(c.Query(QueryParamDays), DefaultScheduleGapDays, MaxScheduleGapDays)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "days must be a number between 1 and " + strconv.Itoa(MaxScheduleGapDays),
+			JSONFieldCode:      ErrorInvalidParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	gaps, err := findScheduleGaps(c.Request.Context(), s.db, sports, scheduleStartDate(time.Now()), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to check the schedule: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gaps)
+}
+
+// scheduleStartDate returns the first playDate a schedule check covers: today in the earliest timezone,
+// the newest round already being played somewhere
+func scheduleStartDate(now time.Time) string {
+	return now.In(earliestTimezone).Format(DateFormatYYYYMMDD)
+}
+
+// findScheduleGaps lists, per sport, the playDates from startDate over the given number of days that have no round
+func findScheduleGaps(ctx context.Context, store Store, sports []string, startDate string, days int) (*ScheduleGaps, error) {
+	start, err := time.Parse(DateFormatYYYYMMDD, startDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid startDate: %w", err)
+	}
+	endDate := start.AddDate(0, 0, days-1).Format(DateFormatYYYYMMDD)
+
+	gaps := &ScheduleGaps{StartDate: startDate, EndDate: endDate, Sports: []SportScheduleGaps{}}
+	for _, sport := range sports {
+		rounds, err := store.GetRoundsBySport(ctx, sport, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		scheduled := map[string]bool{}
+		for _, round := range rounds {
+			scheduled[round.PlayDate] = true
+		}
+
+		missing := []string{}
+		for day := 0; day < days; day++ {
+			if date := start.AddDate(0, 0, day).Format(DateFormatYYYYMMDD); !scheduled[date] {
+				missing = append(missing, date)
+			}
+		}
+		gaps.Sports = append(gaps.Sports, SportScheduleGaps{Sport: sport, MissingDates: missing})
+		gaps.TotalMissing += len(missing)
+	}
+
+	return gaps, nil
+}
+
+// FillGaps creates a round for each missing playDate, earliest first, from a queue of candidate players
+// Each date takes the next candidate for its sport; a candidate that fails is reported and the one after it is tried
+// saveQueue, if given, is called with the entries not used yet after each round is created, so a run that is
+// interrupted doesn't leave used players queued to be scheduled again. Filling stops if it fails
+// Returns a result per candidate tried and per date left unfilled, and the queue entries that weren't used, in order
+func (imp *roundImporter) FillGaps(ctx context.Context, gaps *ScheduleGaps, queue []RoundManifestRow, saveQueue func([]RoundManifestRow) error) ([]RoundManifestResult, []RoundManifestRow, error) {
+	results := []RoundManifestResult{}
+	used := make([]bool, len(queue))
+
+	for _, sportGaps := range gaps.Sports {
+		next := 0 // candidates before this have been tried for the sport
+		for _, date := range sportGaps.MissingDates {
+			filled := false
+			for ; next < len(queue) && !filled; next++ {
+				if queue[next].Sport != sportGaps.Sport {
+					continue
+				}
+
+				candidate := queue[next]
+				candidate.PlayDate = date
+				result, err := imp.importRow(ctx, next+1, candidate)
+				results = append(results, result)
+
+				switch {
+				case err == nil:
+					used[next] = true
+					filled = true
+					if saveQueue != nil {
+						if err := saveQueue(unusedQueueEntries(queue, used)); err != nil {
+							return results, unusedQueueEntries(queue, used), err
+						}
+					}
+				case err.ErrorCode == ErrorRoundAlreadyExists:
+					// Scheduled by someone else since the gaps were found; the candidate wasn't scraped, so keep it for the next date
+					filled = true
+					next--
+				}
+			}
+
+			if !filled {
+				results = append(results, RoundManifestResult{
+					Sport:    sportGaps.Sport,
+					PlayDate: date,
+					Status:   RoundManifestStatusUnfilled,
+					Code:     ErrorScheduleQueueEmpty,
+					Message:  "No candidates left in the queue for " + sportGaps.Sport,
+				})
+				log.Printf("%s %s: no candidates left in the queue", sportGaps.Sport, date)
+			}
+		}
+	}
+
+	return results, unusedQueueEntries(queue, used), nil
+}
+
+// unusedQueueEntries returns the queue entries that haven't been used, in order
+func unusedQueueEntries(queue []RoundManifestRow, used []bool) []RoundManifestRow {
+	remaining := []RoundManifestRow{}
+	for i, candidate := range queue {
+		if !used[i] {
+			remaining = append(remaining, candidate)
+		}
+	}
+	return remaining
+}
+
+// FillSchedule runs the "fill-schedule" command: it finds the playDates over the next days without a round and,
+// given a queue file of candidate players, creates rounds for them and removes the players used from the queue
+// The report is written to out as JSON; the command fails if any date is left without a round
+// Usage: fill-schedule [-days 14] [-delay 6s] [queue.csv|queue.json]
+func FillSchedule(ctx context.Context, cfg *Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("fill-schedule", flag.ContinueOnError)
+	days := flags.Int("days", DefaultScheduleGapDays, "number of days ahead to check, starting today")
+	delay := flags.Duration("delay", DefaultRoundManifestScrapeDelay, "pause between scraping players")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 || *days < 1 || *days > MaxScheduleGapDays {
+		return fmt.Errorf("usage: fill-schedule [-days 1-%d] [-delay 6s] [queue.csv|queue.json]", MaxScheduleGapDays)
+	}
+
+	var queue []RoundManifestRow
+	var queuePath, queueFormat string
+	if flags.NArg() == 1 {
+		var err error
+		queuePath = flags.Arg(0)
+		queue, queueFormat, err = readRoundManifestFile(queuePath, "sport")
+		if err != nil {
+			return err
+		}
+		if err := validateScheduleQueue(queue); err != nil {
+			return err
+		}
+	}
+
+	store, err := NewStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	gaps, err := findScheduleGaps(ctx, store, AllSports(), scheduleStartDate(time.Now()), *days)
+	if err != nil {
+		return err
+	}
+	report := ScheduleFillReport{Gaps: gaps}
+	log.Printf("%d playDates without a round from %s to %s", gaps.TotalMissing, gaps.StartDate, gaps.EndDate)
+
+	// Without a queue, only report the gaps
+	if queuePath == "" {
+		report.Unfilled = gaps.TotalMissing
+	} else if gaps.TotalMissing > 0 {
+		// The queue file is rewritten after every round created, so players already scheduled are never queued again
+		saveQueue := func(remaining []RoundManifestRow) error {
+			return writeRoundManifestFile(queuePath, queueFormat, remaining)
+		}
+		results, remaining, err := newRoundImporter(NewServer(store), *delay).FillGaps(ctx, gaps, queue, saveQueue)
+		if err != nil {
+			return fmt.Errorf("round created but the queue could not be updated, remove the players already scheduled from %s: %w", queuePath, err)
+		}
+		report.Results = results
+		for _, result := range results {
+			if result.Status == RoundManifestStatusUnfilled {
+				report.Unfilled++
+			}
+		}
+
+		if len(remaining) < len(queue) {
+			log.Printf("Used %d players from %s, %d left", len(queue)-len(remaining), queuePath, len(remaining))
+		}
+	}
+
+	if err := writeReport(out, report); err != nil {
+		return err
+	}
+	if report.Unfilled > 0 {
+		return fmt.Errorf("%d playDates have no round", report.Unfilled)
+	}
+	return nil
+}
+
+// validateScheduleQueue checks that every queue entry is for a known sport and leaves the playDate to fill-schedule
+func validateScheduleQueue(queue []RoundManifestRow) error {
+	for i, candidate := range queue {
+		if !IsValidSport(candidate.Sport) {
+			return fmt.Errorf("queue row %d: invalid sport %q", i+1, candidate.Sport)
+		}
+		if candidate.PlayDate != "" {
+			return fmt.Errorf("queue row %d: queued players are given the first free playDate and can't have their own", i+1)
+		}
+	}
+	return nil
+}
+
+// writeRoundManifestFile replaces a manifest file with the given rows, keeping its format
+// CSV files are written with a playDate column only if a row has a playDate
+func writeRoundManifestFile(path, format string, rows []RoundManifestRow) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	defer os.Remove(temp.Name())
+
+	if err := writeRoundManifest(temp, format, rows); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
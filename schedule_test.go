@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScheduleStartDate(t *testing.T) {
+	tests := []struct {
+		now      time.Time
+		expected string
+	}{
+		{now: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), expected: "2026-03-01"},
+		{now: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), expected: "2026-03-02"}, // midnight in UTC+14
+		{now: time.Date(2026, 3, 1, 23, 0, 0, 0, time.FixedZone("PST", -8*3600)), expected: "2026-03-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.now.String(), func(t *testing.T) {
+			if got := scheduleStartDate(tt.now); got != tt.expected {
+				t.Errorf("scheduleStartDate() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindScheduleGaps(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	for _, round := range []*Round{
+		{Sport: SportBasketball, PlayDate: "2026-02-28"}, // before the range
+		{Sport: SportBasketball, PlayDate: "2026-03-01"},
+		{Sport: SportBasketball, PlayDate: "2026-03-03"},
+		{Sport: SportBaseball, PlayDate: "2026-03-01"},
+		{Sport: SportBaseball, PlayDate: "2026-03-02"},
+		{Sport: SportBaseball, PlayDate: "2026-03-03"},
+	} {
+		if err := store.CreateRound(ctx, round); err != nil {
+			t.Fatalf("CreateRound() error = %v", err)
+		}
+	}
+
+	gaps, err := findScheduleGaps(ctx, store, AllSports(), "2026-03-01", 4)
+	if err != nil {
+		t.Fatalf("findScheduleGaps() error = %v", err)
+	}
+
+	expected := &ScheduleGaps{
+		StartDate:    "2026-03-01",
+		EndDate:      "2026-03-04",
+		TotalMissing: 7,
+		Sports: []SportScheduleGaps{
+			{Sport: SportBaseball, MissingDates: []string{"2026-03-04"}},
+			{Sport: SportBasketball, MissingDates: []string{"2026-03-02", "2026-03-04"}},
+			{Sport: SportFootball, MissingDates: []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04"}},
+		},
+	}
+	if !reflect.DeepEqual(gaps, expected) {
+		t.Errorf("findScheduleGaps() = %+v, want %+v", gaps, expected)
+	}
+
+	if _, err := findScheduleGaps(ctx, store, AllSports(), "03/01/2026", 4); err == nil {
+		t.Errorf("findScheduleGaps() with an invalid startDate succeeded, want an error")
+	}
+}
+
+func TestGetScheduleGaps(t *testing.T) {
+	server := getTestServer()
+	start := scheduleStartDate(time.Now())
+	if err := server.db.CreateRound(context.Background(), &Round{Sport: SportFootball, PlayDate: start}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+
+	w := performRequest(server.GetScheduleGaps, http.MethodGet, "/v1/schedule/gaps?sport=football&days=3", nil, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var gaps ScheduleGaps
+	if err := json.NewDecoder(w.Body).Decode(&gaps); err != nil {
+		t.Fatalf("failed to decode gaps: %v", err)
+	}
+	if gaps.StartDate != start || gaps.TotalMissing != 2 || len(gaps.Sports) != 1 || gaps.Sports[0].Sport != SportFootball {
+		t.Errorf("gaps = %+v, want 2 football days missing from %s", gaps, start)
+	}
+
+	w = performRequest(server.GetScheduleGaps, http.MethodGet, "/v1/schedule/gaps", nil, "")
+	if err := json.NewDecoder(w.Body).Decode(&gaps); err != nil {
+		t.Fatalf("failed to decode gaps: %v", err)
+	}
+	if w.Code != http.StatusOK || len(gaps.Sports) != len(AllSports()) || gaps.TotalMissing != 3*DefaultScheduleGapDays-1 {
+		t.Errorf("default gaps = %d %+v, want every sport over %d days", w.Code, gaps, DefaultScheduleGapDays)
+	}
+
+	for _, query := range []string{
+		"sport=hockey",
+		"days=0",
+		"days=367",
+		"days=two",
+	} {
+		if w := performRequest(server.GetScheduleGaps, http.MethodGet, "/v1/schedule/gaps?"+query, nil, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("gaps?%s status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestRoundImporterFillGaps(t *testing.T) {
+	server := getTestServer()
+	ctx := context.Background()
+
+	var scraped []string
+	var pauses []time.Duration
+	imp := newTestRoundImporter(server, &scraped, &pauses)
+
+	queue := []RoundManifestRow{
+		{Sport: SportBasketball, Name: "LeBron", Theme: "Legends"},
+		{Sport: SportFootball, Name: "Josh Allen"},
+		{Sport: SportBasketball, Name: "broken"},
+		{Sport: SportBasketball, Name: "Curry"},
+		{Sport: SportFootball, Name: "Mahomes"},
+		{Sport: SportBaseball, Name: "Trout"},
+	}
+	gaps := &ScheduleGaps{Sports: []SportScheduleGaps{
+		{Sport: SportBaseball, MissingDates: []string{}},
+		{Sport: SportBasketball, MissingDates: []string{"2026-03-02", "2026-03-04"}},
+		{Sport: SportFootball, MissingDates: []string{"2026-03-01", "2026-03-03"}},
+	}}
+
+	var saved [][]RoundManifestRow
+	saveQueue := func(remaining []RoundManifestRow) error {
+		saved = append(saved, remaining)
+		return nil
+	}
+	results, remaining, err := imp.FillGaps(ctx, gaps, queue, saveQueue)
+	if err != nil {
+		t.Fatalf("FillGaps() error = %v", err)
+	}
+
+	expected := []RoundManifestResult{
+		{Row: 1, Sport: SportBasketball, PlayDate: "2026-03-02", Status: RoundManifestStatusCreated, PlayerName: "LeBron"},
+		{Row: 3, Sport: SportBasketball, PlayDate: "2026-03-04", Status: RoundManifestStatusFailed, Code: ErrorScrapingError},
+		{Row: 4, Sport: SportBasketball, PlayDate: "2026-03-04", Status: RoundManifestStatusCreated, PlayerName: "Curry"},
+		{Row: 2, Sport: SportFootball, PlayDate: "2026-03-01", Status: RoundManifestStatusFailed, Code: ErrorMultiplePlayersFound},
+		{Row: 5, Sport: SportFootball, PlayDate: "2026-03-01", Status: RoundManifestStatusCreated, PlayerName: "Mahomes"},
+		{Sport: SportFootball, PlayDate: "2026-03-03", Status: RoundManifestStatusUnfilled, Code: ErrorScheduleQueueEmpty},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(expected), results)
+	}
+	for i, want := range expected {
+		got := results[i]
+		got.RoundID, got.Message = "", ""
+		if got != want {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	// Failed and unused candidates stay queued
+	if !reflect.DeepEqual(remaining, []RoundManifestRow{queue[1], queue[2], queue[5]}) {
+		t.Errorf("remaining = %+v, want Josh Allen, broken and Trout", remaining)
+	}
+
+	// The queue is saved after each round created, without the candidates used so far
+	if len(saved) != 3 || len(saved[0]) != 5 || len(saved[1]) != 4 || !reflect.DeepEqual(saved[2], remaining) {
+		t.Errorf("saved queues = %+v, want one per round created, ending with the remaining candidates", saved)
+	}
+
+	round, _ := server.db.GetRound(ctx, SportBasketball, "2026-03-02")
+	if round == nil || round.Player.Name != "LeBron" || round.Theme != "Legends" {
+		t.Errorf("GetRound() = %+v, want LeBron's round with its theme", round)
+	}
+
+	// A date scheduled since the gaps were found keeps its round and doesn't use up a candidate
+	if err := server.db.CreateRound(ctx, &Round{Sport: SportBaseball, PlayDate: "2026-03-05", Player: Player{Name: "Ohtani"}}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	gaps = &ScheduleGaps{Sports: []SportScheduleGaps{{Sport: SportBaseball, MissingDates: []string{"2026-03-05", "2026-03-06"}}}}
+	results, remaining, err = imp.FillGaps(ctx, gaps, []RoundManifestRow{{Sport: SportBaseball, Name: "Trout"}}, nil)
+	if err != nil {
+		t.Fatalf("FillGaps() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Code != ErrorRoundAlreadyExists || results[1].Status != RoundManifestStatusCreated || results[1].PlayDate != "2026-03-06" {
+		t.Errorf("results = %+v, want Trout on 2026-03-06", results)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("remaining = %+v, want none", remaining)
+	}
+
+	// Filling stops as soon as the queue can't be saved
+	gaps = &ScheduleGaps{Sports: []SportScheduleGaps{{Sport: SportBasketball, MissingDates: []string{"2026-03-07", "2026-03-08"}}}}
+	queue = []RoundManifestRow{{Sport: SportBasketball, Name: "Duncan"}, {Sport: SportBasketball, Name: "Bird"}}
+	results, remaining, err = imp.FillGaps(ctx, gaps, queue, func([]RoundManifestRow) error { return errors.New("disk full") })
+	if err == nil || len(results) != 1 || !reflect.DeepEqual(remaining, queue[1:]) {
+		t.Errorf("FillGaps() with a failing save = %+v, %+v, %v, want Duncan's round and then the error", results, remaining, err)
+	}
+}
+
+func TestFillSchedule(t *testing.T) {
+	cfg := &Config{StorageBackend: StorageBackendMemory}
+	dir := t.TempDir()
+
+	// Without a queue the gaps are only reported
+	var out bytes.Buffer
+	err := FillSchedule(context.Background(), cfg, []string{"-days", "2"}, &out)
+	if err == nil || err.Error() != "6 playDates have no round" {
+		t.Errorf("FillSchedule() error = %v, want 6 playDates have no round", err)
+	}
+	var report ScheduleFillReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if report.Gaps == nil || report.Gaps.TotalMissing != 6 || report.Unfilled != 6 || len(report.Results) != 0 {
+		t.Errorf("report = %+v, want 6 gaps and no results", report)
+	}
+
+	// Candidates that fail validation never reach Sports Reference, so this runs offline
+	queue := filepath.Join(dir, "queue.csv")
+	contents := "sport,name\nbasketball,\n"
+	if err := os.WriteFile(queue, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write queue: %v", err)
+	}
+	out.Reset()
+	err = FillSchedule(context.Background(), cfg, []string{"-days", "1", "-delay", "0s", queue}, &out)
+	if err == nil || err.Error() != "3 playDates have no round" {
+		t.Errorf("FillSchedule() error = %v, want 3 playDates have no round", err)
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if len(report.Results) != 4 || report.Results[1].Code != ErrorMissingRequiredParameter {
+		t.Errorf("results = %+v, want the basketball candidate to fail and 3 unfilled dates", report.Results)
+	}
+	if got, _ := os.ReadFile(queue); string(got) != contents {
+		t.Errorf("queue = %q, want it unchanged when no candidate was used", got)
+	}
+
+	for name, contents := range map[string]string{
+		"invalid sport": "sport,name\nhockey,Gretzky\n",
+		"playDate":      "sport,playDate,name\nbasketball,2026-03-01,LeBron James\n",
+	} {
+		if err := os.WriteFile(queue, []byte(contents), 0o644); err != nil {
+			t.Fatalf("failed to write queue: %v", err)
+		}
+		if err := FillSchedule(context.Background(), cfg, []string{queue}, &out); err == nil {
+			t.Errorf("FillSchedule() with a queue entry with %s succeeded, want an error", name)
+		}
+	}
+	for _, args := range [][]string{
+		{"-days", "0"},
+		{queue, queue},
+	} {
+		if err := FillSchedule(context.Background(), cfg, args, &out); err == nil {
+			t.Errorf("FillSchedule(%v) succeeded, want an error", args)
+		}
+	}
+}
+
+func TestWriteRoundManifestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	if err := os.WriteFile(path, []byte(`[{"sport":"basketball","name":"LeBron James"},{"sport":"football","name":"Patrick Mahomes"}]`), 0o644); err != nil {
+		t.Fatalf("failed to write queue: %v", err)
+	}
+
+	remaining := []RoundManifestRow{{Sport: SportFootball, Name: "Patrick Mahomes"}}
+	if err := writeRoundManifestFile(path, RoundManifestFormatJSON, remaining); err != nil {
+		t.Fatalf("writeRoundManifestFile() error = %v", err)
+	}
+
+	rows, format, err := readRoundManifestFile(path, "sport")
+	if err != nil || format != RoundManifestFormatJSON || !reflect.DeepEqual(rows, remaining) {
+		t.Errorf("readRoundManifestFile() = %+v, %s, %v, want the remaining queue", rows, format, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("got %d files, want the temporary file to be renamed over the queue", len(entries))
+	}
+}
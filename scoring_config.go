@@ -0,0 +1,112 @@
+package main
+
+// ScoringConfig defines how a play-through is scored for a sport
+type ScoringConfig struct {
+	MaxScore              int            // Score for a correct guess with no tiles flipped and no wrong guesses
+	TileCosts             map[string]int // Points deducted for flipping each tile, keyed by tile name
+	IncorrectGuessPenalty int            // Points deducted for each wrong guess
+}
+
+// GetScoringConfig returns the scoring weights for a given sport
+// Tiles that give the answer away more easily (photo, initials, nicknames) cost more
+func GetScoringConfig(sport string) ScoringConfig {
+	switch sport {
+	case SportBaseball:
+		return ScoringConfig{
+			MaxScore: 100,
+			TileCosts: map[string]int{
+				TileBio:                  6,
+				TilePlayerInformation:    6,
+				TileDraftInformation:     4, // MLB draft rarely narrows it down
+				TileTeamsPlayedOn:        8,
+				TileJerseyNumbers:        6,
+				TileCareerStats:          8,
+				TilePersonalAchievements: 8,
+				TilePhoto:                12,
+				TileYearsActive:          6,
+				TileInitials:             12,
+				TileNicknames:            14,
+			},
+			IncorrectGuessPenalty: 5,
+		}
+
+	case SportBasketball, SportFootball:
+		return ScoringConfig{
+			MaxScore: 100,
+			TileCosts: map[string]int{
+				TileBio:                  6,
+				TilePlayerInformation:    6,
+				TileDraftInformation:     8,
+				TileTeamsPlayedOn:        8,
+				TileJerseyNumbers:        6,
+				TileCareerStats:          8,
+				TilePersonalAchievements: 8,
+				TilePhoto:                12,
+				TileYearsActive:          6,
+				TileInitials:             12,
+				TileNicknames:            10,
+			},
+			IncorrectGuessPenalty: 5,
+		}
+
+	default:
+		return ScoringConfig{
+			MaxScore: 100,
+			TileCosts: map[string]int{
+				TileBio:                  6,
+				TilePlayerInformation:    6,
+				TileDraftInformation:     6,
+				TileTeamsPlayedOn:        8,
+				TileJerseyNumbers:        6,
+				TileCareerStats:          8,
+				TilePersonalAchievements: 8,
+				TilePhoto:                12,
+				TileYearsActive:          6,
+				TileInitials:             12,
+				TileNicknames:            12,
+			},
+			IncorrectGuessPenalty: 5,
+		}
+	}
+}
+
+// tileCost returns the points deducted for flipping a tile. Unknown tiles cost nothing
+func (cfg ScoringConfig) tileCost(tileName string) int {
+	return cfg.TileCosts[tileName]
+}
+
+// totalTileCost returns what flipping every tile costs, the most a correct guess can lose to tiles
+func (cfg ScoringConfig) totalTileCost() int {
+	total := 0
+	for _, tile := range AllTiles() {
+		total += cfg.tileCost(tile)
+	}
+	return total
+}
+
+// calculateScore is the server-authoritative scoring engine
+// Incorrect results score 0. Correct results start at MaxScore and lose the cost of every tile flipped
+// and a penalty for every wrong guess, never dropping below 0
+func calculateScore(sport string, isCorrect bool, flippedTiles []string, incorrectGuesses int) int {
+	if !isCorrect {
+		return 0
+	}
+
+	cfg := GetScoringConfig(sport)
+	score := cfg.MaxScore - incorrectGuesses*cfg.IncorrectGuessPenalty
+
+	// Each tile is only charged once, even if the list contains duplicates
+	var charged []string
+	for _, tile := range flippedTiles {
+		if contains(charged, tile) {
+			continue
+		}
+		charged = append(charged, tile)
+		score -= cfg.tileCost(tile)
+	}
+
+	if score < 0 {
+		return 0
+	}
+	return score
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestGetScoringConfig(t *testing.T) {
+	sports := append(AllSports(), "unknown")
+
+	for _, sport := range sports {
+		t.Run(sport, func(t *testing.T) {
+			cfg := GetScoringConfig(sport)
+
+			// Every registry tile is scored, whatever its position in the registry
+			totalCost := 0
+			for _, tile := range AllTiles() {
+				cost, ok := cfg.TileCosts[tile]
+				if !ok || cost <= 0 {
+					t.Errorf("Expected tile %s to have a positive cost, got %d", tile, cost)
+				}
+				totalCost += cost
+			}
+			for tile := range cfg.TileCosts {
+				if _, ok := lookupTile(tile); !ok {
+					t.Errorf("Expected only registry tiles to have costs, got %s", tile)
+				}
+			}
+
+			// Flipping every tile must still leave points on the table for a correct guess
+			if totalCost >= cfg.MaxScore {
+				t.Errorf("Expected total tile cost below MaxScore %d, got %d", cfg.MaxScore, totalCost)
+			}
+
+			if cfg.IncorrectGuessPenalty <= 0 {
+				t.Errorf("Expected a positive incorrect guess penalty, got %d", cfg.IncorrectGuessPenalty)
+			}
+		})
+	}
+}
+
+func TestScoringConfigTileCost(t *testing.T) {
+	cfg := ScoringConfig{
+		MaxScore:  100,
+		TileCosts: map[string]int{TileBio: 3, TileNicknames: 11},
+	}
+
+	if cost := cfg.tileCost(TileNicknames); cost != 11 {
+		t.Errorf("tileCost(%s) = %d, want 11", TileNicknames, cost)
+	}
+	if cost := cfg.tileCost("unknown"); cost != 0 {
+		t.Errorf("Expected unknown tile to cost 0, got %d", cost)
+	}
+
+	// Missing weights cost nothing instead of panicking
+	if cost := cfg.tileCost(TilePhoto); cost != 0 {
+		t.Errorf("Expected tile without a weight to cost 0, got %d", cost)
+	}
+}
+
+func TestCalculateScore(t *testing.T) {
+	tests := []struct {
+		name             string
+		sport            string
+		isCorrect        bool
+		flippedTiles     []string
+		incorrectGuesses int
+		expected         int
+	}{
+		{name: "correct with no tiles", sport: SportBasketball, isCorrect: true, flippedTiles: nil, expected: 100},
+		{name: "correct with tiles", sport: SportBasketball, isCorrect: true, flippedTiles: []string{TileBio, TilePhoto}, expected: 82},
+		{name: "correct with tiles and wrong guesses", sport: SportBasketball, isCorrect: true, flippedTiles: []string{TileYearsActive, TileTeamsPlayedOn, TileJerseyNumbers}, incorrectGuesses: 1, expected: 75},
+		{name: "weights differ per sport", sport: SportBaseball, isCorrect: true, flippedTiles: []string{TileDraftInformation}, expected: 96},
+		{name: "same tiles in football", sport: SportFootball, isCorrect: true, flippedTiles: []string{TileDraftInformation}, expected: 92},
+		{name: "duplicate tiles are charged once", sport: SportBasketball, isCorrect: true, flippedTiles: []string{TilePhoto, TilePhoto}, expected: 88},
+		{name: "incorrect result", sport: SportBasketball, isCorrect: false, flippedTiles: []string{TileBio}, incorrectGuesses: 2, expected: 0},
+		{name: "all tiles flipped", sport: SportBasketball, isCorrect: true, flippedTiles: AllTiles(), expected: 10},
+		{name: "score never goes negative", sport: SportBasketball, isCorrect: true, flippedTiles: AllTiles(), incorrectGuesses: 10, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calculateScore(tt.sport, tt.isCorrect, tt.flippedTiles, tt.incorrectGuesses)
+			if result != tt.expected {
+				t.Errorf("calculateScore() = %d, want %d", result, tt.expected)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ type scrapeParams struct {
 	SportsReferenceURL string
 	Theme              string
 	Hostname           string
+	DryRun             bool // preview the scraped round without saving it
 }
 
 // scrapeError represents a scraping error with HTTP status and error details
@@ -29,6 +30,7 @@ type scrapeError struct {
 	Message    string
 	ErrorCode  string
 	Err        error
+	Candidates []PlayerCandidate // players to pick from when a name search is ambiguous
 }
 
 const (
@@ -44,12 +46,30 @@ func (e *scrapeError) Error() string {
 
 // parseAndValidateScrapeParams extracts and validates scraping parameters from the request
 func parseAndValidateScrapeParams(c *gin.Context) (*scrapeParams, *scrapeError) {
-	sport := c.Query(QueryParamSport)
-	playDate := c.Query(QueryParamPlayDate)
-	name := c.Query(QueryParamName)
-	sportsReferenceURL := c.Query(QueryParamSportsReferenceURL)
-	theme := c.Query(QueryParamTheme)
+	dryRun := false
+	if value := c.Query(QueryParamDryRun); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, &scrapeError{
+				StatusCode: 400,
+				Message:    "dryRun parameter must be true or false",
+				ErrorCode:  ErrorInvalidParameter,
+			}
+		}
+		dryRun = parsed
+	}
+
+	params, err := validateScrapeParams(c.Query(QueryParamSport), c.Query(QueryParamPlayDate), c.Query(QueryParamName),
+		c.Query(QueryParamSportsReferenceURL), c.Query(QueryParamTheme))
+	if err != nil {
+		return nil, err
+	}
+	params.DryRun = dryRun
+	return params, nil
+}
 
+// validateScrapeParams validates the parameters of a single scrape, from a request or a manifest row
+func validateScrapeParams(sport, playDate, name, sportsReferenceURL, theme string) (*scrapeParams, *scrapeError) {
 	// Validate required parameters
 	if sport == "" {
 		return nil, &scrapeError{
@@ -142,7 +162,7 @@ func searchPlayerByName(name, hostname string) (string, *scrapeError) {
 	// Variable to capture the final URL after redirects
 	var finalURL string
 	var collectorError error
-	var playerSearchItems []string
+	var playerSearchItems []PlayerCandidate
 
 	// Set up error handling
 	collector.OnError(func(r *colly.Response, err error) {
@@ -166,7 +186,9 @@ func searchPlayerByName(name, hostname string) (string, *scrapeError) {
 		// Get the player URL path from the search-item-url div text
 		playerURLPath := strings.TrimSpace(e.ChildText("div.search-item-url"))
 		if playerURLPath != "" {
-			playerSearchItems = append(playerSearchItems, playerURLPath)
+			candidate := parsePlayerSearchName(e.DOM.Find("div.search-item-name a").First().Text())
+			candidate.SportsReferenceURL = fmt.Sprintf("https://www.%s%s", hostname, playerURLPath)
+			playerSearchItems = append(playerSearchItems, candidate)
 		}
 	})
 
@@ -196,7 +218,7 @@ func searchPlayerByName(name, hostname string) (string, *scrapeError) {
 		// Check if there's exactly one player result in the search results
 		if len(playerSearchItems) == 1 {
 			// Use the single player result
-			finalURL = fmt.Sprintf("https://www.%s%s", hostname, playerSearchItems[0])
+			finalURL = playerSearchItems[0].SportsReferenceURL
 			// fmt.Printf("Found single player result, using URL: %s\n", finalURL)
 		} else if len(playerSearchItems) == 0 {
 			return "", &scrapeError{
@@ -207,8 +229,9 @@ func searchPlayerByName(name, hostname string) (string, *scrapeError) {
 		} else {
 			return "", &scrapeError{
 				StatusCode: 400,
-				Message:    "Multiple players found with the name '" + name + "'. Please provide the sportsReferenceURL parameter to specify the exact player.",
+				Message:    "Multiple players found with the name '" + name + "'. Please provide the sportsReferenceURL of one of the candidates to specify the exact player.",
 				ErrorCode:  ErrorMultiplePlayersFound,
+				Candidates: playerSearchItems,
 			}
 		}
 	}
@@ -229,8 +252,57 @@ func searchPlayerByName(name, hostname string) (string, *scrapeError) {
 	return finalURL, nil
 }
 
-// createRoundFromPlayer builds a Round struct from Player data and params
+// parsePlayerSearchName splits the name shown in a search result, e.g. "Josh Allen (QB) (2018-2024)",
+// into the player's name, position and years active. Results don't always include a position
+func parsePlayerSearchName(text string) PlayerCandidate {
+	text = strings.Join(strings.Fields(text), " ")
+
+	var candidate PlayerCandidate
+	yearsRegex := regexp.MustCompile(`^\d{4}(-\d{4})?$`)
+	parenRegex := regexp.MustCompile(`\s*\(([^)]*)\)`)
+	for _, match := range parenRegex.FindAllStringSubmatch(text, -1) {
+		value := strings.TrimSpace(match[1])
+		if yearsRegex.MatchString(value) {
+			candidate.YearsActive = value
+		} else if candidate.Position == "" {
+			candidate.Position = value
+		}
+	}
+	candidate.Name = strings.TrimSpace(parenRegex.ReplaceAllString(text, ""))
+
+	return candidate
+}
+
+// createRoundFromPlayer builds a Round from Player data and params and saves it
 func (s *Server) createRoundFromPlayer(ctx context.Context, player *Player, params *scrapeParams) (*Round, *scrapeError) {
+	round, scrapeErr := buildRoundFromPlayer(player, params)
+	if scrapeErr != nil {
+		return nil, scrapeErr
+	}
+
+	// Store the round in DynamoDB
+	if err := s.db.CreateRound(ctx, round); err != nil {
+		if err.Error() == "round already exists" {
+			return nil, &scrapeError{
+				StatusCode: 409,
+				Message:    "Round already exists for sport '" + params.Sport + "' on playDate '" + params.PlayDate + "'",
+				ErrorCode:  ErrorRoundAlreadyExists,
+				Err:        err,
+			}
+		}
+		return nil, &scrapeError{
+			StatusCode: 500,
+			Message:    "Failed to create round: " + err.Error(),
+			ErrorCode:  ErrorDatabaseError,
+			Err:        err,
+		}
+	}
+
+	return round, nil
+}
+
+// buildRoundFromPlayer builds a Round struct from Player data and params without saving it
+func buildRoundFromPlayer(player *Player, params *scrapeParams) (*Round, *scrapeError) {
 	roundID, err := GenerateRoundID(params.Sport, params.PlayDate)
 	if err != nil {
 		return nil, &scrapeError{
@@ -257,27 +329,47 @@ func (s *Server) createRoundFromPlayer(ctx context.Context, player *Player, para
 		},
 	}
 
-	// Store the round in DynamoDB
-	if err := s.db.CreateRound(ctx, round); err != nil {
-		return nil, &scrapeError{
-			StatusCode: 500,
-			Message:    "Failed to create round: " + err.Error(),
-			ErrorCode:  ErrorDatabaseError,
-			Err:        err,
+	return round, nil
+}
+
+// scrapeWarnings lists the problems an admin should review in a scraped player before the round goes live
+// Tiles are checked in display order; the photo is only checked for being missing since it holds a URL, not a clue
+func scrapeWarnings(player *Player) []ScrapeWarning {
+	warnings := []ScrapeWarning{}
+	for _, tile := range tileRegistry {
+		value := *tile.Field(player)
+		switch {
+		case tile.Key == TilePhoto:
+			if value == "" {
+				warnings = append(warnings, ScrapeWarning{Tile: tile.Key, Code: ScrapeWarningMissingPhoto, Message: "No photo was found"})
+			}
+		case strings.TrimSpace(value) == "":
+			warnings = append(warnings, ScrapeWarning{Tile: tile.Key, Code: ScrapeWarningEmptyTile, Message: "Tile is empty"})
+		case tile.Key == TilePersonalAchievements && value == "N/A":
+			warnings = append(warnings, ScrapeWarning{Tile: tile.Key, Code: ScrapeWarningNoAchievements, Message: "No achievements were found, so the tile reads N/A"})
+		case len(value) > ClueMaxLength:
+			warnings = append(warnings, ScrapeWarning{
+				Tile:    tile.Key,
+				Code:    ScrapeWarningClueTooLong,
+				Message: fmt.Sprintf("Clue is %d characters, over the %d character limit", len(value), ClueMaxLength),
+			})
 		}
 	}
-
-	return round, nil
+	return warnings
 }
 
 // respondWithScrapeError sends an error response based on scrapeError
 func respondWithScrapeError(c *gin.Context, err *scrapeError) {
-	c.JSON(err.StatusCode, gin.H{
+	response := gin.H{
 		JSONFieldError:     getStatusText(err.StatusCode),
 		JSONFieldMessage:   err.Message,
 		JSONFieldCode:      err.ErrorCode,
 		JSONFieldTimestamp: time.Now(),
-	})
+	}
+	if len(err.Candidates) > 0 {
+		response[JSONFieldCandidates] = err.Candidates
+	}
+	c.JSON(err.StatusCode, response)
 }
 
 // getStatusText returns the HTTP status text for a status code
@@ -325,72 +417,13 @@ func scrapePlayerData(playerURL, hostname, sport string) (*Player, error) {
 		fmt.Printf("Scraping error: %v\n", err)
 	})
 
-	var statsPulloutElement *colly.HTMLElement
-	var rawAchievements []string
-
 	// Register all scrapers
-	scrapePlayerName(c, player) // includes initials
-	scrapeBio(c, player, sport)
-	scrapePlayerInformation(c, player, sport)
-	scrapeDraftInformation(c, player, sport)
-	scrapeYearsActiveAndTeamsPlayedOn(c, player, sport)
-	scrapeJerseyNumbers(c, player)
-	scrapeCareerStats(c, &statsPulloutElement)
-	scrapePersonalAchievements(c, &rawAchievements)
-	scrapePhoto(c, player)
-	scrapeNicknames(c, player, sport)
-
-	// Post-processing after scraping completes
-	c.OnScraped(func(r *colly.Response) {
-		// Set draft information default if not found
-		if player.DraftInformation == "" {
-			player.DraftInformation = "Undrafted"
+	scrapePlayerName(c, player)
+	for _, tile := range tileRegistry {
+		if tile.Scrape != nil {
+			tile.Scrape(c, player, sport)
 		}
-
-		// Set career stats. Need playerInformation to determine position for stats to get
-		if statsPulloutElement != nil {
-			careerStatsConfig := GetCareerStatsConfig(sport, player.PlayerInformation)
-
-			winsOrSavesValue := 0
-			var winsOrSavesLabel string
-
-			var careerStats []string
-			for _, statConfig := range careerStatsConfig.Stats {
-				statValue := strings.TrimSpace(statsPulloutElement.DOM.Find(statConfig.HTMLPath).Text())
-				if statValue != "" {
-					// Logic to correctly display the higher of Wins or Saves (baseball pitcher-only)
-					if statConfig.StatLabel == "W" || statConfig.StatLabel == "SV" {
-						intStatValue, err := strconv.Atoi(statValue)
-						if err != nil {
-							fmt.Printf("Warning: Failed to convert %s value '%s' to integer: %v\n", statConfig.StatLabel, statValue, err)
-							continue
-						}
-						if intStatValue > winsOrSavesValue {
-							winsOrSavesValue = intStatValue
-							winsOrSavesLabel = statConfig.StatLabel
-						}
-					} else {
-						careerStats = append(careerStats, fmt.Sprintf("%s %s", statValue, statConfig.StatLabel))
-					}
-				}
-			}
-
-			if winsOrSavesLabel != "" {
-				careerStats = append([]string{fmt.Sprintf("%d %s", winsOrSavesValue, winsOrSavesLabel)}, careerStats...)
-			}
-
-			if len(careerStats) > 0 {
-				player.CareerStats = strings.Join(careerStats, ", ")
-			}
-		}
-
-		// Set personal achievements
-		if len(rawAchievements) > 0 {
-			player.PersonalAchievements = ProcessAchievements(sport, rawAchievements, ClueMaxLength)
-		} else {
-			player.PersonalAchievements = "N/A"
-		}
-	})
+	}
 
 	// Visit the player page
 	err := c.Visit(playerURL)
@@ -409,13 +442,18 @@ func scrapePlayerData(playerURL, hostname, sport string) (*Player, error) {
 func scrapePlayerName(c *colly.Collector, player *Player) {
 	c.OnHTML("h1[itemprop='name'], h1 span", func(e *colly.HTMLElement) {
 		if player.Name == "" {
-			playerName := strings.TrimSpace(e.Text)
-			player.Name = playerName
-			player.Initials = getPlayerInitials(playerName)
+			player.Name = strings.TrimSpace(e.Text)
 		}
 	})
 }
 
+// scrapeInitials derives the initials from the player's name once the page is scraped
+func scrapeInitials(c *colly.Collector, player *Player, sport string) {
+	c.OnScraped(func(r *colly.Response) {
+		player.Initials = getPlayerInitials(player.Name)
+	})
+}
+
 // scrapeBio extracts the player's biographical information (birth date and location)
 func scrapeBio(c *colly.Collector, player *Player, sport string) {
 	var dobText string
@@ -542,6 +580,13 @@ func scrapeDraftInformation(c *colly.Collector, player *Player, sport string) {
 			player.DraftInformation = strings.Join(strings.Fields(player.DraftInformation), " ")
 		}
 	})
+
+	// Set draft information default if not found
+	c.OnScraped(func(r *colly.Response) {
+		if player.DraftInformation == "" {
+			player.DraftInformation = "Undrafted"
+		}
+	})
 }
 
 // scrapeYearsActive extracts years active and teams played on accounting for injury/unplayed years
@@ -584,7 +629,7 @@ func scrapeYearsActiveAndTeamsPlayedOn(c *colly.Collector, player *Player, sport
 }
 
 // scrapeJerseyNumbers extracts jersey numbers using uni_holder class
-func scrapeJerseyNumbers(c *colly.Collector, player *Player) {
+func scrapeJerseyNumbers(c *colly.Collector, player *Player, sport string) {
 
 	c.OnHTML(".uni_holder", func(e *colly.HTMLElement) {
 		// Remove all newlines, tabs, and extra spaces
@@ -617,25 +662,76 @@ func scrapeJerseyNumbers(c *colly.Collector, player *Player) {
 	})
 }
 
-// scrapeCareerStats captures the stats pullout element for processing
-func scrapeCareerStats(c *colly.Collector, statsPulloutElement **colly.HTMLElement) {
+// scrapeCareerStats captures the stats pullout element and formats the career stats once the page is scraped
+// Needs playerInformation to determine which stats to show for the player's position
+func scrapeCareerStats(c *colly.Collector, player *Player, sport string) {
+	var statsPulloutElement *colly.HTMLElement
 	c.OnHTML(".stats_pullout", func(e *colly.HTMLElement) {
-		*statsPulloutElement = e
+		statsPulloutElement = e
+	})
+
+	c.OnScraped(func(r *colly.Response) {
+		if statsPulloutElement == nil {
+			return
+		}
+
+		careerStatsConfig := GetCareerStatsConfig(sport, player.PlayerInformation)
+
+		winsOrSavesValue := 0
+		var winsOrSavesLabel string
+
+		var careerStats []string
+		for _, statConfig := range careerStatsConfig.Stats {
+			statValue := strings.TrimSpace(statsPulloutElement.DOM.Find(statConfig.HTMLPath).Text())
+			if statValue != "" {
+				// Logic to correctly display the higher of Wins or Saves (baseball pitcher-only)
+				if statConfig.StatLabel == "W" || statConfig.StatLabel == "SV" {
+					intStatValue, err := strconv.Atoi(statValue)
+					if err != nil {
+						fmt.Printf("Warning: Failed to convert %s value '%s' to integer: %v\n", statConfig.StatLabel, statValue, err)
+						continue
+					}
+					if intStatValue > winsOrSavesValue {
+						winsOrSavesValue = intStatValue
+						winsOrSavesLabel = statConfig.StatLabel
+					}
+				} else {
+					careerStats = append(careerStats, fmt.Sprintf("%s %s", statValue, statConfig.StatLabel))
+				}
+			}
+		}
+
+		if winsOrSavesLabel != "" {
+			careerStats = append([]string{fmt.Sprintf("%d %s", winsOrSavesValue, winsOrSavesLabel)}, careerStats...)
+		}
+
+		if len(careerStats) > 0 {
+			player.CareerStats = strings.Join(careerStats, ", ")
+		}
 	})
 }
 
 // scrapePersonalAchievements extracts awards, honors, and championships
-func scrapePersonalAchievements(c *colly.Collector, rawAchievements *[]string) {
+func scrapePersonalAchievements(c *colly.Collector, player *Player, sport string) {
+	var rawAchievements []string
 	c.OnHTML("ul#bling li", func(e *colly.HTMLElement) {
 		achievement := strings.TrimSpace(e.Text)
 		if achievement != "" {
-			*rawAchievements = append(*rawAchievements, achievement)
+			rawAchievements = append(rawAchievements, achievement)
+		}
+	})
+
+	c.OnScraped(func(r *colly.Response) {
+		if len(rawAchievements) > 0 {
+			player.PersonalAchievements = ProcessAchievements(sport, rawAchievements, ClueMaxLength)
+		} else {
+			player.PersonalAchievements = "N/A"
 		}
 	})
 }
 
 // scrapePhoto extracts the player photo URL from multiple possible selectors
-func scrapePhoto(c *colly.Collector, player *Player) {
+func scrapePhoto(c *colly.Collector, player *Player, sport string) {
 	c.OnHTML("div#meta", func(e *colly.HTMLElement) {
 		mediaItemFind := e.DOM.Find("div.media-item")
 		if mediaItemFind.Length() > 0 {
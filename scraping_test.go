@@ -1,9 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -104,6 +107,20 @@ func TestParseAndValidateScrapeParams(t *testing.T) {
 			expectedCode:   ErrorMissingRequiredParameter,
 			shouldSucceed:  false,
 		},
+		{
+			name:           "valid params with dryRun",
+			queryParams:    "sport=basketball&playDate=2024-01-15&name=LeBron+James&dryRun=true",
+			expectedStatus: 0,
+			expectedCode:   "",
+			shouldSucceed:  true,
+		},
+		{
+			name:           "invalid dryRun parameter",
+			queryParams:    "sport=basketball&playDate=2024-01-15&name=LeBron+James&dryRun=maybe",
+			expectedStatus: 400,
+			expectedCode:   ErrorInvalidParameter,
+			shouldSucceed:  false,
+		},
 		{
 			name:           "valid params with theme",
 			queryParams:    "sport=football&playDate=2024-09-01&name=Patrick+Mahomes&theme=dark",
@@ -212,6 +229,76 @@ func TestResolvePlayerURL(t *testing.T) {
 	}
 }
 
+// TestParsePlayerSearchName tests splitting a search result's name into candidate details
+func TestParsePlayerSearchName(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected PlayerCandidate
+	}{
+		{text: "LeBron James (2004-2025)", expected: PlayerCandidate{Name: "LeBron James", YearsActive: "2004-2025"}},
+		{text: "Josh Allen (QB) (2018-2024)", expected: PlayerCandidate{Name: "Josh Allen", Position: "QB", YearsActive: "2018-2024"}},
+		{text: "  Ken Griffey\n Jr. (1989)  ", expected: PlayerCandidate{Name: "Ken Griffey Jr.", YearsActive: "1989"}},
+		{text: "Mike Trout", expected: PlayerCandidate{Name: "Mike Trout"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := parsePlayerSearchName(tt.text); got != tt.expected {
+				t.Errorf("parsePlayerSearchName(%q) = %+v, want %+v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRespondWithScrapeError tests that ambiguous searches list their candidates
+func TestRespondWithScrapeError(t *testing.T) {
+	candidates := []PlayerCandidate{
+		{Name: "Josh Allen", Position: "QB", YearsActive: "2018-2024", SportsReferenceURL: "https://www.pro-football-reference.com/players/A/AlleJo02.htm"},
+		{Name: "Josh Allen", Position: "DE", YearsActive: "2019-2024", SportsReferenceURL: "https://www.pro-football-reference.com/players/A/AlleJo03.htm"},
+	}
+
+	tests := []struct {
+		name       string
+		err        *scrapeError
+		candidates int
+	}{
+		{
+			name:       "multiple players",
+			err:        &scrapeError{StatusCode: 400, Message: "Multiple players found", ErrorCode: ErrorMultiplePlayersFound, Candidates: candidates},
+			candidates: 2,
+		},
+		{
+			name: "other errors",
+			err:  &scrapeError{StatusCode: 400, Message: "No players found", ErrorCode: ErrorNoPlayersFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			respondWithScrapeError(c, tt.err)
+
+			var response struct {
+				Code       string            `json:"code"`
+				Candidates []PlayerCandidate `json:"candidates"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if w.Code != tt.err.StatusCode || response.Code != tt.err.ErrorCode {
+				t.Errorf("response = %d %s, want %d %s", w.Code, response.Code, tt.err.StatusCode, tt.err.ErrorCode)
+			}
+			if len(response.Candidates) != tt.candidates {
+				t.Errorf("got %d candidates, want %d", len(response.Candidates), tt.candidates)
+			}
+			if tt.candidates > 0 && response.Candidates[1] != candidates[1] {
+				t.Errorf("candidate = %+v, want %+v", response.Candidates[1], candidates[1])
+			}
+		})
+	}
+}
+
 // TestGetStatusText tests HTTP status code to text conversion
 func TestGetStatusText(t *testing.T) {
 	tests := []struct {
@@ -255,3 +342,98 @@ func TestGetStatusText(t *testing.T) {
 		})
 	}
 }
+
+// TestScrapeWarnings tests the review warnings for a scraped player
+func TestScrapeWarnings(t *testing.T) {
+	complete := Player{
+		Bio:                  "Born Dec 30, 1984 in Akron, OH",
+		PlayerInformation:    "SF, 6-9, 250lb",
+		DraftInformation:     "1st round (1st overall), 2003",
+		TeamsPlayedOn:        "CLE, MIA, LAL",
+		JerseyNumbers:        "23, 6",
+		CareerStats:          "27.1 PTS, 7.5 TRB, 7.4 AST",
+		PersonalAchievements: "4x NBA Champ, 4x MVP",
+		Photo:                "https://www.basketball-reference.com/req/lebron.jpg",
+		YearsActive:          "2003-Present",
+		Initials:             "LJ",
+		Nicknames:            "King James",
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(p *Player)
+		expected []ScrapeWarning
+	}{
+		{
+			name:     "complete player",
+			modify:   func(p *Player) {},
+			expected: []ScrapeWarning{},
+		},
+		{
+			name:   "long clue",
+			modify: func(p *Player) { p.DraftInformation = strings.Repeat("x", ClueMaxLength+1) },
+			expected: []ScrapeWarning{
+				{Tile: TileDraftInformation, Code: ScrapeWarningClueTooLong, Message: fmt.Sprintf("Clue is %d characters, over the %d character limit", ClueMaxLength+1, ClueMaxLength)},
+			},
+		},
+		{
+			name: "missing photo and achievements",
+			modify: func(p *Player) {
+				p.Photo = ""
+				p.PersonalAchievements = "N/A"
+			},
+			expected: []ScrapeWarning{
+				{Tile: TilePersonalAchievements, Code: ScrapeWarningNoAchievements, Message: "No achievements were found, so the tile reads N/A"},
+				{Tile: TilePhoto, Code: ScrapeWarningMissingPhoto, Message: "No photo was found"},
+			},
+		},
+		{
+			name: "empty tiles in display order",
+			modify: func(p *Player) {
+				p.Nicknames = ""
+				p.Bio = " "
+			},
+			expected: []ScrapeWarning{
+				{Tile: TileBio, Code: ScrapeWarningEmptyTile, Message: "Tile is empty"},
+				{Tile: TileNicknames, Code: ScrapeWarningEmptyTile, Message: "Tile is empty"},
+			},
+		},
+		{
+			name: "long photo URL",
+			modify: func(p *Player) {
+				p.Photo = "https://www.basketball-reference.com/req/" + strings.Repeat("x", ClueMaxLength) + ".jpg"
+			},
+			expected: []ScrapeWarning{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := complete
+			tt.modify(&player)
+			if got := scrapeWarnings(&player); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("scrapeWarnings() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestBuildRoundFromPlayer tests that a previewed round matches the one that would be saved
+func TestBuildRoundFromPlayer(t *testing.T) {
+	player := &Player{Name: "LeBron James", Bio: "Born in Akron"}
+	params := &scrapeParams{Sport: SportBasketball, PlayDate: "2025-11-15", Theme: "Legends", DryRun: true}
+
+	round, err := buildRoundFromPlayer(player, params)
+	if err != nil {
+		t.Fatalf("buildRoundFromPlayer() error = %v", err)
+	}
+	if round.Sport != SportBasketball || round.PlayDate != "2025-11-15" || round.Theme != "Legends" ||
+		round.Player != *player || round.Stats.Name != "LeBron James" || round.RoundID == "" {
+		t.Errorf("buildRoundFromPlayer() = %+v", round)
+	}
+
+	params.PlayDate = "11/15/2025"
+	if _, err := buildRoundFromPlayer(player, params); err == nil || err.ErrorCode != ErrorInvalidPlayDate {
+		t.Errorf("buildRoundFromPlayer() invalid playDate error = %v, want %s", err, ErrorInvalidPlayDate)
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newSessionID generates a random, unguessable game session identifier
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// buildResultFromSession derives the authoritative Result for a game session
+func buildResultFromSession(session *GameSession) Result {
+	flippedTiles := session.FlippedTiles
+	if flippedTiles == nil {
+		flippedTiles = []string{}
+	}
+
+	return Result{
+		Score:            calculateScore(session.Sport, session.IsCorrect, flippedTiles, session.IncorrectGuesses),
+		IsCorrect:        session.IsCorrect,
+		FlippedTiles:     flippedTiles,
+		IncorrectGuesses: session.IncorrectGuesses,
+	}
+}
+
+// isSessionComplete reports whether the session's round has been solved or revealed
+func isSessionComplete(session *GameSession) bool {
+	return session.IsCorrect || session.Revealed
+}
+
+// recordFlip logs a tile flip on the session. Repeat flips and flips after the round is over are ignored
+// Returns true if the session was modified
+func recordFlip(session *GameSession, tile string) bool {
+	if isSessionComplete(session) || contains(session.FlippedTiles, tile) {
+		return false
+	}
+	session.FlippedTiles = append(session.FlippedTiles, tile)
+	return true
+}
+
+// recordGuess logs a guess on the session. Guesses after the round is over are ignored
+// Returns true if the session was modified
+func recordGuess(session *GameSession, isCorrect, reveal bool) bool {
+	if isSessionComplete(session) {
+		return false
+	}
+
+	switch {
+	case isCorrect:
+		session.IsCorrect = true
+	case reveal:
+		session.Revealed = true
+	default:
+		session.IncorrectGuesses++
+	}
+	return true
+}
+
+// loadGameSession fetches a session and checks that it belongs to the caller
+// Writes the error response and returns nil if the session can't be used
+func (s *Server) loadGameSession(c *gin.Context, sessionId string) *GameSession {
+	session, err := s.db.GetGameSession(c.Request.Context(), sessionId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to retrieve game session: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return nil
+	}
+	if session == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			JSONFieldError:     StatusNotFound,
+			JSONFieldMessage:   "Game session '" + sessionId + "' not found",
+			JSONFieldCode:      ErrorSessionNotFound,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return nil
+	}
+
+	// Sessions started by a signed-in user can only be used by that user
+	if session.UserId != "" && session.UserId != getContextUserId(c) {
+		c.JSON(http.StatusForbidden, gin.H{
+			JSONFieldError:     StatusForbidden,
+			JSONFieldMessage:   "Game session does not belong to the current user",
+			JSONFieldCode:      ErrorSessionMismatch,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return nil
+	}
+
+	return session
+}
+
+// StartGameSession handles POST /v1/round/session - starts a new play-through of a round
+func (s *Server) StartGameSession(c *gin.Context) {
+	sport := c.Query(QueryParamSport)
+	playDate := c.Query(QueryParamPlayDate)
+
+	if sport == "" || playDate == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Sport and playDate parameters are required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	round, err := s.db.GetRound(c.Request.Context(), sport, playDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to retrieve round: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if round == nil || !isRoundReleased(c, playDate) {
+		c.JSON(http.StatusNotFound, gin.H{
+			JSONFieldError:     StatusNotFound,
+			JSONFieldMessage:   "Round not found for sport '" + sport + "' on date '" + playDate + "'",
+			JSONFieldCode:      ErrorRoundNotFound,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	sessionId, err := newSessionID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to start game session: " + err.Error(),
+			JSONFieldCode:      ErrorConfigurationError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	session := &GameSession{
+		SessionID:    sessionId,
+		Sport:        sport,
+		PlayDate:     playDate,
+		UserId:       getContextUserId(c),
+		FlippedTiles: []string{},
+	}
+
+	err = s.db.CreateGameSession(c.Request.Context(), session)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to create game session: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetGameSession handles GET /v1/round/session - returns the current state of a game session
+func (s *Server) GetGameSession(c *gin.Context) {
+	sessionId := c.Query(QueryParamSessionId)
+	if sessionId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "sessionId parameter is required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	session := s.loadGameSession(c, sessionId)
+	if session == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// FlipTile handles POST /v1/round/flip - reveals a single tile and logs the flip on the session
+func (s *Server) FlipTile(c *gin.Context) {
+	sessionId := c.Query(QueryParamSessionId)
+	tile := c.Query(QueryParamTile)
+
+	if sessionId == "" || tile == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "sessionId and tile parameters are required",
+			JSONFieldCode:      ErrorMissingRequiredParameter,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	if !contains(AllTiles(), tile) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			JSONFieldError:     StatusBadRequest,
+			JSONFieldMessage:   "Invalid tile '" + tile + "'",
+			JSONFieldCode:      ErrorInvalidTile,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	session := s.loadGameSession(c, sessionId)
+	if session == nil {
+		return
+	}
+
+	round, err := s.db.GetRound(c.Request.Context(), session.Sport, session.PlayDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			JSONFieldError:     StatusInternalServerError,
+			JSONFieldMessage:   "Failed to retrieve round: " + err.Error(),
+			JSONFieldCode:      ErrorDatabaseError,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+	if round == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			JSONFieldError:     StatusNotFound,
+			JSONFieldMessage:   "Round not found for sport '" + session.Sport + "' on date '" + session.PlayDate + "'",
+			JSONFieldCode:      ErrorRoundNotFound,
+			JSONFieldTimestamp: time.Now(),
+		})
+		return
+	}
+
+	value, _ := getTileValue(&round.Player, tile)
+
+	if recordFlip(session, tile) {
+		err = s.db.UpdateGameSession(c.Request.Context(), session)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				JSONFieldError:     StatusInternalServerError,
+				JSONFieldMessage:   "Failed to update game session: " + err.Error(),
+				JSONFieldCode:      ErrorDatabaseError,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, TileResponse{
+		SessionID: session.SessionID,
+		Tile:      tile,
+		Value:     value,
+	})
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewSessionID(t *testing.T) {
+	id1, err := newSessionID()
+	if err != nil {
+		t.Fatalf("newSessionID() returned error: %v", err)
+	}
+	id2, err := newSessionID()
+	if err != nil {
+		t.Fatalf("newSessionID() returned error: %v", err)
+	}
+
+	if len(id1) != 32 {
+		t.Errorf("Expected session id of length 32, got %d (%s)", len(id1), id1)
+	}
+	if id1 == id2 {
+		t.Errorf("Expected unique session ids, got %s twice", id1)
+	}
+}
+
+func TestRecordFlip(t *testing.T) {
+	session := &GameSession{}
+
+	if !recordFlip(session, TileBio) {
+		t.Error("Expected first flip to be recorded")
+	}
+	if recordFlip(session, TileBio) {
+		t.Error("Expected repeat flip to be ignored")
+	}
+	if !recordFlip(session, TilePhoto) {
+		t.Error("Expected second tile flip to be recorded")
+	}
+
+	if !reflect.DeepEqual(session.FlippedTiles, []string{TileBio, TilePhoto}) {
+		t.Errorf("Expected flipped tiles in order, got %v", session.FlippedTiles)
+	}
+
+	// Flips after the round is solved are not logged
+	session.IsCorrect = true
+	if recordFlip(session, TileInitials) {
+		t.Error("Expected flip after completion to be ignored")
+	}
+	if len(session.FlippedTiles) != 2 {
+		t.Errorf("Expected 2 flipped tiles, got %d", len(session.FlippedTiles))
+	}
+}
+
+func TestRecordGuess(t *testing.T) {
+	session := &GameSession{}
+
+	recordGuess(session, false, false)
+	recordGuess(session, false, false)
+	if session.IncorrectGuesses != 2 {
+		t.Errorf("Expected 2 incorrect guesses, got %d", session.IncorrectGuesses)
+	}
+
+	if !recordGuess(session, true, false) {
+		t.Error("Expected correct guess to be recorded")
+	}
+	if !session.IsCorrect {
+		t.Error("Expected session to be marked correct")
+	}
+
+	// Guesses after completion are ignored
+	if recordGuess(session, false, false) {
+		t.Error("Expected guess after completion to be ignored")
+	}
+	if session.IncorrectGuesses != 2 {
+		t.Errorf("Expected incorrect guesses to stay at 2, got %d", session.IncorrectGuesses)
+	}
+
+	// Revealing ends the round without marking it correct
+	revealed := &GameSession{}
+	recordGuess(revealed, false, true)
+	if !revealed.Revealed || revealed.IsCorrect || revealed.IncorrectGuesses != 0 {
+		t.Errorf("Expected revealed session, got %+v", revealed)
+	}
+}
+
+func TestBuildResultFromSession(t *testing.T) {
+	session := &GameSession{
+		Sport:            SportBasketball,
+		FlippedTiles:     []string{TileYearsActive, TileTeamsPlayedOn, TileJerseyNumbers},
+		IncorrectGuesses: 1,
+		IsCorrect:        true,
+	}
+
+	result := buildResultFromSession(session)
+
+	if result.Score != 75 {
+		t.Errorf("Expected score 75, got %d", result.Score)
+	}
+	if !result.IsCorrect {
+		t.Error("Expected result to be correct")
+	}
+	if result.IncorrectGuesses != 1 {
+		t.Errorf("Expected 1 incorrect guess, got %d", result.IncorrectGuesses)
+	}
+	if !reflect.DeepEqual(result.FlippedTiles, session.FlippedTiles) {
+		t.Errorf("Expected flipped tiles %v, got %v", session.FlippedTiles, result.FlippedTiles)
+	}
+
+	// Sessions without flips produce an empty (not nil) slice
+	empty := buildResultFromSession(&GameSession{Revealed: true})
+	if empty.FlippedTiles == nil || empty.Score != 0 {
+		t.Errorf("Expected empty flipped tiles and zero score, got %+v", empty)
+	}
+}
+
+// TestHandleSessionEndpoints tests the game session handlers' input validation
+func TestHandleSessionEndpoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		handler        func(s *Server, c *gin.Context)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "start session missing playDate",
+			method:         http.MethodPost,
+			path:           "/v1/round/session?sport=basketball",
+			handler:        (*Server).StartGameSession,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "MISSING_REQUIRED_PARAMETER",
+		},
+		{
+			name:           "get session missing sessionId",
+			method:         http.MethodGet,
+			path:           "/v1/round/session",
+			handler:        (*Server).GetGameSession,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "MISSING_REQUIRED_PARAMETER",
+		},
+		{
+			name:           "flip missing tile",
+			method:         http.MethodPost,
+			path:           "/v1/round/flip?sessionId=abc",
+			handler:        (*Server).FlipTile,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "MISSING_REQUIRED_PARAMETER",
+		},
+		{
+			name:           "flip invalid tile",
+			method:         http.MethodPost,
+			path:           "/v1/round/flip?sessionId=abc&tile=name",
+			handler:        (*Server).FlipTile,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_TILE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, tt.path, bytes.NewReader(nil))
+
+			tt.handler(getTestServer(), c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedCode != "" {
+				var errResp map[string]interface{}
+				json.NewDecoder(w.Body).Decode(&errResp)
+				if code, ok := errResp["code"].(string); !ok || code != tt.expectedCode {
+					t.Errorf("Expected error code %s, got %v", tt.expectedCode, errResp["code"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestSQLiteStore opens a migrated in-memory SQLite store that is closed when the test ends
+func newTestSQLiteStore(t *testing.T) *SQLStore {
+	t.Helper()
+	store, err := NewSQLStore(context.Background(), StorageBackendSQLite, ":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLStore() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLStoreSQLite(t *testing.T) {
+	runStoreTests(t, func(t *testing.T) Store {
+		return newTestSQLiteStore(t)
+	})
+}
+
+func TestSQLStoreMigrate(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+
+	// Running migrations again must be a no-op
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate() second run error = %v", err)
+	}
+
+	var count, latest int
+	if err := store.db.QueryRowContext(ctx, "SELECT COUNT(*), MAX(version) FROM schema_migrations").Scan(&count, &latest); err != nil {
+		t.Fatalf("failed to read schema_migrations: %v", err)
+	}
+	if count != len(sqlMigrations) || latest != sqlMigrations[len(sqlMigrations)-1].Version {
+		t.Errorf("schema_migrations has %d rows (latest %d), want %d (latest %d)",
+			count, latest, len(sqlMigrations), sqlMigrations[len(sqlMigrations)-1].Version)
+	}
+}
+
+func TestSQLStoreDeletesExpiredLeaderboardEntries(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLiteStore(t)
+
+	expired := LeaderboardBoardRef{Board: leaderboardWindowBoard("basketball", LeaderboardWindowWeek, "2025-01-10"), ExpiresAt: time.Now().Add(-time.Hour).Unix()}
+	if err := store.AddLeaderboardResult(ctx, []LeaderboardBoardRef{expired}, "alice", "alice", &Result{Score: 50}); err != nil {
+		t.Fatalf("AddLeaderboardResult() error = %v", err)
+	}
+	if entry, _ := store.GetLeaderboardEntry(ctx, expired.Board, "alice"); entry != nil {
+		t.Errorf("GetLeaderboardEntry() on an expired board = %+v, want it deleted", entry)
+	}
+
+	current := []LeaderboardBoardRef{
+		{Board: leaderboardBoard("basketball", LeaderboardBoardAllTime)},
+		{Board: leaderboardWindowBoard("basketball", LeaderboardWindowWeek, "2025-01-11"), ExpiresAt: time.Now().Add(time.Hour).Unix()},
+	}
+	if err := store.AddLeaderboardResult(ctx, current, "alice", "alice", &Result{Score: 50}); err != nil {
+		t.Fatalf("AddLeaderboardResult() error = %v", err)
+	}
+	for _, ref := range current {
+		if entry, _ := store.GetLeaderboardEntry(ctx, ref.Board, "alice"); entry == nil {
+			t.Errorf("GetLeaderboardEntry(%s) = nil, want the entry kept until it expires", ref.Board)
+		}
+	}
+}
+
+func TestSQLStoreRebind(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  sqlDialect
+		query    string
+		expected string
+	}{
+		{
+			name:     "sqlite keeps question marks",
+			dialect:  sqliteDialect,
+			query:    "SELECT data FROM rounds WHERE sport = ? AND play_date = ?",
+			expected: "SELECT data FROM rounds WHERE sport = ? AND play_date = ?",
+		},
+		{
+			name:     "postgres numbers placeholders",
+			dialect:  postgresDialect,
+			query:    "SELECT data FROM rounds WHERE sport = ? AND play_date = ?",
+			expected: "SELECT data FROM rounds WHERE sport = $1 AND play_date = $2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &SQLStore{dialect: tt.dialect}
+			if got := store.rebind(tt.query); got != tt.expected {
+				t.Errorf("rebind() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewSQLStoreUnknownBackend(t *testing.T) {
+	if _, err := NewSQLStore(context.Background(), StorageBackendMemory, ""); err == nil {
+		t.Error("NewSQLStore() expected error for a non-SQL backend")
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -74,38 +76,34 @@ func getPlayerInitials(name string) string {
 	return initials.String()
 }
 
+// applyRoundResult adds a submitted result to a round's stats
+// Archive plays only count toward ArchivePlays so the live stats reflect the day the round was played
+func applyRoundResult(stats *RoundStats, result *Result) {
+	if result.Archive {
+		stats.ArchivePlays++
+		return
+	}
+	updateStatsWithResult(&stats.Stats, result)
+}
+
 // updateStatsWithResult updates statistics with a submitted result
 // Works with both RoundStats and SportStats since they both embed Stats
 func updateStatsWithResult(stats *Stats, result *Result) {
-	// Update total plays
-	stats.TotalPlays++
-
-	// Calculate current correct count from previous percentage
-	correctCount := int(stats.PercentageCorrect * float64(stats.TotalPlays-1) / 100)
+	backfillStatsCounters(stats)
 
-	// Update correct count and average correct score if this result is correct
+	// Update raw counters
+	stats.TotalPlays++
 	if result.IsCorrect {
-		correctCount++
-
-		// Update average correct score
-		totalCorrectScore := stats.AverageCorrectScore * float64(correctCount-1)
-		totalCorrectScore += float64(result.Score)
-		stats.AverageCorrectScore = totalCorrectScore / float64(correctCount)
+		stats.CorrectCount++
+		stats.TotalCorrectScore += result.Score
 	}
-
-	// Update percentage correct (whether result was correct or incorrect)
-	stats.PercentageCorrect = float64(correctCount) * 100 / float64(stats.TotalPlays)
+	stats.TotalTileFlips += len(result.FlippedTiles)
 
 	// Update highest score
 	if result.Score > stats.HighestScore {
 		stats.HighestScore = result.Score
 	}
 
-	// Update average number of tile flips
-	totalTileFlips := stats.AverageNumberOfTileFlips * float64(stats.TotalPlays-1)
-	totalTileFlips += float64(len(result.FlippedTiles))
-	stats.AverageNumberOfTileFlips = totalTileFlips / float64(stats.TotalPlays)
-
 	// Track tile flips
 	if len(result.FlippedTiles) > 0 {
 		// Track first tile flipped
@@ -119,10 +117,249 @@ func updateStatsWithResult(stats *Stats, result *Result) {
 			incrementTileTracker(&stats.MostTileFlippedTracker, tile)
 		}
 
-		// Recalculate most/least common tiles
-		stats.MostCommonFirstTileFlipped = findMostCommonTile(&stats.FirstTileFlippedTracker)
-		stats.MostCommonLastTileFlipped = findMostCommonTile(&stats.LastTileFlippedTracker)
-		stats.MostCommonTileFlipped = findMostCommonTile(&stats.MostTileFlippedTracker)
-		stats.LeastCommonTileFlipped = findLeastCommonTile(&stats.MostTileFlippedTracker)
+		// Track the last tile flipped by outcome
+		lastTile := result.FlippedTiles[len(result.FlippedTiles)-1]
+		if result.IsCorrect {
+			incrementTileTracker(&stats.LastTileFlippedCorrectTracker, lastTile)
+		} else {
+			incrementTileTracker(&stats.LastTileFlippedIncorrectTracker, lastTile)
+		}
+	}
+
+	// Track the results and correct score for each tile flipped, once per result
+	for _, tile := range distinctTiles(result.FlippedTiles) {
+		incrementTileTracker(&stats.TileFlippedResultsTracker, tile)
+		if result.IsCorrect {
+			if stats.TileFlippedScoreTracker == nil {
+				stats.TileFlippedScoreTracker = map[string]int{}
+			}
+			stats.TileFlippedScoreTracker[tile] += result.Score
+		}
+	}
+
+	// Track the distributions of results
+	if result.IsCorrect {
+		incrementDistribution(&stats.ScoreDistribution, scoreBucket(result.Score))
+	}
+	incrementDistribution(&stats.TilesFlippedDistribution, tilesFlippedBucket(result.FlippedTiles))
+	incrementDistribution(&stats.IncorrectGuessesDistribution, incorrectGuessesBucket(result.IncorrectGuesses))
+
+	computeDerivedStats(stats)
+}
+
+// computeDerivedStats recalculates the percentages, averages and most/least common tiles from the raw counters
+// Called on read so stats updated with atomic counters (see DB.ApplyRoundResult) are always consistent
+func computeDerivedStats(stats *Stats) {
+	backfillStatsCounters(stats)
+
+	stats.PercentageCorrect = 0
+	stats.AverageNumberOfTileFlips = 0
+	if stats.TotalPlays > 0 {
+		stats.PercentageCorrect = float64(stats.CorrectCount) * 100 / float64(stats.TotalPlays)
+		stats.AverageNumberOfTileFlips = float64(stats.TotalTileFlips) / float64(stats.TotalPlays)
+	}
+
+	stats.AverageCorrectScore = 0
+	if stats.CorrectCount > 0 {
+		stats.AverageCorrectScore = float64(stats.TotalCorrectScore) / float64(stats.CorrectCount)
+	}
+
+	// Leave the most/least common tiles empty until a tile has been flipped
+	if stats.TotalTileFlips > 0 {
+		stats.MostCommonFirstTileFlipped = findMostCommonTile(stats.FirstTileFlippedTracker)
+		stats.MostCommonLastTileFlipped = findMostCommonTile(stats.LastTileFlippedTracker)
+		stats.MostCommonTileFlipped = findMostCommonTile(stats.MostTileFlippedTracker)
+		stats.LeastCommonTileFlipped = findLeastCommonTile(stats.MostTileFlippedTracker)
+	}
+
+	fillDistributionBuckets(&stats.FirstTileFlippedTracker, AllTiles())
+	fillDistributionBuckets(&stats.LastTileFlippedTracker, AllTiles())
+	fillDistributionBuckets(&stats.MostTileFlippedTracker, AllTiles())
+	fillDistributionBuckets(&stats.LastTileFlippedCorrectTracker, AllTiles())
+	fillDistributionBuckets(&stats.LastTileFlippedIncorrectTracker, AllTiles())
+	fillDistributionBuckets(&stats.TileFlippedResultsTracker, AllTiles())
+	fillDistributionBuckets(&stats.TileFlippedScoreTracker, AllTiles())
+	fillDistributionBuckets(&stats.ScoreDistribution, scoreBuckets())
+	fillDistributionBuckets(&stats.TilesFlippedDistribution, tilesFlippedBuckets())
+	fillDistributionBuckets(&stats.IncorrectGuessesDistribution, incorrectGuessesBuckets())
+}
+
+// computeRoundStats derives a round's stats from its raw counters, including the difficulty rating
+func computeRoundStats(round *Round) {
+	computeDerivedStats(&round.Stats.Stats)
+	round.Stats.Difficulty = roundDifficulty(round.Sport, &round.Stats.Stats)
+}
+
+// newRoundSummary returns the list view of a round, rated for difficulty
+func newRoundSummary(round *Round) *RoundSummary {
+	computeRoundStats(round)
+	return &RoundSummary{
+		RoundID:    round.RoundID,
+		Sport:      round.Sport,
+		PlayDate:   round.PlayDate,
+		Difficulty: round.Stats.Difficulty,
+	}
+}
+
+// newRoundSummaries returns the list view of each round, in the same order
+func newRoundSummaries(rounds []*Round) []*RoundSummary {
+	var summaries []*RoundSummary
+	for _, round := range rounds {
+		summaries = append(summaries, newRoundSummary(round))
+	}
+	return summaries
+}
+
+// roundDifficulty rates how hard a round was from 0 (everyone solved it instantly) to 100
+// It weighs the miss rate, the points lost by correct guesses, the share of tiles flipped and the average number
+// of wrong guesses, each scaled to 0-1. Points lost are measured against what flipping every tile costs in the
+// round's sport rather than against MaxScore, so a sport whose tiles cost more doesn't rate harder for the same play
+// Returns nil until the round has RoundDifficultyMinPlays plays. Call computeDerivedStats first
+func roundDifficulty(sport string, stats *Stats) *float64 {
+	if stats.TotalPlays < RoundDifficultyMinPlays {
+		return nil
+	}
+
+	const (
+		missWeight       = 0.4
+		scoreLossWeight  = 0.25
+		tileFlipsWeight  = 0.2
+		wrongGuessWeight = 0.15
+	)
+
+	// Wrong guess penalties are included, so a round solved after many wrong guesses can reach the cap
+	scoreLoss := 1.0 // nobody solved it
+	cfg := GetScoringConfig(sport)
+	if totalTileCost := cfg.totalTileCost(); stats.CorrectCount > 0 && totalTileCost > 0 {
+		scoreLoss = (float64(cfg.MaxScore) - stats.AverageCorrectScore) / float64(totalTileCost)
+	}
+
+	weighted := missWeight*clampUnit(1-stats.PercentageCorrect/100) +
+		scoreLossWeight*clampUnit(scoreLoss) +
+		tileFlipsWeight*clampUnit(stats.AverageNumberOfTileFlips/float64(len(AllTiles())))
+	weights := missWeight + scoreLossWeight + tileFlipsWeight
+
+	// Rounds played before wrong guesses were bucketed are rated without them
+	guesses, results := 0, 0
+	for count := 0; count <= MaxIncorrectGuessesDistribution; count++ {
+		n := stats.IncorrectGuessesDistribution[incorrectGuessesBucket(count)]
+		guesses += count * n
+		results += n
+	}
+	if results > 0 {
+		averageWrongGuesses := float64(guesses) / float64(results)
+		weighted += wrongGuessWeight * clampUnit(averageWrongGuesses/MaxIncorrectGuessesDistribution)
+		weights += wrongGuessWeight
+	}
+
+	difficulty := math.Round(weighted/weights*1000) / 10
+	return &difficulty
+}
+
+// clampUnit limits a value to the range 0-1
+func clampUnit(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
+
+// scoreBucket returns the score distribution bucket for a correct result's score
+// Buckets are named after their lower bound, so 85 falls in "80" and a perfect 100 gets its own bucket
+func scoreBucket(score int) string {
+	if score < 0 {
+		score = 0
+	}
+	return strconv.Itoa(score / ScoreDistributionBucketSize * ScoreDistributionBucketSize)
+}
+
+// scoreBuckets returns every score distribution bucket from 0 up to the highest possible score
+func scoreBuckets() []string {
+	maxScore := 0
+	for _, sport := range AllSports() {
+		maxScore = max(maxScore, GetScoringConfig(sport).MaxScore)
+	}
+
+	var buckets []string
+	for score := 0; score <= maxScore; score += ScoreDistributionBucketSize {
+		buckets = append(buckets, scoreBucket(score))
+	}
+	return buckets
+}
+
+// tilesFlippedBucket returns the tiles flipped distribution bucket for a result
+// Counts each recognized tile once, so the buckets run from "0" to one per tile
+func tilesFlippedBucket(flippedTiles []string) string {
+	return strconv.Itoa(len(distinctTiles(flippedTiles)))
+}
+
+// distinctTiles returns the recognized tiles in flippedTiles, each once, in the order first flipped
+func distinctTiles(flippedTiles []string) []string {
+	var tiles []string
+	for _, tile := range flippedTiles {
+		if contains(AllTiles(), tile) && !contains(tiles, tile) {
+			tiles = append(tiles, tile)
+		}
+	}
+	return tiles
+}
+
+// tilesFlippedBuckets returns every tiles flipped distribution bucket
+func tilesFlippedBuckets() []string {
+	buckets := make([]string, 0, len(AllTiles())+1)
+	for count := 0; count <= len(AllTiles()); count++ {
+		buckets = append(buckets, strconv.Itoa(count))
+	}
+	return buckets
+}
+
+// incorrectGuessesBucket returns the incorrect guesses distribution bucket for a result
+// Counts of MaxIncorrectGuessesDistribution or more share the last bucket, e.g. "5+"
+func incorrectGuessesBucket(incorrectGuesses int) string {
+	if incorrectGuesses >= MaxIncorrectGuessesDistribution {
+		return strconv.Itoa(MaxIncorrectGuessesDistribution) + "+"
+	}
+	return strconv.Itoa(max(incorrectGuesses, 0))
+}
+
+// incorrectGuessesBuckets returns every incorrect guesses distribution bucket
+func incorrectGuessesBuckets() []string {
+	buckets := make([]string, 0, MaxIncorrectGuessesDistribution+1)
+	for count := 0; count <= MaxIncorrectGuessesDistribution; count++ {
+		buckets = append(buckets, incorrectGuessesBucket(count))
+	}
+	return buckets
+}
+
+// incrementDistribution adds one to a distribution bucket, creating the distribution if needed
+func incrementDistribution(distribution *map[string]int, bucket string) {
+	if *distribution == nil {
+		*distribution = map[string]int{}
+	}
+	(*distribution)[bucket]++
+}
+
+// fillDistributionBuckets adds any missing buckets to a distribution or tile tracker with a count of 0
+// Keeps buckets outside the list, e.g. ones recorded before the scoring config or tile registry changed
+func fillDistributionBuckets(distribution *map[string]int, buckets []string) {
+	if *distribution == nil {
+		*distribution = make(map[string]int, len(buckets))
+	}
+	for _, bucket := range buckets {
+		if _, ok := (*distribution)[bucket]; !ok {
+			(*distribution)[bucket] = 0
+		}
+	}
+}
+
+// backfillStatsCounters reconstructs the raw counters for stats saved before they were tracked
+// Derived values are always computed from the counters, so a non-zero average with a zero counter
+// can only mean the counter is missing
+func backfillStatsCounters(stats *Stats) {
+	if stats.CorrectCount == 0 && stats.PercentageCorrect > 0 {
+		stats.CorrectCount = int(math.Round(stats.PercentageCorrect * float64(stats.TotalPlays) / 100))
+	}
+	if stats.TotalCorrectScore == 0 && stats.AverageCorrectScore > 0 {
+		stats.TotalCorrectScore = int(math.Round(stats.AverageCorrectScore * float64(stats.CorrectCount)))
+	}
+	if stats.TotalTileFlips == 0 && stats.AverageNumberOfTileFlips > 0 {
+		stats.TotalTileFlips = int(math.Round(stats.AverageNumberOfTileFlips * float64(stats.TotalPlays)))
 	}
 }
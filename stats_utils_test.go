@@ -45,24 +45,24 @@ func TestUpdateStatsWithResult_FirstPlay(t *testing.T) {
 		t.Errorf("Expected MostCommonLastTileFlipped to be %s, got %s", TileCareerStats, stats.MostCommonLastTileFlipped)
 	}
 
-	if stats.FirstTileFlippedTracker.Bio != 1 {
-		t.Errorf("Expected FirstTileFlippedTracker.Bio to be 1, got %d", stats.FirstTileFlippedTracker.Bio)
+	if stats.FirstTileFlippedTracker[TileBio] != 1 {
+		t.Errorf("Expected FirstTileFlippedTracker[TileBio] to be 1, got %d", stats.FirstTileFlippedTracker[TileBio])
 	}
 
-	if stats.LastTileFlippedTracker.CareerStats != 1 {
-		t.Errorf("Expected LastTileFlippedTracker.CareerStats to be 1, got %d", stats.LastTileFlippedTracker.CareerStats)
+	if stats.LastTileFlippedTracker[TileCareerStats] != 1 {
+		t.Errorf("Expected LastTileFlippedTracker[TileCareerStats] to be 1, got %d", stats.LastTileFlippedTracker[TileCareerStats])
 	}
 
-	if stats.MostTileFlippedTracker.Bio != 1 {
-		t.Errorf("Expected MostTileFlippedTracker.Bio to be 1, got %d", stats.MostTileFlippedTracker.Bio)
+	if stats.MostTileFlippedTracker[TileBio] != 1 {
+		t.Errorf("Expected MostTileFlippedTracker[TileBio] to be 1, got %d", stats.MostTileFlippedTracker[TileBio])
 	}
 
-	if stats.MostTileFlippedTracker.PlayerInformation != 1 {
-		t.Errorf("Expected MostTileFlippedTracker.PlayerInformation to be 1, got %d", stats.MostTileFlippedTracker.PlayerInformation)
+	if stats.MostTileFlippedTracker[TilePlayerInformation] != 1 {
+		t.Errorf("Expected MostTileFlippedTracker[TilePlayerInformation] to be 1, got %d", stats.MostTileFlippedTracker[TilePlayerInformation])
 	}
 
-	if stats.MostTileFlippedTracker.CareerStats != 1 {
-		t.Errorf("Expected MostTileFlippedTracker.CareerStats to be 1, got %d", stats.MostTileFlippedTracker.CareerStats)
+	if stats.MostTileFlippedTracker[TileCareerStats] != 1 {
+		t.Errorf("Expected MostTileFlippedTracker[TileCareerStats] to be 1, got %d", stats.MostTileFlippedTracker[TileCareerStats])
 	}
 }
 
@@ -273,27 +273,27 @@ func TestUpdateStatsWithResult_TileTrackingAccuracy(t *testing.T) {
 	updateStatsWithResult(stats, result2)
 
 	// First tile: Bio appears 2 times, PlayerInfo appears 0 times
-	if stats.FirstTileFlippedTracker.Bio != 2 {
-		t.Errorf("Expected FirstTileFlippedTracker.Bio to be 2, got %d", stats.FirstTileFlippedTracker.Bio)
+	if stats.FirstTileFlippedTracker[TileBio] != 2 {
+		t.Errorf("Expected FirstTileFlippedTracker[TileBio] to be 2, got %d", stats.FirstTileFlippedTracker[TileBio])
 	}
 
 	// Last tile: CareerStats 1 time, Bio 1 time
-	if stats.LastTileFlippedTracker.CareerStats != 1 {
-		t.Errorf("Expected LastTileFlippedTracker.CareerStats to be 1, got %d", stats.LastTileFlippedTracker.CareerStats)
+	if stats.LastTileFlippedTracker[TileCareerStats] != 1 {
+		t.Errorf("Expected LastTileFlippedTracker[TileCareerStats] to be 1, got %d", stats.LastTileFlippedTracker[TileCareerStats])
 	}
 
-	if stats.LastTileFlippedTracker.Bio != 1 {
-		t.Errorf("Expected LastTileFlippedTracker.Bio to be 1, got %d", stats.LastTileFlippedTracker.Bio)
+	if stats.LastTileFlippedTracker[TileBio] != 1 {
+		t.Errorf("Expected LastTileFlippedTracker[TileBio] to be 1, got %d", stats.LastTileFlippedTracker[TileBio])
 	}
 
 	// Most flipped overall: Bio appears 3 times (1 in first result, 2 in second result)
-	if stats.MostTileFlippedTracker.Bio != 3 {
-		t.Errorf("Expected MostTileFlippedTracker.Bio to be 3, got %d", stats.MostTileFlippedTracker.Bio)
+	if stats.MostTileFlippedTracker[TileBio] != 3 {
+		t.Errorf("Expected MostTileFlippedTracker[TileBio] to be 3, got %d", stats.MostTileFlippedTracker[TileBio])
 	}
 
 	// PlayerInfo appears 1 time
-	if stats.MostTileFlippedTracker.PlayerInformation != 1 {
-		t.Errorf("Expected MostTileFlippedTracker.PlayerInformation to be 1, got %d", stats.MostTileFlippedTracker.PlayerInformation)
+	if stats.MostTileFlippedTracker[TilePlayerInformation] != 1 {
+		t.Errorf("Expected MostTileFlippedTracker[TilePlayerInformation] to be 1, got %d", stats.MostTileFlippedTracker[TilePlayerInformation])
 	}
 
 	// Most common first tile should be Bio
@@ -392,31 +392,31 @@ func TestUpdateStatsWithResult_AllTileTypes(t *testing.T) {
 	updateStatsWithResult(stats, result)
 
 	// Verify all tiles were tracked
-	if stats.MostTileFlippedTracker.Bio != 1 {
+	if stats.MostTileFlippedTracker[TileBio] != 1 {
 		t.Errorf("Expected Bio to be tracked")
 	}
-	if stats.MostTileFlippedTracker.PlayerInformation != 1 {
+	if stats.MostTileFlippedTracker[TilePlayerInformation] != 1 {
 		t.Errorf("Expected PlayerInformation to be tracked")
 	}
-	if stats.MostTileFlippedTracker.DraftInformation != 1 {
+	if stats.MostTileFlippedTracker[TileDraftInformation] != 1 {
 		t.Errorf("Expected DraftInformation to be tracked")
 	}
-	if stats.MostTileFlippedTracker.TeamsPlayedOn != 1 {
+	if stats.MostTileFlippedTracker[TileTeamsPlayedOn] != 1 {
 		t.Errorf("Expected TeamsPlayedOn to be tracked")
 	}
-	if stats.MostTileFlippedTracker.JerseyNumbers != 1 {
+	if stats.MostTileFlippedTracker[TileJerseyNumbers] != 1 {
 		t.Errorf("Expected JerseyNumbers to be tracked")
 	}
-	if stats.MostTileFlippedTracker.CareerStats != 1 {
+	if stats.MostTileFlippedTracker[TileCareerStats] != 1 {
 		t.Errorf("Expected CareerStats to be tracked")
 	}
-	if stats.MostTileFlippedTracker.PersonalAchievements != 1 {
+	if stats.MostTileFlippedTracker[TilePersonalAchievements] != 1 {
 		t.Errorf("Expected PersonalAchievements to be tracked")
 	}
-	if stats.MostTileFlippedTracker.Photo != 1 {
+	if stats.MostTileFlippedTracker[TilePhoto] != 1 {
 		t.Errorf("Expected Photo to be tracked")
 	}
-	if stats.MostTileFlippedTracker.YearsActive != 1 {
+	if stats.MostTileFlippedTracker[TileYearsActive] != 1 {
 		t.Errorf("Expected YearsActive to be tracked")
 	}
 
@@ -449,12 +449,15 @@ func TestUpdateStatsWithResult_ExistingStats(t *testing.T) {
 	// Start with existing stats
 	stats := &Stats{
 		TotalPlays:               5,
+		CorrectCount:             3,
+		TotalCorrectScore:        360,
+		TotalTileFlips:           12,
 		PercentageCorrect:        60.0, // 3 out of 5 correct
 		HighestScore:             150,
 		AverageCorrectScore:      120.0,
-		AverageNumberOfTileFlips: 2.5,
-		FirstTileFlippedTracker: TileFlipTracker{
-			Bio: 3,
+		AverageNumberOfTileFlips: 2.4,
+		FirstTileFlippedTracker: map[string]int{
+			TileBio: 3,
 		},
 		MostCommonFirstTileFlipped: TileBio,
 	}
@@ -489,13 +492,323 @@ func TestUpdateStatsWithResult_ExistingStats(t *testing.T) {
 		t.Errorf("Expected AverageCorrectScore to be %f, got %f", expectedAvgScore, stats.AverageCorrectScore)
 	}
 
-	// Average tile flips: (2.5 * 5 + 2) / 6 = 14.5 / 6 = 2.4166...
-	expectedAvgFlips := 14.5 / 6.0
+	// Average tile flips: (12 + 2) / 6 = 2.333...
+	expectedAvgFlips := 14.0 / 6.0
 	if stats.AverageNumberOfTileFlips != expectedAvgFlips {
 		t.Errorf("Expected AverageNumberOfTileFlips to be %f, got %f", expectedAvgFlips, stats.AverageNumberOfTileFlips)
 	}
 }
 
+func TestUpdateStatsWithResult_LegacyStatsWithoutCounters(t *testing.T) {
+	// Stats saved before raw counters were tracked only have the derived values
+	stats := &Stats{
+		TotalPlays:               3,
+		PercentageCorrect:        200.0 / 3.0, // 2 out of 3 correct
+		AverageCorrectScore:      80.0,
+		AverageNumberOfTileFlips: 3.0,
+	}
+
+	updateStatsWithResult(stats, &Result{
+		Score:        70,
+		IsCorrect:    true,
+		FlippedTiles: []string{TileBio},
+	})
+
+	if stats.CorrectCount != 3 {
+		t.Errorf("Expected CorrectCount to be 3, got %d", stats.CorrectCount)
+	}
+	if stats.TotalCorrectScore != 230 {
+		t.Errorf("Expected TotalCorrectScore to be 230, got %d", stats.TotalCorrectScore)
+	}
+	if stats.TotalTileFlips != 10 {
+		t.Errorf("Expected TotalTileFlips to be 10, got %d", stats.TotalTileFlips)
+	}
+	if stats.PercentageCorrect != 75.0 {
+		t.Errorf("Expected PercentageCorrect to be 75.0, got %f", stats.PercentageCorrect)
+	}
+}
+
+func TestComputeDerivedStats(t *testing.T) {
+	tests := []struct {
+		name                string
+		stats               Stats
+		expectedPercentage  float64
+		expectedAvgScore    float64
+		expectedAvgFlips    float64
+		expectedMostCommon  string
+		expectedFirstCommon string
+	}{
+		{
+			name:  "no plays",
+			stats: Stats{},
+		},
+		{
+			name: "counters only",
+			stats: Stats{
+				TotalPlays:              4,
+				CorrectCount:            3,
+				TotalCorrectScore:       240,
+				TotalTileFlips:          6,
+				FirstTileFlippedTracker: map[string]int{TilePhoto: 3, TileBio: 1},
+				MostTileFlippedTracker:  map[string]int{TilePhoto: 4, TileBio: 2},
+			},
+			expectedPercentage:  75.0,
+			expectedAvgScore:    80.0,
+			expectedAvgFlips:    1.5,
+			expectedMostCommon:  TilePhoto,
+			expectedFirstCommon: TilePhoto,
+		},
+		{
+			name: "stale derived values are overwritten",
+			stats: Stats{
+				TotalPlays:        2,
+				CorrectCount:      1,
+				TotalCorrectScore: 90,
+				PercentageCorrect: 100.0,
+			},
+			expectedPercentage: 50.0,
+			expectedAvgScore:   90.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := tt.stats
+			computeDerivedStats(&stats)
+
+			if stats.PercentageCorrect != tt.expectedPercentage {
+				t.Errorf("PercentageCorrect = %f, want %f", stats.PercentageCorrect, tt.expectedPercentage)
+			}
+			if stats.AverageCorrectScore != tt.expectedAvgScore {
+				t.Errorf("AverageCorrectScore = %f, want %f", stats.AverageCorrectScore, tt.expectedAvgScore)
+			}
+			if stats.AverageNumberOfTileFlips != tt.expectedAvgFlips {
+				t.Errorf("AverageNumberOfTileFlips = %f, want %f", stats.AverageNumberOfTileFlips, tt.expectedAvgFlips)
+			}
+			if stats.MostCommonTileFlipped != tt.expectedMostCommon {
+				t.Errorf("MostCommonTileFlipped = %q, want %q", stats.MostCommonTileFlipped, tt.expectedMostCommon)
+			}
+			if stats.MostCommonFirstTileFlipped != tt.expectedFirstCommon {
+				t.Errorf("MostCommonFirstTileFlipped = %q, want %q", stats.MostCommonFirstTileFlipped, tt.expectedFirstCommon)
+			}
+		})
+	}
+}
+
+func TestUpdateStatsWithResult_Distributions(t *testing.T) {
+	stats := &Stats{}
+	results := []*Result{
+		{Score: 100, IsCorrect: true, FlippedTiles: []string{}},
+		{Score: 85, IsCorrect: true, FlippedTiles: []string{TileBio, TilePhoto}, IncorrectGuesses: 1},
+		{Score: 80, IsCorrect: true, FlippedTiles: []string{TileBio, TileBio, "unknown"}},
+		{Score: 0, IsCorrect: false, FlippedTiles: []string{TileBio, TilePhoto}, IncorrectGuesses: 9},
+	}
+	for _, result := range results {
+		updateStatsWithResult(stats, result)
+	}
+
+	tests := []struct {
+		name         string
+		distribution map[string]int
+		buckets      int
+		expected     map[string]int
+	}{
+		{
+			name:         "score",
+			distribution: stats.ScoreDistribution,
+			buckets:      11,
+			expected:     map[string]int{"0": 0, "80": 2, "90": 0, "100": 1},
+		},
+		{
+			name:         "tiles flipped",
+			distribution: stats.TilesFlippedDistribution,
+			buckets:      len(AllTiles()) + 1,
+			expected:     map[string]int{"0": 1, "1": 1, "2": 2, "3": 0},
+		},
+		{
+			name:         "incorrect guesses",
+			distribution: stats.IncorrectGuessesDistribution,
+			buckets:      MaxIncorrectGuessesDistribution + 1,
+			expected:     map[string]int{"0": 2, "1": 1, "4": 0, "5+": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.distribution) != tt.buckets {
+				t.Errorf("got %d buckets, want %d: %v", len(tt.distribution), tt.buckets, tt.distribution)
+			}
+			for bucket, want := range tt.expected {
+				if got, ok := tt.distribution[bucket]; !ok || got != want {
+					t.Errorf("bucket %q = %d (present %v), want %d", bucket, got, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateStatsWithResult_TileOutcomes(t *testing.T) {
+	stats := &Stats{}
+	results := []*Result{
+		{Score: 80, IsCorrect: true, FlippedTiles: []string{TileBio, TilePhoto}},
+		{Score: 60, IsCorrect: true, FlippedTiles: []string{TilePhoto, TileBio, TilePhoto}},
+		{Score: 0, IsCorrect: false, FlippedTiles: []string{TileBio, TilePhoto}},
+		{Score: 100, IsCorrect: true, FlippedTiles: []string{}},
+	}
+	for _, result := range results {
+		updateStatsWithResult(stats, result)
+	}
+
+	tests := []struct {
+		name     string
+		tracker  map[string]int
+		expected map[string]int
+	}{
+		{name: "last tile before a correct guess", tracker: stats.LastTileFlippedCorrectTracker, expected: map[string]int{TilePhoto: 2, TileBio: 0}},
+		{name: "last tile before an incorrect guess", tracker: stats.LastTileFlippedIncorrectTracker, expected: map[string]int{TilePhoto: 1, TileBio: 0}},
+		{name: "results flipping each tile", tracker: stats.TileFlippedResultsTracker, expected: map[string]int{TilePhoto: 3, TileBio: 3, TileInitials: 0}},
+		{name: "correct score of results flipping each tile", tracker: stats.TileFlippedScoreTracker, expected: map[string]int{TilePhoto: 140, TileBio: 140}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for tile, want := range tt.expected {
+				if got := tt.tracker[tile]; got != want {
+					t.Errorf("tracker[%s] = %d, want %d", tile, got, want)
+				}
+			}
+			if len(tt.tracker) != len(AllTiles()) {
+				t.Errorf("tracker has %d tiles, want %d", len(tt.tracker), len(AllTiles()))
+			}
+		})
+	}
+}
+
+func TestRoundDifficulty(t *testing.T) {
+	guesses := func(counts ...int) map[string]int {
+		distribution := map[string]int{}
+		for i, n := range counts {
+			distribution[incorrectGuessesBucket(i)] = n
+		}
+		return distribution
+	}
+
+	tests := []struct {
+		name     string
+		sport    string
+		stats    Stats
+		expected float64
+		unrated  bool
+	}{
+		{
+			name:    "too few plays",
+			sport:   SportBasketball,
+			stats:   Stats{TotalPlays: RoundDifficultyMinPlays - 1},
+			unrated: true,
+		},
+		{
+			name:     "everyone solved it with no help",
+			sport:    SportBasketball,
+			stats:    Stats{TotalPlays: 10, CorrectCount: 10, TotalCorrectScore: 1000, IncorrectGuessesDistribution: guesses(10)},
+			expected: 0,
+		},
+		{
+			name:     "nobody solved it",
+			sport:    SportBaseball,
+			stats:    Stats{TotalPlays: 10, TotalTileFlips: 110, IncorrectGuessesDistribution: guesses(0, 0, 0, 0, 0, 10)},
+			expected: 100,
+		},
+		{
+			// miss 0.5, score loss 40/90 of the tile costs, flips 0.5, wrong guesses 0.1
+			name:  "mixed results",
+			sport: SportFootball,
+			stats: Stats{
+				TotalPlays: 20, CorrectCount: 10, TotalCorrectScore: 600, TotalTileFlips: 110,
+				IncorrectGuessesDistribution: guesses(10, 10, 0, 0, 0, 0),
+			},
+			expected: 42.6,
+		},
+		{
+			// Same as above without the wrong guesses signal: (0.4*0.5 + 0.25*40/90 + 0.2*0.5) / 0.85
+			name:     "rounds played before wrong guesses were bucketed",
+			sport:    SportFootball,
+			stats:    Stats{TotalPlays: 20, CorrectCount: 10, TotalCorrectScore: 600, TotalTileFlips: 110},
+			expected: 48.4,
+		},
+		{
+			name:  "same play in a sport with other tile costs",
+			sport: SportBaseball,
+			stats: Stats{
+				TotalPlays: 20, CorrectCount: 10, TotalCorrectScore: 600, TotalTileFlips: 110,
+				IncorrectGuessesDistribution: guesses(10, 10, 0, 0, 0, 0),
+			},
+			expected: 42.6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := tt.stats
+			computeDerivedStats(&stats)
+			got := roundDifficulty(tt.sport, &stats)
+			if tt.unrated {
+				if got != nil {
+					t.Errorf("roundDifficulty() = %v, want unrated", *got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("roundDifficulty() = unrated, want %v", tt.expected)
+			}
+			if *got != tt.expected {
+				t.Errorf("roundDifficulty() = %v, want %v", *got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDistributionBuckets(t *testing.T) {
+	tests := []struct {
+		name     string
+		bucket   string
+		expected string
+	}{
+		{name: "zero score", bucket: scoreBucket(0), expected: "0"},
+		{name: "score within a bucket", bucket: scoreBucket(59), expected: "50"},
+		{name: "perfect score", bucket: scoreBucket(100), expected: "100"},
+		{name: "negative score", bucket: scoreBucket(-5), expected: "0"},
+		{name: "no tiles", bucket: tilesFlippedBucket(nil), expected: "0"},
+		{name: "repeated and unknown tiles", bucket: tilesFlippedBucket([]string{TilePhoto, "unknown", TilePhoto, TileInitials}), expected: "2"},
+		{name: "all tiles", bucket: tilesFlippedBucket(AllTiles()), expected: "11"},
+		{name: "no incorrect guesses", bucket: incorrectGuessesBucket(0), expected: "0"},
+		{name: "last exact incorrect guesses bucket", bucket: incorrectGuessesBucket(4), expected: "4"},
+		{name: "many incorrect guesses", bucket: incorrectGuessesBucket(12), expected: "5+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.bucket != tt.expected {
+				t.Errorf("bucket = %q, want %q", tt.bucket, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeDerivedStats_FillsDistributions(t *testing.T) {
+	stats := Stats{ScoreDistribution: map[string]int{"80": 3, "95": 1}}
+	computeDerivedStats(&stats)
+
+	if stats.ScoreDistribution["80"] != 3 || stats.ScoreDistribution["0"] != 0 || len(stats.ScoreDistribution) != 12 {
+		t.Errorf("ScoreDistribution = %v, want counts kept and missing buckets added", stats.ScoreDistribution)
+	}
+	if stats.ScoreDistribution["95"] != 1 {
+		t.Errorf("ScoreDistribution = %v, want unknown buckets kept", stats.ScoreDistribution)
+	}
+	if len(stats.TilesFlippedDistribution) != len(AllTiles())+1 || len(stats.IncorrectGuessesDistribution) != MaxIncorrectGuessesDistribution+1 {
+		t.Errorf("distributions = %v, %v, want every bucket", stats.TilesFlippedDistribution, stats.IncorrectGuessesDistribution)
+	}
+}
+
 func TestGetPlayerInitials_StandardName(t *testing.T) {
 	tests := []struct {
 		name     string
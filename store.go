@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// Store is the persistence layer used by the HTTP handlers
+// Implementations must honour the same conditional-write semantics and error messages as DB:
+// "round already exists", "round not found", "user stats already exist", "user stats version conflict",
+// "game session already exists", "submission already exists", "league already exists",
+// "league member already exists", "round edit already exists", "round edit conflict" and "invalid cursor"
+type Store interface {
+	// Rounds
+	GetRound(ctx context.Context, sport, playDate string) (*Round, error)
+	CreateRound(ctx context.Context, round *Round) error
+	UpdateRound(ctx context.Context, round *Round) error
+	PatchRound(ctx context.Context, sport, playDate string, patch *RoundPatch, editor string) (*Round, error)
+	ApplyRoundResult(ctx context.Context, sport, playDate string, result *Result) error
+	DeleteRound(ctx context.Context, sport, playDate string) error
+	GetRoundsBySport(ctx context.Context, sport, startDate, endDate string) ([]*RoundSummary, error)
+	GetRoundStatsBySport(ctx context.Context, sport, startDate, endDate string) ([]*Round, error)
+
+	// User stats
+	GetUserStats(ctx context.Context, userId string) (*UserStats, error)
+	CreateUserStats(ctx context.Context, stats *UserStats) error
+	UpdateUserStats(ctx context.Context, stats *UserStats) error
+
+	// Game sessions
+	GetGameSession(ctx context.Context, sessionId string) (*GameSession, error)
+	CreateGameSession(ctx context.Context, session *GameSession) error
+	UpdateGameSession(ctx context.Context, session *GameSession) error
+
+	// Result submissions
+	GetSubmission(ctx context.Context, submissionKey string) (*Submission, error)
+	CreateSubmission(ctx context.Context, submission *Submission) error
+	DeleteSubmission(ctx context.Context, submissionKey string) error
+	UpdateSubmissionStatus(ctx context.Context, submissionKey, status string) error
+
+	// Play history
+	PutPlayHistoryEntry(ctx context.Context, entry *PlayHistoryEntry) error
+	GetPlayHistoryEntry(ctx context.Context, userId, sport, playDate string) (*PlayHistoryEntry, error)
+	GetPlayHistory(ctx context.Context, userId, sport string, limit int32, cursor string) ([]*PlayHistoryEntry, string, error)
+
+	// Leaderboards
+	AddLeaderboardResult(ctx context.Context, boards []LeaderboardBoardRef, userId, userName string, result *Result) error
+	GetLeaderboard(ctx context.Context, board string, limit int32, cursor string) ([]*LeaderboardEntry, string, error)
+	GetLeaderboardEntry(ctx context.Context, board, userId string) (*LeaderboardEntry, error)
+	GetLeaderboardEntriesForUsers(ctx context.Context, board string, userIds []string) ([]*LeaderboardEntry, error)
+
+	// Leagues
+	CreateLeague(ctx context.Context, league *League) error
+	GetLeague(ctx context.Context, leagueId string) (*League, error)
+	GetLeagueByInviteCode(ctx context.Context, inviteCode string) (*League, error)
+	AddLeagueMember(ctx context.Context, member *LeagueMember) error
+	GetLeagueMembers(ctx context.Context, leagueId string) ([]*LeagueMember, error)
+	GetUserLeagues(ctx context.Context, userId string) ([]*League, error)
+
+	// Round edit audit trail
+	CreateRoundEdit(ctx context.Context, edit *RoundEdit) error
+	GetRoundEdits(ctx context.Context, sport, playDate string) ([]*RoundEdit, error)
+}
+
+// Migrator is implemented by stores that manage their own schema and data migrations
+// Migrate must be safe to run repeatedly and concurrently with the API
+type Migrator interface {
+	Migrate(ctx context.Context) error
+}
+
+// Compile-time checks that every implementation satisfies Store
+var (
+	_ Store = (*DB)(nil)
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*SQLStore)(nil)
+
+	_ Migrator = (*DB)(nil)
+	_ Migrator = (*SQLStore)(nil)
+)
+
+// NewStore creates the Store selected by cfg.StorageBackend
+func NewStore(cfg *Config) (Store, error) {
+	switch cfg.StorageBackend {
+	case StorageBackendDynamoDB:
+		db, err := NewDB(cfg)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("DynamoDB client initialized (Rounds Table: %s, User Stats Table: %s, Game Sessions Table: %s, Submissions Table: %s, Play History Table: %s, Leaderboard Table: %s, Leagues Table: %s, League Members Table: %s, Round Edits Table: %s, Region: %s)",
+			cfg.RoundsTableName, cfg.UserStatsTableName, cfg.GameSessionsTableName, cfg.SubmissionsTableName, cfg.PlayHistoryTableName, cfg.LeaderboardTableName,
+			cfg.LeaguesTableName, cfg.LeagueMembersTableName, cfg.RoundEditsTableName, cfg.AWSRegion)
+		// SQL stores always migrate on open; DynamoDB tables are usually managed by template.yaml, so it's opt-in
+		if cfg.AutoMigrate {
+			if err := db.Migrate(context.Background()); err != nil {
+				return nil, err
+			}
+			log.Printf("DynamoDB migrations applied")
+		}
+		return db, nil
+	case StorageBackendMemory:
+		log.Printf("In-memory store initialized (data is lost when the server stops)")
+		return NewMemoryStore(), nil
+	case StorageBackendSQLite, StorageBackendPostgres:
+		store, err := NewSQLStore(context.Background(), cfg.StorageBackend, cfg.DatabaseURL)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("%s store initialized", cfg.StorageBackend)
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.StorageBackend)
+	}
+}
+
+// MigrateStore creates or upgrades the schema of the configured storage backend and applies pending data migrations
+// Backs the "migrate" command; stores without a schema have nothing to do
+func MigrateStore(ctx context.Context, cfg *Config) error {
+	var store Store
+	var err error
+	switch cfg.StorageBackend {
+	case StorageBackendDynamoDB:
+		// Use NewDB directly so migrations run exactly once, whatever AUTO_MIGRATE says
+		store, err = NewDB(cfg)
+	default:
+		store, err = NewStore(cfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	migrator, ok := store.(Migrator)
+	if !ok {
+		log.Printf("Storage backend %q has no schema to migrate", cfg.StorageBackend)
+		return nil
+	}
+	return migrator.Migrate(ctx)
+}
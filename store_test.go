@@ -0,0 +1,523 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewStore(t *testing.T) {
+	tests := []struct {
+		name        string
+		backend     string
+		expectType  string
+		expectError bool
+	}{
+		{name: "memory backend", backend: StorageBackendMemory, expectType: "*main.MemoryStore", expectError: false},
+		{name: "sqlite backend", backend: StorageBackendSQLite, expectType: "*main.SQLStore", expectError: false},
+		{name: "unknown backend", backend: "cassandra", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewStore(&Config{StorageBackend: tt.backend, DatabaseURL: ":memory:"})
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("NewStore(%q) expected error", tt.backend)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewStore(%q) error = %v", tt.backend, err)
+			}
+			if got := fmt.Sprintf("%T", store); got != tt.expectType {
+				t.Errorf("NewStore(%q) returned %s, want %s", tt.backend, got, tt.expectType)
+			}
+		})
+	}
+}
+
+// runStoreTests runs the behaviour every Store implementation must share against stores created by newStore
+func runStoreTests(t *testing.T, newStore func(t *testing.T) Store) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store Store)
+	}{
+		{name: "Rounds", test: testStoreRounds},
+		{name: "UserStatsVersioning", test: testStoreUserStatsVersioning},
+		{name: "Submissions", test: testStoreSubmissions},
+		{name: "GameSessions", test: testStoreGameSessions},
+		{name: "PlayHistory", test: testStorePlayHistory},
+		{name: "Leaderboards", test: testStoreLeaderboards},
+		{name: "Leagues", test: testStoreLeagues},
+		{name: "RoundEdits", test: testStoreRoundEdits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+func testStoreRounds(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	round := &Round{RoundID: "basketball#2025-11-15", Sport: "basketball", PlayDate: "2025-11-15", Player: Player{Name: "LeBron James"}}
+	if err := store.CreateRound(ctx, round); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	if round.Stats.Name != "LeBron James" || round.Created.IsZero() {
+		t.Errorf("expected stats and timestamps to be initialized, got %+v", round.Stats)
+	}
+
+	err := store.CreateRound(ctx, &Round{Sport: "basketball", PlayDate: "2025-11-15"})
+	if err == nil || err.Error() != "round already exists" {
+		t.Errorf("CreateRound() duplicate error = %v, want round already exists", err)
+	}
+
+	// Returned rounds are copies
+	got, _ := store.GetRound(ctx, "basketball", "2025-11-15")
+	got.Player.Name = "changed"
+	got, _ = store.GetRound(ctx, "basketball", "2025-11-15")
+	if got.Player.Name != "LeBron James" {
+		t.Errorf("expected stored round to be unaffected by caller changes, got %q", got.Player.Name)
+	}
+
+	if err := store.ApplyRoundResult(ctx, "basketball", "2025-11-15", &Result{Score: 80, IsCorrect: true, FlippedTiles: []string{TileBio}}); err != nil {
+		t.Fatalf("ApplyRoundResult() error = %v", err)
+	}
+	got, _ = store.GetRound(ctx, "basketball", "2025-11-15")
+	if got.Stats.TotalPlays != 1 || got.Stats.HighestScore != 80 || got.Stats.PercentageCorrect != 100 {
+		t.Errorf("unexpected stats after result: %+v", got.Stats.Stats)
+	}
+
+	// Archive plays are counted apart from the live stats
+	if err := store.ApplyRoundResult(ctx, "basketball", "2025-11-15", &Result{Score: 100, IsCorrect: true, Archive: true}); err != nil {
+		t.Fatalf("ApplyRoundResult() archive error = %v", err)
+	}
+	got, _ = store.GetRound(ctx, "basketball", "2025-11-15")
+	if got.Stats.ArchivePlays != 1 || got.Stats.TotalPlays != 1 || got.Stats.HighestScore != 80 {
+		t.Errorf("unexpected stats after archive play: archivePlays %d, %+v", got.Stats.ArchivePlays, got.Stats.Stats)
+	}
+	err = store.ApplyRoundResult(ctx, "basketball", "2025-11-16", &Result{Archive: true})
+	if err == nil || err.Error() != "round not found" {
+		t.Errorf("ApplyRoundResult() archive play on a missing round error = %v, want round not found", err)
+	}
+
+	err = store.ApplyRoundResult(ctx, "basketball", "2025-11-16", &Result{})
+	if err == nil || err.Error() != "round not found" {
+		t.Errorf("ApplyRoundResult() missing round error = %v, want round not found", err)
+	}
+
+	store.CreateRound(ctx, &Round{Sport: "basketball", PlayDate: "2025-11-17"})
+	store.CreateRound(ctx, &Round{Sport: "baseball", PlayDate: "2025-11-16"})
+	rounds, err := store.GetRoundsBySport(ctx, "basketball", "2025-11-01", "")
+	if err != nil {
+		t.Fatalf("GetRoundsBySport() error = %v", err)
+	}
+	if len(rounds) != 2 || rounds[0].PlayDate != "2025-11-17" || rounds[1].PlayDate != "2025-11-15" {
+		t.Errorf("GetRoundsBySport() = %+v, want 2025-11-17 then 2025-11-15", rounds)
+	}
+	withStats, err := store.GetRoundStatsBySport(ctx, "basketball", "2025-11-01", "")
+	if err != nil {
+		t.Fatalf("GetRoundStatsBySport() error = %v", err)
+	}
+	if len(withStats) != 2 || withStats[1].PlayDate != "2025-11-15" || withStats[1].Stats.TotalPlays != 1 || withStats[1].Stats.MostTileFlippedTracker[TileBio] != 1 {
+		t.Errorf("GetRoundStatsBySport() = %+v, want 2025-11-15 with its tile counters", withStats)
+	}
+
+	// Rounds are rated once enough people have played
+	if len(rounds) == 2 && rounds[1].Difficulty != nil {
+		t.Errorf("GetRoundsBySport() difficulty = %v after 1 play, want none", *rounds[1].Difficulty)
+	}
+	for i := 1; i < RoundDifficultyMinPlays; i++ {
+		if err := store.ApplyRoundResult(ctx, "basketball", "2025-11-15", &Result{IncorrectGuesses: 1}); err != nil {
+			t.Fatalf("ApplyRoundResult() error = %v", err)
+		}
+	}
+	rounds, _ = store.GetRoundsBySport(ctx, "basketball", "2025-11-15", "2025-11-15")
+	got, _ = store.GetRound(ctx, "basketball", "2025-11-15")
+	if len(rounds) != 1 || rounds[0].Difficulty == nil || got.Stats.Difficulty == nil || *rounds[0].Difficulty != *got.Stats.Difficulty {
+		t.Errorf("GetRoundsBySport() = %+v and round difficulty %v, want the same rating", rounds, got.Stats.Difficulty)
+	}
+
+	store.DeleteRound(ctx, "basketball", "2025-11-15")
+	if got, _ := store.GetRound(ctx, "basketball", "2025-11-15"); got != nil {
+		t.Errorf("expected round to be deleted, got %+v", got)
+	}
+}
+
+func testStoreUserStatsVersioning(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	if err := store.CreateUserStats(ctx, &UserStats{UserId: "user-1"}); err != nil {
+		t.Fatalf("CreateUserStats() error = %v", err)
+	}
+	err := store.CreateUserStats(ctx, &UserStats{UserId: "user-1"})
+	if err == nil || err.Error() != "user stats already exist" {
+		t.Errorf("CreateUserStats() duplicate error = %v, want user stats already exist", err)
+	}
+
+	first, _ := store.GetUserStats(ctx, "user-1")
+	second, _ := store.GetUserStats(ctx, "user-1")
+	if first.Version != 1 {
+		t.Errorf("Version = %d, want 1", first.Version)
+	}
+
+	first.UserName = "first"
+	if err := store.UpdateUserStats(ctx, first); err != nil {
+		t.Fatalf("UpdateUserStats() error = %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Version after update = %d, want 2", first.Version)
+	}
+
+	// The second copy was read before the first write and must not overwrite it
+	second.UserName = "second"
+	err = store.UpdateUserStats(ctx, second)
+	if err == nil || err.Error() != "user stats version conflict" {
+		t.Errorf("UpdateUserStats() stale error = %v, want user stats version conflict", err)
+	}
+
+	got, _ := store.GetUserStats(ctx, "user-1")
+	if got.UserName != "first" {
+		t.Errorf("UserName = %q, want first", got.UserName)
+	}
+
+	err = store.UpdateUserStats(ctx, &UserStats{UserId: "missing"})
+	if err == nil || err.Error() != "user stats version conflict" {
+		t.Errorf("UpdateUserStats() missing error = %v, want user stats version conflict", err)
+	}
+}
+
+func testStoreSubmissions(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	if err := store.CreateSubmission(ctx, &Submission{SubmissionKey: "key", Result: Result{Score: 50}}); err != nil {
+		t.Fatalf("CreateSubmission() error = %v", err)
+	}
+	err := store.CreateSubmission(ctx, &Submission{SubmissionKey: "key"})
+	if err == nil || err.Error() != "submission already exists" {
+		t.Errorf("CreateSubmission() duplicate error = %v, want submission already exists", err)
+	}
+
+	got, _ := store.GetSubmission(ctx, "key")
+	if got == nil || got.Result.Score != 50 {
+		t.Errorf("GetSubmission() = %+v, want score 50", got)
+	}
+
+	if err := store.UpdateSubmissionStatus(ctx, "key", SubmissionStatusComplete); err != nil {
+		t.Fatalf("UpdateSubmissionStatus() error = %v", err)
+	}
+	if got, _ := store.GetSubmission(ctx, "key"); got == nil || got.Status != SubmissionStatusComplete || got.Result.Score != 50 {
+		t.Errorf("GetSubmission() after update = %+v, want status complete and score 50", got)
+	}
+	if err := store.UpdateSubmissionStatus(ctx, "missing", SubmissionStatusComplete); err != nil {
+		t.Errorf("UpdateSubmissionStatus() of a missing key error = %v, want nil", err)
+	}
+	if got, _ := store.GetSubmission(ctx, "missing"); got != nil {
+		t.Errorf("UpdateSubmissionStatus() created %+v for a missing key", got)
+	}
+
+	store.DeleteSubmission(ctx, "key")
+	if got, _ := store.GetSubmission(ctx, "key"); got != nil {
+		t.Errorf("expected submission to be deleted, got %+v", got)
+	}
+}
+
+func testStoreGameSessions(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	session := &GameSession{SessionID: "abc", Sport: "basketball", PlayDate: "2025-11-15"}
+	if err := store.CreateGameSession(ctx, session); err != nil {
+		t.Fatalf("CreateGameSession() error = %v", err)
+	}
+	if session.ExpiresAt == 0 {
+		t.Error("expected ExpiresAt to be set")
+	}
+	err := store.CreateGameSession(ctx, &GameSession{SessionID: "abc"})
+	if err == nil || err.Error() != "game session already exists" {
+		t.Errorf("CreateGameSession() duplicate error = %v, want game session already exists", err)
+	}
+
+	session.FlippedTiles = []string{TileBio}
+	store.UpdateGameSession(ctx, session)
+	got, _ := store.GetGameSession(ctx, "abc")
+	if got == nil || len(got.FlippedTiles) != 1 || got.ExpiresAt != session.ExpiresAt {
+		t.Errorf("GetGameSession() = %+v, want one flipped tile and the same expiry", got)
+	}
+}
+
+func testStorePlayHistory(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	for _, entry := range []*PlayHistoryEntry{
+		{UserId: "user-1", Sport: "basketball", PlayDate: "2025-11-13"},
+		{UserId: "user-1", Sport: "basketball", PlayDate: "2025-11-14"},
+		{UserId: "user-1", Sport: "basketball", PlayDate: "2025-11-15"},
+		{UserId: "user-1", Sport: "baseball", PlayDate: "2025-11-15"},
+		{UserId: "user-2", Sport: "basketball", PlayDate: "2025-11-15"},
+	} {
+		if err := store.PutPlayHistoryEntry(ctx, entry); err != nil {
+			t.Fatalf("PutPlayHistoryEntry() error = %v", err)
+		}
+	}
+
+	entry, _ := store.GetPlayHistoryEntry(ctx, "user-1", "baseball", "2025-11-15")
+	if entry == nil || entry.SportPlayDate != "baseball#2025-11-15" {
+		t.Errorf("GetPlayHistoryEntry() = %+v, want baseball#2025-11-15", entry)
+	}
+
+	page, cursor, err := store.GetPlayHistory(ctx, "user-1", "basketball", 2, "")
+	if err != nil {
+		t.Fatalf("GetPlayHistory() error = %v", err)
+	}
+	if len(page) != 2 || page[0].PlayDate != "2025-11-15" || page[1].PlayDate != "2025-11-14" || cursor == "" {
+		t.Fatalf("first page = %+v (cursor %q), want 2025-11-15, 2025-11-14 and a cursor", page, cursor)
+	}
+
+	page, cursor, err = store.GetPlayHistory(ctx, "user-1", "basketball", 2, cursor)
+	if err != nil {
+		t.Fatalf("GetPlayHistory() error = %v", err)
+	}
+	if len(page) != 1 || page[0].PlayDate != "2025-11-13" || cursor != "" {
+		t.Errorf("second page = %+v (cursor %q), want only 2025-11-13 and no cursor", page, cursor)
+	}
+
+	page, _, _ = store.GetPlayHistory(ctx, "user-1", "", 10, "")
+	if len(page) != 4 {
+		t.Errorf("unfiltered history has %d entries, want 4", len(page))
+	}
+
+	if _, _, err := store.GetPlayHistory(ctx, "user-1", "", 10, "!!"); err == nil || err.Error() != "invalid cursor" {
+		t.Errorf("GetPlayHistory() bad cursor error = %v, want invalid cursor", err)
+	}
+}
+
+func testStoreLeaderboards(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	board := leaderboardBoard("basketball", LeaderboardBoardAllTime)
+	for _, r := range []struct {
+		userId string
+		score  int
+	}{
+		{"alice", 50}, {"bob", 80}, {"carol", 80}, {"dave", 20}, {"alice", 40},
+	} {
+		if err := store.AddLeaderboardResult(ctx, []LeaderboardBoardRef{{Board: board}}, r.userId, "name-"+r.userId, &Result{Score: r.score, IsCorrect: r.score > 0}); err != nil {
+			t.Fatalf("AddLeaderboardResult() error = %v", err)
+		}
+	}
+	// Other boards don't leak into this one, and one call adds the result to every board it's given
+	otherBoards := []LeaderboardBoardRef{
+		{Board: leaderboardBoard("baseball", LeaderboardBoardAllTime)},
+		{Board: leaderboardWindowBoard("baseball", LeaderboardWindowWeek, "2025-01-10"), ExpiresAt: time.Now().Add(time.Hour).Unix()},
+	}
+	if err := store.AddLeaderboardResult(ctx, otherBoards, "erin", "name-erin", &Result{Score: 500}); err != nil {
+		t.Fatalf("AddLeaderboardResult() error = %v", err)
+	}
+	for _, ref := range otherBoards {
+		if entry, _ := store.GetLeaderboardEntry(ctx, ref.Board, "erin"); entry == nil || entry.Score != 500 {
+			t.Errorf("GetLeaderboardEntry(%s) = %+v, want erin with 500 points", ref.Board, entry)
+		}
+	}
+
+	// alice 90, bob 80, carol 80, dave 20
+	page, cursor, err := store.GetLeaderboard(ctx, board, 2, "")
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(page) != 2 || page[0].UserId != "alice" || page[0].Score != 90 || page[0].RoundsPlayed != 2 || page[0].HighestScore != 50 || page[0].Rank != 1 {
+		t.Fatalf("first page = %+v, want alice first with 90 points over 2 rounds", page)
+	}
+	if page[1].Score != 80 || page[1].Rank != 2 || cursor == "" {
+		t.Fatalf("first page second entry = %+v (cursor %q), want 80 points at rank 2 and a cursor", page[1], cursor)
+	}
+
+	page, cursor, err = store.GetLeaderboard(ctx, board, 2, cursor)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(page) != 2 || page[0].Score != 80 || page[0].Rank != 2 || page[1].UserId != "dave" || page[1].Rank != 4 {
+		t.Fatalf("second page = %+v, want the other 80 at rank 2 then dave at rank 4", page)
+	}
+	if cursor != "" {
+		// DynamoDB may hand back a cursor for an empty last page, which is also fine
+		if rest, _, _ := store.GetLeaderboard(ctx, board, 2, cursor); len(rest) != 0 {
+			t.Errorf("third page = %+v, want none", rest)
+		}
+	}
+
+	entry, err := store.GetLeaderboardEntry(ctx, board, "dave")
+	if err != nil {
+		t.Fatalf("GetLeaderboardEntry() error = %v", err)
+	}
+	if entry == nil || entry.Rank != 4 || entry.UserName != "name-dave" {
+		t.Errorf("GetLeaderboardEntry() = %+v, want name-dave at rank 4", entry)
+	}
+	if entry, _ := store.GetLeaderboardEntry(ctx, board, "erin"); entry != nil {
+		t.Errorf("GetLeaderboardEntry() for a player on another board = %+v, want nil", entry)
+	}
+
+	entries, err := store.GetLeaderboardEntriesForUsers(ctx, board, []string{"dave", "erin", "alice"})
+	if err != nil {
+		t.Fatalf("GetLeaderboardEntriesForUsers() error = %v", err)
+	}
+	sortLeaderboardEntries(entries)
+	if len(entries) != 2 || entries[0].UserId != "alice" || entries[0].Score != 90 || entries[1].UserId != "dave" || entries[1].UserName != "name-dave" {
+		t.Errorf("GetLeaderboardEntriesForUsers() = %+v, want alice and dave without erin", entries)
+	}
+	if entries, err := store.GetLeaderboardEntriesForUsers(ctx, board, nil); err != nil || len(entries) != 0 {
+		t.Errorf("GetLeaderboardEntriesForUsers() with no users = %+v (%v), want none", entries, err)
+	}
+
+	if _, _, err := store.GetLeaderboard(ctx, board, 2, "!!"); err == nil || err.Error() != "invalid cursor" {
+		t.Errorf("GetLeaderboard() bad cursor error = %v, want invalid cursor", err)
+	}
+}
+
+func testStoreLeagues(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	league := &League{LeagueID: "league-1", Name: "Office", InviteCode: "ABCD2345", OwnerId: "alice"}
+	if err := store.CreateLeague(ctx, league); err != nil {
+		t.Fatalf("CreateLeague() error = %v", err)
+	}
+	if league.Created.IsZero() {
+		t.Errorf("CreateLeague() did not set Created")
+	}
+	if err := store.CreateLeague(ctx, &League{LeagueID: "league-1", Name: "Again", InviteCode: "WXYZ6789"}); err == nil || err.Error() != "league already exists" {
+		t.Errorf("CreateLeague() duplicate id error = %v, want league already exists", err)
+	}
+	if err := store.CreateLeague(ctx, &League{LeagueID: "league-2", Name: "Again", InviteCode: "ABCD2345"}); err == nil || err.Error() != "league already exists" {
+		t.Errorf("CreateLeague() duplicate invite code error = %v, want league already exists", err)
+	}
+	if err := store.CreateLeague(ctx, &League{LeagueID: "league-3", Name: "Family", InviteCode: "FAM23456", OwnerId: "bob"}); err != nil {
+		t.Fatalf("CreateLeague() error = %v", err)
+	}
+
+	got, err := store.GetLeague(ctx, "league-1")
+	if err != nil || got == nil || got.Name != "Office" || got.InviteCode != "ABCD2345" || got.OwnerId != "alice" {
+		t.Errorf("GetLeague() = %+v, %v, want the Office league", got, err)
+	}
+	if got, err := store.GetLeague(ctx, "missing"); err != nil || got != nil {
+		t.Errorf("GetLeague() for a missing league = %+v, %v, want nil", got, err)
+	}
+	if got, err := store.GetLeagueByInviteCode(ctx, "FAM23456"); err != nil || got == nil || got.LeagueID != "league-3" {
+		t.Errorf("GetLeagueByInviteCode() = %+v, %v, want league-3", got, err)
+	}
+	if got, err := store.GetLeagueByInviteCode(ctx, "NOPE2345"); err != nil || got != nil {
+		t.Errorf("GetLeagueByInviteCode() for an unknown code = %+v, %v, want nil", got, err)
+	}
+
+	for _, m := range []struct{ leagueId, userId string }{
+		{"league-1", "carol"}, {"league-1", "alice"}, {"league-3", "bob"}, {"league-3", "alice"},
+	} {
+		if err := store.AddLeagueMember(ctx, &LeagueMember{LeagueID: m.leagueId, UserId: m.userId, UserName: "name-" + m.userId}); err != nil {
+			t.Fatalf("AddLeagueMember() error = %v", err)
+		}
+	}
+	if err := store.AddLeagueMember(ctx, &LeagueMember{LeagueID: "league-1", UserId: "alice"}); err == nil || err.Error() != "league member already exists" {
+		t.Errorf("AddLeagueMember() duplicate error = %v, want league member already exists", err)
+	}
+
+	members, err := store.GetLeagueMembers(ctx, "league-1")
+	if err != nil {
+		t.Fatalf("GetLeagueMembers() error = %v", err)
+	}
+	if len(members) != 2 || members[0].UserId != "alice" || members[1].UserId != "carol" || members[1].UserName != "name-carol" || members[1].Joined.IsZero() {
+		t.Errorf("GetLeagueMembers() = %+v, want alice then carol", members)
+	}
+	if members, err := store.GetLeagueMembers(ctx, "missing"); err != nil || len(members) != 0 {
+		t.Errorf("GetLeagueMembers() for a missing league = %+v, %v, want none", members, err)
+	}
+
+	leagues, err := store.GetUserLeagues(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetUserLeagues() error = %v", err)
+	}
+	if len(leagues) != 2 || leagues[0].LeagueID != "league-1" || leagues[1].LeagueID != "league-3" {
+		t.Errorf("GetUserLeagues() = %+v, want league-1 and league-3", leagues)
+	}
+	if leagues, err := store.GetUserLeagues(ctx, "dave"); err != nil || len(leagues) != 0 {
+		t.Errorf("GetUserLeagues() for a player in no leagues = %+v, %v, want none", leagues, err)
+	}
+}
+
+func testStoreRoundEdits(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	round := &Round{Sport: "basketball", PlayDate: "2025-11-15", Theme: "Legends", Player: Player{Name: "LeBron James", Bio: "Born in Akron", Nicknames: "King James"}}
+	if err := store.CreateRound(ctx, round); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	if err := store.ApplyRoundResult(ctx, "basketball", "2025-11-15", &Result{Score: 80, IsCorrect: true}); err != nil {
+		t.Fatalf("ApplyRoundResult() error = %v", err)
+	}
+
+	theme := "Champions"
+	previous, err := store.PatchRound(ctx, "basketball", "2025-11-15", &RoundPatch{Theme: &theme, Player: map[string]string{TileBio: "Born in Akron, Ohio"}}, "erin")
+	if err != nil {
+		t.Fatalf("PatchRound() error = %v", err)
+	}
+	if previous.Theme != "Legends" || previous.Player.Bio != "Born in Akron" {
+		t.Errorf("PatchRound() previous = theme %q, bio %q, want the round before the patch", previous.Theme, previous.Player.Bio)
+	}
+
+	got, _ := store.GetRound(ctx, "basketball", "2025-11-15")
+	if got.Theme != "Champions" || got.Player.Bio != "Born in Akron, Ohio" {
+		t.Errorf("GetRound() after patch = theme %q, bio %q, want the patched values", got.Theme, got.Player.Bio)
+	}
+	if got.Player.Name != "LeBron James" || got.Player.Nicknames != "King James" || got.Stats.TotalPlays != 1 || got.Stats.HighestScore != 80 {
+		t.Errorf("PatchRound() changed fields outside the patch: %+v", got)
+	}
+
+	// The patch is recorded with it; patching to the current values records nothing
+	if _, err := store.PatchRound(ctx, "basketball", "2025-11-15", &RoundPatch{Theme: &theme}, "frank"); err != nil {
+		t.Fatalf("PatchRound() no-op error = %v", err)
+	}
+	patched, err := store.GetRoundEdits(ctx, "basketball", "2025-11-15")
+	if err != nil {
+		t.Fatalf("GetRoundEdits() error = %v", err)
+	}
+	if len(patched) != 1 || patched[0].Editor != "erin" || len(patched[0].Changes) != 2 || patched[0].Changes[0].OldValue != "Legends" {
+		t.Fatalf("GetRoundEdits() after patch = %+v, want erin's theme and bio changes", patched)
+	}
+
+	if _, err := store.PatchRound(ctx, "basketball", "2025-11-16", &RoundPatch{Theme: &theme}, "erin"); err == nil || err.Error() != "round not found" {
+		t.Errorf("PatchRound() missing round error = %v, want round not found", err)
+	}
+
+	first := time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+	edits := []*RoundEdit{
+		{Sport: "basketball", PlayDate: "2025-11-15", Editor: "bob", Edited: first.Add(time.Minute), Changes: []RoundChange{{Field: RoundEditFieldTheme, OldValue: "Legends", NewValue: "Champions"}}},
+		{Sport: "basketball", PlayDate: "2025-11-15", Editor: "alice", Edited: first, Changes: []RoundChange{{Field: RoundEditFieldPlayerPrefix + TileBio, OldValue: "a", NewValue: "b"}}},
+		{Sport: "baseball", PlayDate: "2025-11-15", Editor: "carol", Edited: first},
+	}
+	for _, edit := range edits {
+		if err := store.CreateRoundEdit(ctx, edit); err != nil {
+			t.Fatalf("CreateRoundEdit() error = %v", err)
+		}
+	}
+	err = store.CreateRoundEdit(ctx, &RoundEdit{Sport: "basketball", PlayDate: "2025-11-15", Editor: "dave", Edited: first})
+	if err == nil || err.Error() != "round edit already exists" {
+		t.Errorf("CreateRoundEdit() duplicate error = %v, want round edit already exists", err)
+	}
+
+	recorded, err := store.GetRoundEdits(ctx, "basketball", "2025-11-15")
+	if err != nil {
+		t.Fatalf("GetRoundEdits() error = %v", err)
+	}
+	// erin's patch was made now, after the backdated edits
+	if len(recorded) != 3 || recorded[0].Editor != "alice" || recorded[1].Editor != "bob" || recorded[2].Editor != "erin" {
+		t.Fatalf("GetRoundEdits() = %+v, want alice, bob then erin", recorded)
+	}
+	if !recorded[0].Edited.Equal(first) || len(recorded[1].Changes) != 1 || recorded[1].Changes[0].NewValue != "Champions" {
+		t.Errorf("GetRoundEdits() did not round-trip the edit: %+v", recorded[1])
+	}
+	if edits, err := store.GetRoundEdits(ctx, "football", "2025-11-15"); err != nil || len(edits) != 0 {
+		t.Errorf("GetRoundEdits() for an unedited round = %+v, %v, want none", edits, err)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// submissionIdentity returns who a result submission is counted against
+// Signed-in users are identified by userId, anonymous players by their guest/device token
+// Example: "user#auth0|123", "guest#3f9c0e..."
+func submissionIdentity(c *gin.Context) string {
+	if userId := getContextUserId(c); userId != "" {
+		return "user#" + userId
+	}
+	if token := strings.TrimSpace(c.GetHeader(HeaderGuestToken)); token != "" {
+		return "guest#" + token
+	}
+	return ""
+}
+
+// submissionKeys returns the keys a result submission is deduplicated by
+// One key per (identity, sport, playDate) and one per Idempotency-Key header, if either is available
+// Example: "user#auth0|123#basketball#2025-11-15", "idempotency#user#auth0|123#abc-123"
+func submissionKeys(c *gin.Context, sport, playDate, sessionId string) []string {
+	identity := submissionIdentity(c)
+
+	var keys []string
+	if identity != "" {
+		keys = append(keys, identity+"#"+sport+"#"+playDate)
+	}
+	if idempotencyKey := strings.TrimSpace(c.GetHeader(HeaderIdempotencyKey)); idempotencyKey != "" {
+		// Scope the key to the caller so one client can't replay another's result
+		// Callers with no identity are scoped to the game session, which only they know the ID of
+		scope := identity
+		if scope == "" {
+			scope = "session#" + sessionId
+		}
+		keys = append(keys, "idempotency#"+scope+"#"+idempotencyKey)
+	}
+	return keys
+}
+
+// findSubmission returns the first existing submission stored under any of the keys
+func (s *Server) findSubmission(ctx context.Context, keys []string) (*Submission, error) {
+	for _, key := range keys {
+		submission, err := s.db.GetSubmission(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if submission != nil {
+			return submission, nil
+		}
+	}
+	return nil, nil
+}
+
+// claimSubmission stores the submission under every key so later attempts are treated as repeats
+// If another request claimed one of the keys first, the claims made here are released and the
+// existing submission is returned instead
+func (s *Server) claimSubmission(ctx context.Context, keys []string, submission Submission) (*Submission, error) {
+	var claimed []string
+	for _, key := range keys {
+		submission.SubmissionKey = key
+		err := s.db.CreateSubmission(ctx, &submission)
+		if err == nil {
+			claimed = append(claimed, key)
+			continue
+		}
+
+		s.releaseSubmission(ctx, claimed)
+		if err.Error() != "submission already exists" {
+			return nil, err
+		}
+
+		existing, err := s.db.GetSubmission(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+	return nil, nil
+}
+
+// releaseSubmission removes claimed keys so a submission that failed part way can be retried
+// Best effort: failures are logged, not returned
+func (s *Server) releaseSubmission(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.db.DeleteSubmission(ctx, key); err != nil {
+			log.Printf("Failed to release submission %s: %v", key, err)
+		}
+	}
+}
+
+// markSubmission records the step a submission has reached under every key it was claimed with
+// Best effort: failures are logged, not returned. A key left behind only means a retry may replay
+// the result without finishing its steps, never that it's counted twice
+func (s *Server) markSubmission(ctx context.Context, keys []string, status string) {
+	for _, key := range keys {
+		if err := s.db.UpdateSubmissionStatus(ctx, key, status); err != nil {
+			log.Printf("Failed to mark submission %s %s: %v", key, status, err)
+		}
+	}
+}
+
+// finishSubmission responds to a repeat of an existing submission with its result
+// If the request that claimed it counted the round but failed before the user stats or leaderboards,
+// the remaining steps are counted first
+func (s *Server) finishSubmission(c *gin.Context, keys []string, existing *Submission) {
+	if existing.Status == SubmissionStatusRoundCounted || existing.Status == SubmissionStatusUserCounted {
+		today := time.Now().In(getUserTimezone(c)).Format(DateFormatYYYYMMDD)
+		result := existing.Result
+		if err := s.countUserResult(c, keys, existing.Status, existing.Sport, existing.PlayDate, today, &result); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				JSONFieldError:     StatusInternalServerError,
+				JSONFieldMessage:   "Failed to count result: " + err.Error(),
+				JSONFieldCode:      ErrorDatabaseError,
+				JSONFieldTimestamp: time.Now(),
+			})
+			return
+		}
+		s.markSubmission(c.Request.Context(), keys, SubmissionStatusComplete)
+	}
+
+	replaySubmission(c, existing.Result)
+}
+
+// replaySubmission responds with a previously counted result without touching any aggregates
+func replaySubmission(c *gin.Context, result Result) {
+	c.Header(HeaderIdempotentReplayed, "true")
+	c.JSON(http.StatusOK, result)
+}
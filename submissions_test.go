@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSubmissionIdentity(t *testing.T) {
+	tests := []struct {
+		name       string
+		userId     string
+		guestToken string
+		expected   string
+	}{
+		{name: "signed-in user", userId: "auth0|123", expected: "user#auth0|123"},
+		{name: "user takes precedence over guest token", userId: "auth0|123", guestToken: "device-1", expected: "user#auth0|123"},
+		{name: "guest token", guestToken: "device-1", expected: "guest#device-1"},
+		{name: "guest token is trimmed", guestToken: "  device-1 ", expected: "guest#device-1"},
+		{name: "anonymous without token", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/v1/results", nil)
+			if tt.userId != "" {
+				c.Set(ConstantUserId, tt.userId)
+			}
+			if tt.guestToken != "" {
+				c.Request.Header.Set(HeaderGuestToken, tt.guestToken)
+			}
+
+			got := submissionIdentity(c)
+			if got != tt.expected {
+				t.Errorf("submissionIdentity() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubmissionKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		userId         string
+		guestToken     string
+		idempotencyKey string
+		expected       []string
+	}{
+		{
+			name:     "signed-in user",
+			userId:   "auth0|123",
+			expected: []string{"user#auth0|123#basketball#2025-11-15"},
+		},
+		{
+			name:       "guest",
+			guestToken: "device-1",
+			expected:   []string{"guest#device-1#basketball#2025-11-15"},
+		},
+		{
+			name:           "user with idempotency key",
+			userId:         "auth0|123",
+			idempotencyKey: "abc-123",
+			expected:       []string{"user#auth0|123#basketball#2025-11-15", "idempotency#user#auth0|123#abc-123"},
+		},
+		{
+			name:           "anonymous with idempotency key only",
+			idempotencyKey: "abc-123",
+			expected:       []string{"idempotency#session#session-1#abc-123"},
+		},
+		{
+			name:           "guest with idempotency key",
+			guestToken:     "device-1",
+			idempotencyKey: "abc-123",
+			expected:       []string{"guest#device-1#basketball#2025-11-15", "idempotency#guest#device-1#abc-123"},
+		},
+		{
+			name:     "anonymous without any key",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/v1/results", nil)
+			if tt.userId != "" {
+				c.Set(ConstantUserId, tt.userId)
+			}
+			if tt.guestToken != "" {
+				c.Request.Header.Set(HeaderGuestToken, tt.guestToken)
+			}
+			if tt.idempotencyKey != "" {
+				c.Request.Header.Set(HeaderIdempotencyKey, tt.idempotencyKey)
+			}
+
+			got := submissionKeys(c, "basketball", "2025-11-15", "session-1")
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("submissionKeys() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// userStatsOutageStore fails every user stats write while down is set
+type userStatsOutageStore struct {
+	*MemoryStore
+	down bool
+}
+
+func (s *userStatsOutageStore) CreateUserStats(ctx context.Context, stats *UserStats) error {
+	if s.down {
+		return errors.New("user stats unavailable")
+	}
+	return s.MemoryStore.CreateUserStats(ctx, stats)
+}
+
+// TestSubmitResultsResumesAfterUserStatsFailure retries a submission whose round stats were counted
+// before the user stats failed, which must finish the user stats without counting the round again
+func TestSubmitResultsResumesAfterUserStatsFailure(t *testing.T) {
+	store := &userStatsOutageStore{MemoryStore: NewMemoryStore(), down: true}
+	server := NewServer(store)
+	ctx := context.Background()
+	userId := "auth0|player"
+
+	if err := store.CreateRound(ctx, &Round{Sport: "basketball", PlayDate: "2025-11-15", Player: Player{Name: "LeBron James"}}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	w := performRequest(server.StartGameSession, http.MethodPost, "/v1/round/session?sport=basketball&playDate=2025-11-15", nil, userId)
+	var session GameSession
+	json.NewDecoder(w.Body).Decode(&session)
+
+	submitPath := "/v1/results?sport=basketball&playDate=2025-11-15&sessionId=" + session.SessionID
+	if w := performRequest(server.SubmitResults, http.MethodPost, submitPath, nil, userId); w.Code != http.StatusInternalServerError {
+		t.Fatalf("SubmitResults during the outage: status %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	submission, _ := store.GetSubmission(ctx, "user#"+userId+"#basketball#2025-11-15")
+	if submission == nil || submission.Status != SubmissionStatusRoundCounted {
+		t.Fatalf("submission = %+v, want the claim kept with the round counted", submission)
+	}
+
+	store.down = false
+	w = performRequest(server.SubmitResults, http.MethodPost, submitPath, nil, userId)
+	if w.Code != http.StatusOK || w.Header().Get(HeaderIdempotentReplayed) != "true" {
+		t.Fatalf("retry: status %d, replayed header %q", w.Code, w.Header().Get(HeaderIdempotentReplayed))
+	}
+
+	round, _ := store.GetRound(ctx, "basketball", "2025-11-15")
+	if round.Stats.ArchivePlays != 1 {
+		t.Errorf("round archive plays = %d, want the round counted once", round.Stats.ArchivePlays)
+	}
+	userStats, _ := store.GetUserStats(ctx, userId)
+	if userStats == nil || len(userStats.Sports) != 1 || userStats.Sports[0].ArchivePlays != 1 {
+		t.Errorf("user stats = %+v, want the play counted once", userStats)
+	}
+	submission, _ = store.GetSubmission(ctx, "user#"+userId+"#basketball#2025-11-15")
+	if submission.Status != SubmissionStatusComplete {
+		t.Errorf("submission status = %q, want %q", submission.Status, SubmissionStatusComplete)
+	}
+
+	// Later repeats only replay
+	performRequest(server.SubmitResults, http.MethodPost, submitPath, nil, userId)
+	userStats, _ = store.GetUserStats(ctx, userId)
+	if userStats.Sports[0].ArchivePlays != 1 {
+		t.Errorf("user archive plays = %d after another repeat, want 1", userStats.Sports[0].ArchivePlays)
+	}
+}
+
+// leaderboardOutageStore fails every leaderboard write while down is set
+type leaderboardOutageStore struct {
+	*MemoryStore
+	down bool
+}
+
+func (s *leaderboardOutageStore) AddLeaderboardResult(ctx context.Context, boards []LeaderboardBoardRef, userId, userName string, result *Result) error {
+	if s.down {
+		return errors.New("leaderboards unavailable")
+	}
+	return s.MemoryStore.AddLeaderboardResult(ctx, boards, userId, userName, result)
+}
+
+// TestSubmitResultsResumesAfterLeaderboardFailure retries a submission whose user stats were counted
+// before the leaderboards failed, which must rank the result without counting the user stats again
+func TestSubmitResultsResumesAfterLeaderboardFailure(t *testing.T) {
+	store := &leaderboardOutageStore{MemoryStore: NewMemoryStore(), down: true}
+	server := NewServer(store)
+	ctx := context.Background()
+	userId := "auth0|player"
+	today := time.Now().UTC().Format(DateFormatYYYYMMDD)
+
+	if err := store.CreateRound(ctx, &Round{Sport: "basketball", PlayDate: today, Player: Player{Name: "LeBron James"}}); err != nil {
+		t.Fatalf("CreateRound() error = %v", err)
+	}
+	w := performRequest(server.StartGameSession, http.MethodPost, "/v1/round/session?sport=basketball&playDate="+today, nil, userId)
+	var session GameSession
+	json.NewDecoder(w.Body).Decode(&session)
+
+	submitPath := "/v1/results?sport=basketball&playDate=" + today + "&sessionId=" + session.SessionID
+	if w := performRequest(server.SubmitResults, http.MethodPost, submitPath, nil, userId); w.Code != http.StatusInternalServerError {
+		t.Fatalf("SubmitResults during the outage: status %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	submission, _ := store.GetSubmission(ctx, "user#"+userId+"#basketball#"+today)
+	if submission == nil || submission.Status != SubmissionStatusUserCounted {
+		t.Fatalf("submission = %+v, want the claim kept with the user stats counted", submission)
+	}
+
+	store.down = false
+	w = performRequest(server.SubmitResults, http.MethodPost, submitPath, nil, userId)
+	if w.Code != http.StatusOK || w.Header().Get(HeaderIdempotentReplayed) != "true" {
+		t.Fatalf("retry: status %d, replayed header %q", w.Code, w.Header().Get(HeaderIdempotentReplayed))
+	}
+
+	userStats, _ := store.GetUserStats(ctx, userId)
+	if userStats == nil || len(userStats.Sports) != 1 || userStats.Sports[0].Stats.TotalPlays != 1 {
+		t.Errorf("user stats = %+v, want the play counted once", userStats)
+	}
+	entry, _ := store.GetLeaderboardEntry(ctx, leaderboardBoard("basketball", today), userId)
+	if entry == nil || entry.RoundsPlayed != 1 {
+		t.Errorf("daily leaderboard entry = %+v, want the play ranked once", entry)
+	}
+	submission, _ = store.GetSubmission(ctx, "user#"+userId+"#basketball#"+today)
+	if submission.Status != SubmissionStatusComplete {
+		t.Errorf("submission status = %q, want %q", submission.Status, SubmissionStatusComplete)
+	}
+}
+
+func TestReplaySubmission(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/results", nil)
+
+	replaySubmission(c, Result{Score: 75, IsCorrect: true, FlippedTiles: []string{"photo"}})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get(HeaderIdempotentReplayed); got != "true" {
+		t.Errorf("%s header = %q, want %q", HeaderIdempotentReplayed, got, "true")
+	}
+}
@@ -1,62 +1,93 @@
 package main
 
-// incrementTileTracker increments the appropriate field in a TileFlipTracker based on the tile name
-func incrementTileTracker(tracker *TileFlipTracker, tileName string) {
-	if tracker == nil || tileName == "" {
-		return
+import "github.com/gocolly/colly/v2"
+
+// Tile defines a clue tile in one place: the key it is flipped, tracked and scored by,
+// the Player field holding its content and the scraper that fills that field
+// Adding a clue type means adding a Player field, a Tile* constant and an entry in tileRegistry
+type Tile struct {
+	Key    string
+	Field  func(player *Player) *string
+	Scrape tileScraper // nil when the field is filled by another tile's scraper
+}
+
+// tileScraper registers the colly callbacks that fill a tile's Player field
+type tileScraper func(c *colly.Collector, player *Player, sport string)
+
+// tileRegistry lists every tile in display order
+// A new tile also needs a cost for every sport in GetScoringConfig
+var tileRegistry = []Tile{
+	{Key: TileBio, Field: func(p *Player) *string { return &p.Bio }, Scrape: scrapeBio},
+	{Key: TilePlayerInformation, Field: func(p *Player) *string { return &p.PlayerInformation }, Scrape: scrapePlayerInformation},
+	{Key: TileDraftInformation, Field: func(p *Player) *string { return &p.DraftInformation }, Scrape: scrapeDraftInformation},
+	{Key: TileTeamsPlayedOn, Field: func(p *Player) *string { return &p.TeamsPlayedOn }}, // see scrapeYearsActiveAndTeamsPlayedOn
+	{Key: TileJerseyNumbers, Field: func(p *Player) *string { return &p.JerseyNumbers }, Scrape: scrapeJerseyNumbers},
+	{Key: TileCareerStats, Field: func(p *Player) *string { return &p.CareerStats }, Scrape: scrapeCareerStats},
+	{Key: TilePersonalAchievements, Field: func(p *Player) *string { return &p.PersonalAchievements }, Scrape: scrapePersonalAchievements},
+	{Key: TilePhoto, Field: func(p *Player) *string { return &p.Photo }, Scrape: scrapePhoto},
+	{Key: TileYearsActive, Field: func(p *Player) *string { return &p.YearsActive }, Scrape: scrapeYearsActiveAndTeamsPlayedOn},
+	{Key: TileInitials, Field: func(p *Player) *string { return &p.Initials }, Scrape: scrapeInitials},
+	{Key: TileNicknames, Field: func(p *Player) *string { return &p.Nicknames }, Scrape: scrapeNicknames},
+}
+
+// AllTiles returns the keys of every tile in the registry, in display order
+func AllTiles() []string {
+	keys := make([]string, len(tileRegistry))
+	for i, tile := range tileRegistry {
+		keys[i] = tile.Key
 	}
+	return keys
+}
 
-	switch tileName {
-	case TileBio:
-		tracker.Bio++
-	case TilePlayerInformation:
-		tracker.PlayerInformation++
-	case TileDraftInformation:
-		tracker.DraftInformation++
-	case TileTeamsPlayedOn:
-		tracker.TeamsPlayedOn++
-	case TileJerseyNumbers:
-		tracker.JerseyNumbers++
-	case TileCareerStats:
-		tracker.CareerStats++
-	case TilePersonalAchievements:
-		tracker.PersonalAchievements++
-	case TilePhoto:
-		tracker.Photo++
-	case TileYearsActive:
-		tracker.YearsActive++
-	case TileInitials:
-		tracker.Initials++
-	case TileNicknames:
-		tracker.Nicknames++
+// lookupTile returns the registry entry for a tile key
+// The second return value is false if the tile is not recognized
+func lookupTile(tileName string) (Tile, bool) {
+	for _, tile := range tileRegistry {
+		if tile.Key == tileName {
+			return tile, true
+		}
 	}
+	return Tile{}, false
 }
 
-// findMostCommonTile returns the tile name with the highest count in the tracker
-func findMostCommonTile(tracker *TileFlipTracker) string {
+// getTileValue returns the content of the named tile for a player
+// The second return value is false if the tile name is not recognized
+func getTileValue(player *Player, tileName string) (string, bool) {
+	if player == nil {
+		return "", false
+	}
+
+	tile, ok := lookupTile(tileName)
+	if !ok {
+		return "", false
+	}
+	return *tile.Field(player), true
+}
+
+// incrementTileTracker adds one to a tile's count in a tracker, creating the tracker if needed
+// Unrecognized tiles are ignored
+func incrementTileTracker(tracker *map[string]int, tileName string) {
 	if tracker == nil {
-		return ""
+		return
+	}
+	if _, ok := lookupTile(tileName); !ok {
+		return
+	}
+
+	if *tracker == nil {
+		*tracker = map[string]int{}
 	}
+	(*tracker)[tileName]++
+}
 
+// findMostCommonTile returns the tile name with the highest count in the tracker
+// Ties go to the tile that comes first in the registry
+func findMostCommonTile(tracker map[string]int) string {
 	maxCount := 0
 	mostCommon := ""
 
-	tiles := map[string]int{
-		TileBio:                  tracker.Bio,
-		TilePlayerInformation:    tracker.PlayerInformation,
-		TileDraftInformation:     tracker.DraftInformation,
-		TileTeamsPlayedOn:        tracker.TeamsPlayedOn,
-		TileJerseyNumbers:        tracker.JerseyNumbers,
-		TileCareerStats:          tracker.CareerStats,
-		TilePersonalAchievements: tracker.PersonalAchievements,
-		TilePhoto:                tracker.Photo,
-		TileYearsActive:          tracker.YearsActive,
-		TileInitials:             tracker.Initials,
-		TileNicknames:            tracker.Nicknames,
-	}
-
-	for tileName, count := range tiles {
-		if count > maxCount {
+	for _, tileName := range AllTiles() {
+		if count := tracker[tileName]; count > maxCount {
 			maxCount = count
 			mostCommon = tileName
 		}
@@ -65,31 +96,18 @@ func findMostCommonTile(tracker *TileFlipTracker) string {
 	return mostCommon
 }
 
-// findLeastCommonTile returns the tile name with the lowest non-zero count in the tracker
-func findLeastCommonTile(tracker *TileFlipTracker) string {
-	if tracker == nil {
+// findLeastCommonTile returns the tile name with the lowest count in the tracker
+// Tiles missing from the tracker have never been flipped and count as 0. Ties go to the tile that comes first in the registry
+func findLeastCommonTile(tracker map[string]int) string {
+	if len(tracker) == 0 {
 		return ""
 	}
 
 	minCount := -1
 	leastCommon := ""
 
-	tiles := map[string]int{
-		TileBio:                  tracker.Bio,
-		TilePlayerInformation:    tracker.PlayerInformation,
-		TileDraftInformation:     tracker.DraftInformation,
-		TileTeamsPlayedOn:        tracker.TeamsPlayedOn,
-		TileJerseyNumbers:        tracker.JerseyNumbers,
-		TileCareerStats:          tracker.CareerStats,
-		TilePersonalAchievements: tracker.PersonalAchievements,
-		TilePhoto:                tracker.Photo,
-		TileYearsActive:          tracker.YearsActive,
-		TileInitials:             tracker.Initials,
-		TileNicknames:            tracker.Nicknames,
-	}
-
-	for tileName, count := range tiles {
-		if count >= 0 && (minCount == -1 || count < minCount) {
+	for _, tileName := range AllTiles() {
+		if count := tracker[tileName]; minCount == -1 || count < minCount {
 			minCount = count
 			leastCommon = tileName
 		}
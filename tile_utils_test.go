@@ -1,140 +1,109 @@
 package main
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTileRegistry(t *testing.T) {
+	player := &Player{}
+	seenKeys := map[string]bool{}
+	seenFields := map[*string]string{}
+	for _, tile := range tileRegistry {
+		if tile.Key == "" || seenKeys[tile.Key] {
+			t.Errorf("tile key %q is empty or repeated", tile.Key)
+		}
+		seenKeys[tile.Key] = true
+
+		if tile.Field == nil {
+			t.Errorf("tile %q has no field", tile.Key)
+			continue
+		}
+		field := tile.Field(player)
+		if other, ok := seenFields[field]; ok {
+			t.Errorf("tiles %q and %q share a Player field", tile.Key, other)
+		}
+		seenFields[field] = tile.Key
+	}
+
+	// PatchRound updates player.<key> in place, so each key must be its field's stored attribute name
+	value := reflect.ValueOf(player).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		tileKey, ok := seenFields[value.Field(i).Addr().Interface().(*string)]
+		if tag := value.Type().Field(i).Tag.Get("dynamodbav"); ok && tag != tileKey {
+			t.Errorf("tile %q is stored as %q", tileKey, tag)
+		}
+	}
+
+	// Only teamsPlayedOn is filled by another tile's scraper
+	for _, tile := range tileRegistry {
+		if (tile.Scrape == nil) != (tile.Key == TileTeamsPlayedOn) {
+			t.Errorf("tile %q has scraper %v", tile.Key, tile.Scrape != nil)
+		}
+	}
+
+	if len(AllTiles()) != len(tileRegistry) || AllTiles()[0] != TileBio {
+		t.Errorf("AllTiles() = %v, want the registry keys in order", AllTiles())
+	}
+}
 
 func TestIncrementTileTracker(t *testing.T) {
 	tests := []struct {
-		name       string
-		tracker    *TileFlipTracker
-		tileName   string
-		wantNil    bool
-		checkField func(*TileFlipTracker) int
-		expected   int
+		name     string
+		tracker  map[string]int
+		tileName string
+		expected map[string]int
 	}{
 		{
-			name:     "nil tracker",
+			name:     "creates the tracker",
 			tracker:  nil,
-			tileName: "bio",
-			wantNil:  true,
-		},
-		{
-			name:     "empty tile name",
-			tracker:  &TileFlipTracker{},
-			tileName: "",
-			checkField: func(t *TileFlipTracker) int {
-				return t.Bio
-			},
-			expected: 0,
-		},
-		{
-			name:     "increment bio",
-			tracker:  &TileFlipTracker{},
-			tileName: "bio",
-			checkField: func(t *TileFlipTracker) int {
-				return t.Bio
-			},
-			expected: 1,
+			tileName: TileBio,
+			expected: map[string]int{TileBio: 1},
 		},
 		{
-			name:     "increment playerInformation",
-			tracker:  &TileFlipTracker{},
-			tileName: "playerInformation",
-			checkField: func(t *TileFlipTracker) int {
-				return t.PlayerInformation
-			},
-			expected: 1,
-		},
-		{
-			name:     "increment draftInformation",
-			tracker:  &TileFlipTracker{},
-			tileName: "draftInformation",
-			checkField: func(t *TileFlipTracker) int {
-				return t.DraftInformation
-			},
-			expected: 1,
-		},
-		{
-			name:     "increment teamsPlayedOn",
-			tracker:  &TileFlipTracker{},
-			tileName: "teamsPlayedOn",
-			checkField: func(t *TileFlipTracker) int {
-				return t.TeamsPlayedOn
-			},
-			expected: 1,
-		},
-		{
-			name:     "increment jerseyNumbers",
-			tracker:  &TileFlipTracker{},
-			tileName: "jerseyNumbers",
-			checkField: func(t *TileFlipTracker) int {
-				return t.JerseyNumbers
-			},
-			expected: 1,
-		},
-		{
-			name:     "increment careerStats",
-			tracker:  &TileFlipTracker{},
-			tileName: "careerStats",
-			checkField: func(t *TileFlipTracker) int {
-				return t.CareerStats
-			},
-			expected: 1,
-		},
-		{
-			name:     "increment personalAchievements",
-			tracker:  &TileFlipTracker{},
-			tileName: "personalAchievements",
-			checkField: func(t *TileFlipTracker) int {
-				return t.PersonalAchievements
-			},
-			expected: 1,
+			name:     "increments an existing count",
+			tracker:  map[string]int{TilePhoto: 2, TileBio: 1},
+			tileName: TilePhoto,
+			expected: map[string]int{TilePhoto: 3, TileBio: 1},
 		},
 		{
-			name:     "increment photo",
-			tracker:  &TileFlipTracker{},
-			tileName: "photo",
-			checkField: func(t *TileFlipTracker) int {
-				return t.Photo
-			},
-			expected: 1,
+			name:     "adds a missing tile",
+			tracker:  map[string]int{TileBio: 1},
+			tileName: TileNicknames,
+			expected: map[string]int{TileBio: 1, TileNicknames: 1},
 		},
 		{
-			name:     "increment yearsActive",
-			tracker:  &TileFlipTracker{},
-			tileName: "yearsActive",
-			checkField: func(t *TileFlipTracker) int {
-				return t.YearsActive
-			},
-			expected: 1,
+			name:     "empty tile name",
+			tracker:  map[string]int{},
+			tileName: "",
+			expected: map[string]int{},
 		},
 		{
 			name:     "invalid tile name",
-			tracker:  &TileFlipTracker{},
+			tracker:  map[string]int{},
 			tileName: "invalidTile",
-			checkField: func(t *TileFlipTracker) int {
-				return t.Bio
-			},
-			expected: 0,
+			expected: map[string]int{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			incrementTileTracker(tt.tracker, tt.tileName)
-			if tt.wantNil {
-				return
-			}
-			if got := tt.checkField(tt.tracker); got != tt.expected {
-				t.Errorf("incrementTileTracker() = %v, want %v", got, tt.expected)
+			tracker := tt.tracker
+			incrementTileTracker(&tracker, tt.tileName)
+			if !reflect.DeepEqual(tracker, tt.expected) {
+				t.Errorf("incrementTileTracker() = %v, want %v", tracker, tt.expected)
 			}
 		})
 	}
+
+	// A nil pointer is ignored
+	incrementTileTracker(nil, TileBio)
 }
 
 func TestFindMostCommonTile(t *testing.T) {
 	tests := []struct {
 		name    string
-		tracker *TileFlipTracker
+		tracker map[string]int
 		want    string
 	}{
 		{
@@ -143,39 +112,29 @@ func TestFindMostCommonTile(t *testing.T) {
 			want:    "",
 		},
 		{
-			name:    "empty tracker",
-			tracker: &TileFlipTracker{},
+			name:    "all zero",
+			tracker: map[string]int{TileBio: 0, TilePhoto: 0},
 			want:    "",
 		},
 		{
 			name: "bio is most common",
-			tracker: &TileFlipTracker{
-				Bio:                  10,
-				PlayerInformation:    5,
-				DraftInformation:     3,
-				TeamsPlayedOn:        2,
-				JerseyNumbers:        1,
-				CareerStats:          4,
-				PersonalAchievements: 6,
-				Photo:                7,
-				YearsActive:          8,
+			tracker: map[string]int{
+				TileBio:               10,
+				TilePlayerInformation: 5,
+				TilePhoto:             7,
+				TileYearsActive:       8,
 			},
-			want: "bio",
+			want: TileBio,
 		},
 		{
-			name: "yearsActive is most common",
-			tracker: &TileFlipTracker{
-				Bio:                  1,
-				PlayerInformation:    2,
-				DraftInformation:     3,
-				TeamsPlayedOn:        4,
-				JerseyNumbers:        5,
-				CareerStats:          6,
-				PersonalAchievements: 7,
-				Photo:                8,
-				YearsActive:          15,
-			},
-			want: "yearsActive",
+			name:    "ties go to the first tile in the registry",
+			tracker: map[string]int{TileNicknames: 4, TileJerseyNumbers: 4, TileBio: 1},
+			want:    TileJerseyNumbers,
+		},
+		{
+			name:    "unknown tiles are ignored",
+			tracker: map[string]int{"retiredTile": 100, TileYearsActive: 15},
+			want:    TileYearsActive,
 		},
 	}
 
@@ -189,86 +148,98 @@ func TestFindMostCommonTile(t *testing.T) {
 }
 
 func TestFindLeastCommonTile(t *testing.T) {
+	allFlipped := map[string]int{}
+	for i, tile := range AllTiles() {
+		allFlipped[tile] = 20 - i
+	}
+
 	tests := []struct {
-		name      string
-		tracker   *TileFlipTracker
-		want      string
-		doNotWant string
+		name    string
+		tracker map[string]int
+		want    string
 	}{
 		{
-			name:      "nil tracker",
-			tracker:   nil,
-			want:      "",
-			doNotWant: "",
+			name:    "nil tracker",
+			tracker: nil,
+			want:    "",
 		},
 		{
-			name: "jerseyNumbers is least common",
-			tracker: &TileFlipTracker{
-				Bio:                  10,
-				PlayerInformation:    5,
-				DraftInformation:     3,
-				TeamsPlayedOn:        2,
-				JerseyNumbers:        1,
-				CareerStats:          4,
-				PersonalAchievements: 6,
-				Photo:                7,
-				YearsActive:          8,
-				Initials:             11,
-				Nicknames:            12,
-			},
-			want:      "jerseyNumbers",
-			doNotWant: "",
+			name:    "lowest count",
+			tracker: allFlipped,
+			want:    TileNicknames,
 		},
 		{
-			name: "years active is least common",
-			tracker: &TileFlipTracker{
-				Bio:                  1,
-				PlayerInformation:    2,
-				DraftInformation:     3,
-				TeamsPlayedOn:        4,
-				JerseyNumbers:        5,
-				CareerStats:          6,
-				PersonalAchievements: 7,
-				Photo:                8,
-				YearsActive:          0,
-				Initials:             10,
-				Nicknames:            11,
-			},
-			want:      "yearsActive",
-			doNotWant: "",
+			name:    "tiles missing from the tracker count as zero",
+			tracker: map[string]int{TileBio: 10, TilePlayerInformation: 5},
+			want:    TileDraftInformation,
 		},
 		{
-			name: "some fields are zero",
-			tracker: &TileFlipTracker{
-				Bio:                  0,
-				PlayerInformation:    0,
-				DraftInformation:     5,
-				TeamsPlayedOn:        0,
-				JerseyNumbers:        3,
-				CareerStats:          0,
-				PersonalAchievements: 0,
-				Photo:                0,
-				YearsActive:          0,
-				Initials:             10,
-				Nicknames:            11,
+			name: "explicit zero",
+			tracker: map[string]int{
+				TileBio: 1, TilePlayerInformation: 2, TileDraftInformation: 3, TileTeamsPlayedOn: 4, TileJerseyNumbers: 5,
+				TileCareerStats: 6, TilePersonalAchievements: 7, TilePhoto: 8, TileYearsActive: 0, TileInitials: 10, TileNicknames: 11,
 			},
-			want:      "jerseyNumbers",
-			doNotWant: "nicknames",
+			want: TileYearsActive,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := findLeastCommonTile(tt.tracker)
-			if tt.doNotWant != "" {
-				if got == tt.doNotWant {
-					t.Errorf("findLeastCommonTile() = %v, do NOT want %v", got, tt.doNotWant)
-				}
-			} else {
-				if got != tt.want {
-					t.Errorf("findLeastCommonTile() = %v, want %v", got, tt.want)
-				}
+			if got := findLeastCommonTile(tt.tracker); got != tt.want {
+				t.Errorf("findLeastCommonTile() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestGetTileValue(t *testing.T) {
+	player := &Player{
+		Name:                 "LeBron James",
+		Bio:                  "Born: Dec 30, 1984",
+		PlayerInformation:    "Position: SF",
+		DraftInformation:     "2003: 1st Rd (1st Ovr)",
+		YearsActive:          "2003-Present",
+		TeamsPlayedOn:        "CLE, MIA, LAL",
+		JerseyNumbers:        "23, 6",
+		CareerStats:          "27.1 PTS",
+		PersonalAchievements: "4x NBA Champ",
+		Photo:                "https://example.com/photo.jpg",
+		Initials:             "L.J.",
+		Nicknames:            "King James",
+	}
+
+	tests := []struct {
+		name          string
+		tileName      string
+		expectedValue string
+		expectedOk    bool
+	}{
+		{name: "bio", tileName: TileBio, expectedValue: player.Bio, expectedOk: true},
+		{name: "player information", tileName: TilePlayerInformation, expectedValue: player.PlayerInformation, expectedOk: true},
+		{name: "draft information", tileName: TileDraftInformation, expectedValue: player.DraftInformation, expectedOk: true},
+		{name: "teams played on", tileName: TileTeamsPlayedOn, expectedValue: player.TeamsPlayedOn, expectedOk: true},
+		{name: "jersey numbers", tileName: TileJerseyNumbers, expectedValue: player.JerseyNumbers, expectedOk: true},
+		{name: "career stats", tileName: TileCareerStats, expectedValue: player.CareerStats, expectedOk: true},
+		{name: "personal achievements", tileName: TilePersonalAchievements, expectedValue: player.PersonalAchievements, expectedOk: true},
+		{name: "photo", tileName: TilePhoto, expectedValue: player.Photo, expectedOk: true},
+		{name: "years active", tileName: TileYearsActive, expectedValue: player.YearsActive, expectedOk: true},
+		{name: "initials", tileName: TileInitials, expectedValue: player.Initials, expectedOk: true},
+		{name: "nicknames", tileName: TileNicknames, expectedValue: player.Nicknames, expectedOk: true},
+		{name: "name is not a tile", tileName: "name", expectedValue: "", expectedOk: false},
+		{name: "empty tile name", tileName: "", expectedValue: "", expectedOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := getTileValue(player, tt.tileName)
+			if value != tt.expectedValue || ok != tt.expectedOk {
+				t.Errorf("getTileValue(%q) = (%q, %v), want (%q, %v)", tt.tileName, value, ok, tt.expectedValue, tt.expectedOk)
+			}
+		})
+	}
+
+	// Test with nil player
+	if _, ok := getTileValue(nil, TileBio); ok {
+		t.Error("Expected nil player to return ok = false")
+	}
+}